@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tags (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create article_tags table
+CREATE TABLE article_tags (
+    article_id TEXT NOT NULL,
+    tag_id TEXT NOT NULL,
+    PRIMARY KEY (article_id, tag_id),
+    CONSTRAINT fk_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tag FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_article_tags_tag_id ON article_tags(tag_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_article_tags_tag_id;
+DROP TABLE IF EXISTS article_tags;
+DROP TABLE IF EXISTS tags;
+-- +goose StatementEnd
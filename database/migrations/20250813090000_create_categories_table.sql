@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE categories (
+    id TEXT PRIMARY KEY,
+    parent_id TEXT NULL,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+
+-- Primary category of an article
+ALTER TABLE articles ADD COLUMN category_id TEXT NULL;
+ALTER TABLE articles ADD CONSTRAINT fk_category FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_articles_category_id ON articles(category_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_articles_category_id;
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS fk_category;
+ALTER TABLE articles DROP COLUMN IF EXISTS category_id;
+DROP INDEX IF EXISTS idx_categories_parent_id;
+DROP TABLE IF EXISTS categories;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE articles ADD COLUMN slug TEXT NULL;
+ALTER TABLE articles ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Backfill existing rows; the id suffix keeps generated slugs unique
+UPDATE articles
+SET slug = trim(both '-' from lower(regexp_replace(title, '[^a-zA-Z0-9]+', '-', 'g'))) || '-' || left(id, 8),
+    updated_at = created_at;
+
+ALTER TABLE articles ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX idx_articles_slug ON articles(slug);
+
+-- Create article_slug_history table
+CREATE TABLE article_slug_history (
+    slug TEXT PRIMARY KEY,
+    article_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_article_slug_history_article_id ON article_slug_history(article_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_article_slug_history_article_id;
+DROP TABLE IF EXISTS article_slug_history;
+DROP INDEX IF EXISTS idx_articles_slug;
+ALTER TABLE articles DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS slug;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sanitized HTML rendering of the Markdown body; rows written before this
+-- migration keep an empty value and are rendered when read.
+ALTER TABLE articles ADD COLUMN body_html TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE articles DROP COLUMN IF EXISTS body_html;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE articles
+    ADD COLUMN excerpt TEXT NOT NULL DEFAULT '',
+    ADD COLUMN word_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN reading_time INTEGER NOT NULL DEFAULT 0;
+
+-- Approximate values for existing rows; they are recomputed on the next update.
+UPDATE articles SET
+    excerpt = LEFT(body, 200),
+    word_count = COALESCE(array_length(regexp_split_to_array(btrim(body), '\s+'), 1), 0);
+UPDATE articles SET reading_time = CEIL(word_count / 200.0) WHERE btrim(body) <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS excerpt,
+    DROP COLUMN IF EXISTS word_count,
+    DROP COLUMN IF EXISTS reading_time;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE comments (
+    id TEXT PRIMARY KEY,
+    article_id TEXT NOT NULL,
+    parent_id TEXT,
+    user_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    depth INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_comment_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_comment_parent FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE,
+    CONSTRAINT chk_comment_status CHECK (status IN ('pending', 'approved', 'spam'))
+);
+
+-- Threads are paged by their top-level comments, replies are loaded by parent.
+CREATE INDEX idx_comments_threads ON comments(article_id, created_at, id) WHERE parent_id IS NULL;
+CREATE INDEX idx_comments_parent_id ON comments(parent_id);
+
+-- Approved comments only, kept in step with the comments table by the repository.
+ALTER TABLE articles ADD COLUMN comment_count INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE articles DROP COLUMN IF EXISTS comment_count;
+DROP INDEX IF EXISTS idx_comments_parent_id;
+DROP INDEX IF EXISTS idx_comments_threads;
+DROP TABLE IF EXISTS comments;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE article_reactions (
+    article_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (article_id, user_id, type),
+    CONSTRAINT fk_reaction_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+-- Totals per reaction type, fed from counters in Redis so busy articles do not contend
+-- for a row lock on every reaction.
+CREATE TABLE article_reaction_counts (
+    article_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (article_id, type),
+    CONSTRAINT fk_reaction_count_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+CREATE TABLE article_bookmarks (
+    user_id TEXT NOT NULL,
+    article_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, article_id),
+    CONSTRAINT fk_bookmark_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_article_bookmarks_article_id ON article_bookmarks(article_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_article_bookmarks_article_id;
+DROP TABLE IF EXISTS article_bookmarks;
+DROP TABLE IF EXISTS article_reaction_counts;
+DROP TABLE IF EXISTS article_reactions;
+-- +goose StatementEnd
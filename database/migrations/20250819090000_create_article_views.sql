@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Unique visitors per article and day, rolled up from HyperLogLogs in Redis.
+CREATE TABLE article_views (
+    article_id TEXT NOT NULL,
+    day DATE NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (article_id, day),
+    CONSTRAINT fk_view_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_article_views_day ON article_views(day);
+
+ALTER TABLE articles ADD COLUMN view_count BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE articles DROP COLUMN IF EXISTS view_count;
+DROP INDEX IF EXISTS idx_article_views_day;
+DROP TABLE IF EXISTS article_views;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Type-ahead matches titles and author names by word prefix.
+CREATE INDEX idx_articles_title_trgm ON articles USING GIN (lower(title) gin_trgm_ops);
+CREATE INDEX idx_authors_name_trgm ON authors USING GIN (lower(name) gin_trgm_ops);
+
+-- The words of every article, for "did you mean" suggestions. Refreshed periodically
+-- by the server.
+CREATE MATERIALIZED VIEW search_words AS
+SELECT word, ndoc
+FROM ts_stat($$SELECT to_tsvector('simple', title || ' ' || body) FROM articles$$)
+WHERE length(word) BETWEEN 3 AND 40;
+
+CREATE UNIQUE INDEX idx_search_words_word ON search_words(word);
+CREATE INDEX idx_search_words_trgm ON search_words USING GIN (word gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP MATERIALIZED VIEW IF EXISTS search_words;
+DROP INDEX IF EXISTS idx_authors_name_trgm;
+DROP INDEX IF EXISTS idx_articles_title_trgm;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The text search configuration of an article language. Languages without one are
+-- searched unstemmed.
+CREATE FUNCTION article_search_config(language TEXT) RETURNS regconfig
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT CASE language
+        WHEN 'en' THEN 'english'::regconfig
+        WHEN 'id' THEN 'indonesian'::regconfig
+        ELSE 'simple'::regconfig
+    END
+$$;
+
+-- Translations of an article share its translation_id, which is the id of the
+-- article first written; each language appears once per translation.
+ALTER TABLE articles
+    ADD COLUMN language TEXT NOT NULL DEFAULT 'en',
+    ADD COLUMN translation_id TEXT;
+UPDATE articles SET translation_id = id;
+ALTER TABLE articles ALTER COLUMN translation_id SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_articles_translation_language ON articles(translation_id, language);
+CREATE INDEX idx_articles_language ON articles(language);
+
+DROP INDEX IF EXISTS idx_articles_title_body_search;
+DROP INDEX IF EXISTS idx_articles_body_search;
+DROP INDEX IF EXISTS idx_articles_title_search;
+CREATE INDEX idx_articles_search ON articles
+    USING GIN (to_tsvector(article_search_config(language), title || ' ' || body));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_articles_search;
+CREATE INDEX idx_articles_title_search ON articles USING GIN (to_tsvector('english', title));
+CREATE INDEX idx_articles_body_search ON articles USING GIN (to_tsvector('english', body));
+CREATE INDEX idx_articles_title_body_search ON articles USING GIN (to_tsvector('english', title || ' ' || body));
+
+DROP INDEX IF EXISTS idx_articles_language;
+DROP INDEX IF EXISTS idx_articles_translation_language;
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS translation_id,
+    DROP COLUMN IF EXISTS language;
+
+DROP FUNCTION IF EXISTS article_search_config(TEXT);
+-- +goose StatementEnd
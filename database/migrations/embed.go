@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations so the binary runs them without the
+// migration files on disk.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
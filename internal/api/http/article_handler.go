@@ -1,24 +1,39 @@
 package handler
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/bagasss3/go-article/internal/articleio"
 	"github.com/bagasss3/go-article/internal/config"
 	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/logger"
 	"github.com/bagasss3/go-article/pkg/model"
 	"github.com/bagasss3/go-article/pkg/response"
 	"github.com/labstack/echo/v4"
-
-	log "github.com/sirupsen/logrus"
 )
 
 type articleHandler struct {
 	articleService model.ArticleMethodService
+	viewService    model.ViewMethodService
+	searchService  model.SearchMethodService
 }
 
-func NewArticleHandler(articleService model.ArticleMethodService) *articleHandler {
+func NewArticleHandler(articleService model.ArticleMethodService, viewService model.ViewMethodService, searchService model.SearchMethodService) *articleHandler {
 	return &articleHandler{
 		articleService: articleService,
+		viewService:    viewService,
+		searchService:  searchService,
 	}
 }
 
@@ -27,23 +42,69 @@ func (h *articleHandler) Register(g *echo.Group) {
 	{
 		api.GET("", h.getAll)
 		api.POST("", h.create)
+		api.POST("\\:batchImport", h.batchImport)
+		api.GET("/export", h.export)
+		api.GET("/trending", h.getTrending)
+		api.GET("/slug/:slug", h.getBySlug)
+		api.GET("/:id", h.getByID)
+		api.GET("/:id/related", h.getRelated)
+		api.PUT("/:id", h.update)
 	}
 }
 func (h *articleHandler) getAll(c echo.Context) error {
 	var query model.ArticleQuery
 
 	if err := c.Bind(&query); err != nil {
-		log.Error(err)
+		logger.FromContext(c.Request().Context()).Error(err)
 		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
 	}
 
+	searching := strings.TrimSpace(query.Query) != ""
+
+	// The facets of a search are counted alongside the page of results. The echo
+	// context is recycled once the handler returns, so the count only sees ctx, and is
+	// cancelled when the handler returns without waiting for it.
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	var facets chan *model.Facets
+	if searching {
+		facets = make(chan *model.Facets, 1)
+		go func(query model.ArticleQuery) {
+			result, err := h.searchService.Facets(ctx, query)
+			if err != nil {
+				logger.FromContext(ctx).WithError(err).Warn("failed to count search facets")
+			}
+			facets <- result
+		}(query)
+	}
+
 	articles, total, err := h.articleService.FindAll(c.Request().Context(), query)
 	if err != nil {
-		log.Error(err)
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	data, err := helper.SelectFields(articles, helper.ParseFields(query.Fields))
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
 		return handleError(c, err)
 	}
 
-	return response.ResponseInterfaceTotal(c, http.StatusOK, articles, "List Article", int(total))
+	if !searching {
+		return response.ResponseInterfaceTotal(c, http.StatusOK, data, "List Article", int(total))
+	}
+
+	meta := model.SearchMeta{Facets: <-facets}
+	if total == 0 {
+		// A search that found nothing still answers; the suggestion is a bonus.
+		meta.DidYouMean, err = h.searchService.DidYouMean(c.Request().Context(), query.Query)
+		if err != nil {
+			logger.FromContext(c.Request().Context()).WithError(err).Warn("failed to suggest a search")
+		}
+	}
+
+	return response.ResponseInterfaceSearch(c, http.StatusOK, data, "List Article", int(total), meta)
 }
 
 func (h *articleHandler) create(c echo.Context) error {
@@ -59,9 +120,289 @@ func (h *articleHandler) create(c echo.Context) error {
 
 	result, err := h.articleService.Create(c.Request().Context(), req)
 	if err != nil {
-		log.Error(err)
+		logger.FromContext(c.Request().Context()).Error(err)
 		return handleError(c, err)
 	}
 
 	return response.ResponseInterface(c, http.StatusCreated, result, "Store Article")
 }
+
+func (h *articleHandler) getByID(c echo.Context) error {
+	var opts model.ArticleReadOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &opts); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+	opts.Languages = preferredLanguages(c)
+
+	result, err := h.articleService.FindByID(c.Request().Context(), c.Param("id"), opts)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	data, err := helper.SelectFields(result, helper.ParseFields(opts.Fields))
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	h.recordView(c, result)
+	setContentLanguage(c, result)
+
+	return response.ResponseInterface(c, http.StatusOK, data, "Find Article By ID")
+}
+
+func (h *articleHandler) getBySlug(c echo.Context) error {
+	var opts model.ArticleReadOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &opts); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+	opts.Languages = preferredLanguages(c)
+
+	slug := c.Param("slug")
+	result, canonical, err := h.articleService.FindBySlug(c.Request().Context(), slug, opts)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	if canonical != slug {
+		location := path.Join(path.Dir(c.Request().URL.Path), canonical)
+		if c.QueryString() != "" {
+			location += "?" + c.QueryString()
+		}
+		return c.Redirect(http.StatusMovedPermanently, location)
+	}
+
+	data, err := helper.SelectFields(result, helper.ParseFields(opts.Fields))
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	h.recordView(c, result)
+	setContentLanguage(c, result)
+
+	return response.ResponseInterface(c, http.StatusOK, data, "Find Article By Slug")
+}
+
+func (h *articleHandler) getRelated(c echo.Context) error {
+	var query model.RelatedQuery
+	if err := c.Bind(&query); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	articles, err := h.articleService.FindRelated(c.Request().Context(), c.Param("id"), query)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	data, err := helper.SelectFields(articles, helper.ParseFields(query.Fields))
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, data, "List Related Article")
+}
+
+// recordView counts the caller as a viewer of article: by the X-User-ID header when
+// present, by client IP otherwise. Failing to count never fails the read.
+func (h *articleHandler) recordView(c echo.Context, article *model.Article) {
+	visitor := "ip:" + c.RealIP()
+	if id := userID(c); id != "" {
+		visitor = "user:" + id
+	}
+
+	if err := h.viewService.Record(c.Request().Context(), article.ID, visitor); err != nil {
+		logger.FromContext(c.Request().Context()).WithError(err).Warn("failed to record article view")
+	}
+}
+
+// preferredLanguages lists the languages a single article is read in, most preferred
+// first: the lang query parameter, then those of the Accept-Language header.
+func preferredLanguages(c echo.Context) []string {
+	var languages []string
+	if lang := strings.ToLower(strings.TrimSpace(c.QueryParam("lang"))); lang != "" {
+		languages = append(languages, lang)
+	}
+	for _, lang := range helper.ParseAcceptLanguage(c.Request().Header.Get("Accept-Language")) {
+		if !slices.Contains(languages, lang) {
+			languages = append(languages, lang)
+		}
+	}
+	return languages
+}
+
+// setContentLanguage tells caches the response depends on Accept-Language and
+// clients which language they were served.
+func setContentLanguage(c echo.Context, article *model.Article) {
+	header := c.Response().Header()
+	header.Add(echo.HeaderVary, "Accept-Language")
+	if article.Language != "" {
+		header.Set("Content-Language", article.Language)
+	}
+}
+
+func (h *articleHandler) getTrending(c echo.Context) error {
+	var query model.TrendingQuery
+	if err := c.Bind(&query); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	articles, err := h.viewService.Trending(c.Request().Context(), query)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	data, err := helper.SelectFields(articles, helper.ParseFields(query.Fields))
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, data, "List Trending Article")
+}
+
+func (h *articleHandler) update(c echo.Context) error {
+	var req *model.UpdateArticleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, config.BadRequest, helper.GetValueBetween(err.Error(), "Error:", "tag"))
+	}
+
+	result, err := h.articleService.Update(c.Request().Context(), c.Param("id"), req)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, result, "Update Article")
+}
+
+// importFormats maps the media types accepted by batchImport to their format.
+var importFormats = map[string]string{
+	"application/x-ndjson": model.TransferFormatNDJSON,
+	"application/jsonl":    model.TransferFormatNDJSON,
+	"text/csv":             model.TransferFormatCSV,
+	"application/zip":      model.TransferFormatMarkdown,
+}
+
+func (h *articleHandler) batchImport(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+		format = importFormats[mediaType]
+	}
+	if !slices.Contains(model.TransferFormats, format) {
+		return response.ResponseInterfaceError(c, http.StatusBadRequest,
+			fmt.Sprintf("format must be one of %s", strings.Join(model.TransferFormats, ", ")), config.BadRequest)
+	}
+
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, config.ImportMaxBytes())
+
+	var rows model.ArticleImportReader
+	switch format {
+	case model.TransferFormatNDJSON:
+		rows = articleio.NewNDJSONReader(body)
+	case model.TransferFormatCSV:
+		rows = articleio.NewCSVReader(body)
+	case model.TransferFormatMarkdown:
+		// A zip archive is read from its end, so it has to be in memory first.
+		archive, err := io.ReadAll(body)
+		if err != nil {
+			logger.FromContext(c.Request().Context()).Error(err)
+			return importBodyError(c, err)
+		}
+		fsys, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return response.ResponseInterfaceError(c, http.StatusBadRequest, "invalid zip archive: "+err.Error(), config.BadRequest)
+		}
+		rows = articleio.NewMarkdownReader(fsys)
+	}
+
+	result, err := h.articleService.Import(c.Request().Context(), rows)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return importBodyError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, result, "Import Article")
+}
+
+// importBodyError reports an import that failed as a whole, telling an oversized body
+// apart from other errors.
+func importBodyError(c echo.Context, err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return response.ResponseInterfaceError(c, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("the import must not exceed %d bytes", maxBytesErr.Limit), config.BadRequest)
+	}
+	return handleError(c, err)
+}
+
+func (h *articleHandler) export(c echo.Context) error {
+	var query model.ArticleQuery
+	if err := c.Bind(&query); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = model.TransferFormatNDJSON
+	}
+	w, err := articleio.NewWriter(format, &exportResponse{c: c, format: format})
+	if err != nil {
+		return response.ResponseInterfaceError(c, http.StatusBadRequest,
+			fmt.Sprintf("format must be one of %s", strings.Join(model.TransferFormats, ", ")), config.BadRequest)
+	}
+
+	err = h.articleService.Export(c.Request().Context(), query, w)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		if c.Response().Committed {
+			// The status is already sent; the client sees a truncated download.
+			return nil
+		}
+		return handleError(c, err)
+	}
+
+	if !c.Response().Committed {
+		writeExportHeader(c, format)
+	}
+
+	return nil
+}
+
+// exportResponse sends the download headers with the first byte of an export, so an
+// error before that can still be answered with a JSON error.
+type exportResponse struct {
+	c      echo.Context
+	format string
+}
+
+func (w *exportResponse) Write(p []byte) (int, error) {
+	if !w.c.Response().Committed {
+		writeExportHeader(w.c, w.format)
+	}
+	return w.c.Response().Write(p)
+}
+
+func writeExportHeader(c echo.Context, format string) {
+	filename := fmt.Sprintf("articles-%s%s", time.Now().UTC().Format("20060102-150405"), articleio.Extension(format))
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, articleio.ContentType(format))
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().WriteHeader(http.StatusOK)
+}
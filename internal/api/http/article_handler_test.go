@@ -1,13 +1,19 @@
 package handler
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	customErr "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/middleware"
 	"github.com/bagasss3/go-article/pkg/model"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -25,11 +31,66 @@ func (m *MockArticleService) FindAll(ctx context.Context, query model.ArticleQue
 	return args.Get(0).([]*model.Article), args.Int(1), args.Error(2)
 }
 
+func (m *MockArticleService) FindByID(ctx context.Context, id string, opts model.ArticleReadOptions) (*model.Article, error) {
+	args := m.Called(ctx, id, opts)
+	return args.Get(0).(*model.Article), args.Error(1)
+}
+
+func (m *MockArticleService) FindBySlug(ctx context.Context, slug string, opts model.ArticleReadOptions) (*model.Article, string, error) {
+	args := m.Called(ctx, slug, opts)
+	return args.Get(0).(*model.Article), args.String(1), args.Error(2)
+}
+
+func (m *MockArticleService) Update(ctx context.Context, id string, req *model.UpdateArticleRequest) (*model.Article, error) {
+	args := m.Called(ctx, id, req)
+	return args.Get(0).(*model.Article), args.Error(1)
+}
+
 func (m *MockArticleService) Create(ctx context.Context, req *model.CreateArticleRequest) (*model.Article, error) {
 	args := m.Called(ctx, req)
 	return args.Get(0).(*model.Article), args.Error(1)
 }
 
+func (m *MockArticleService) Import(ctx context.Context, rows model.ArticleImportReader) (*model.ArticleImportResult, error) {
+	args := m.Called(ctx, rows)
+	return args.Get(0).(*model.ArticleImportResult), args.Error(1)
+}
+
+func (m *MockArticleService) Export(ctx context.Context, filter model.ArticleQuery, w model.ArticleExportWriter) error {
+	args := m.Called(ctx, filter, w)
+	return args.Error(0)
+}
+
+func (m *MockArticleService) FindRelated(ctx context.Context, id string, query model.RelatedQuery) ([]*model.Article, error) {
+	args := m.Called(ctx, id, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Article), args.Error(1)
+}
+
+type MockViewService struct {
+	mock.Mock
+}
+
+func (m *MockViewService) Record(ctx context.Context, articleID uuid.UUID, visitor string) error {
+	args := m.Called(ctx, articleID, visitor)
+	return args.Error(0)
+}
+
+func (m *MockViewService) Flush(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockViewService) Trending(ctx context.Context, query model.TrendingQuery) ([]*model.Article, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Article), args.Error(1)
+}
+
 func TestArticleHandler_Create(t *testing.T) {
 	e := echo.New()
 	validator := validator.New()
@@ -37,7 +98,7 @@ func TestArticleHandler_Create(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		service := new(MockArticleService)
-		handler := NewArticleHandler(service)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
 
 		authorID := uuid.New().String()
 		body := `{"author_id":"` + authorID + `","title":"Test Title","body":"Test Body"}`
@@ -62,7 +123,7 @@ func TestArticleHandler_Create(t *testing.T) {
 
 	t.Run("bind error", func(t *testing.T) {
 		service := new(MockArticleService)
-		handler := NewArticleHandler(service)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
 
 		body := `{"author_id":"invalid-json"`
 		req := httptest.NewRequest(http.MethodPost, "/article", strings.NewReader(body))
@@ -77,7 +138,7 @@ func TestArticleHandler_Create(t *testing.T) {
 
 	t.Run("validation error", func(t *testing.T) {
 		service := new(MockArticleService)
-		handler := NewArticleHandler(service)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
 
 		body := `{"title":"T","body":"B"}`
 		req := httptest.NewRequest(http.MethodPost, "/article", strings.NewReader(body))
@@ -92,7 +153,7 @@ func TestArticleHandler_Create(t *testing.T) {
 
 	t.Run("service error", func(t *testing.T) {
 		service := new(MockArticleService)
-		handler := NewArticleHandler(service)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
 
 		authorID := uuid.New().String()
 		body := `{"author_id":"` + authorID + `","title":"Test","body":"Content"}`
@@ -114,7 +175,7 @@ func TestArticleHandler_GetAll(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		service := new(MockArticleService)
-		handler := NewArticleHandler(service)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
 
 		req := httptest.NewRequest(http.MethodGet, "/article?page=1&limit=10", nil)
 		rec := httptest.NewRecorder()
@@ -140,7 +201,7 @@ func TestArticleHandler_GetAll(t *testing.T) {
 
 	t.Run("validation error", func(t *testing.T) {
 		service := new(MockArticleService)
-		handler := NewArticleHandler(service)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
 
 		body := `{"title":"T","body":"B"}`
 		req := httptest.NewRequest(http.MethodPost, "/article", strings.NewReader(body))
@@ -153,9 +214,27 @@ func TestArticleHandler_GetAll(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
+	t.Run("selected fields", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodGet, "/article?fields=id,title", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		id := uuid.New()
+		query := model.ArticleQuery{ArticleReadOptions: model.ArticleReadOptions{Fields: []string{"id,title"}}}
+		service.On("FindAll", mock.Anything, query).Return([]*model.Article{{ID: id, Title: "Test Title"}}, 1, nil)
+
+		err := handler.getAll(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"data":[{"id":"`+id.String()+`","title":"Test Title"}]`)
+	})
+
 	t.Run("bind error", func(t *testing.T) {
 		service := new(MockArticleService)
-		handler := NewArticleHandler(service)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
 
 		req := httptest.NewRequest(http.MethodGet, "/article?page=bad", nil)
 		rec := httptest.NewRecorder()
@@ -166,9 +245,82 @@ func TestArticleHandler_GetAll(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
+	t.Run("empty search suggests a respelling", func(t *testing.T) {
+		service := new(MockArticleService)
+		search := new(MockSearchService)
+		handler := NewArticleHandler(service, new(MockViewService), search)
+
+		req := httptest.NewRequest(http.MethodGet, "/article?query=postgress", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		service.On("FindAll", mock.Anything, model.ArticleQuery{Query: "postgress"}).Return([]*model.Article{}, 0, nil)
+		search.On("DidYouMean", mock.Anything, "postgress").Return("postgres", nil)
+		search.On("Facets", mock.Anything, model.ArticleQuery{Query: "postgress"}).Return(nil, errors.New("db error"))
+
+		require.NoError(t, handler.getAll(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"total":0`)
+		require.Contains(t, rec.Body.String(), `"did_you_mean":"postgres"`)
+		require.NotContains(t, rec.Body.String(), "facets")
+	})
+
+	t.Run("search with results", func(t *testing.T) {
+		service := new(MockArticleService)
+		search := new(MockSearchService)
+		handler := NewArticleHandler(service, new(MockViewService), search)
+
+		req := httptest.NewRequest(http.MethodGet, "/article?query=postgres", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		service.On("FindAll", mock.Anything, model.ArticleQuery{Query: "postgres"}).
+			Return([]*model.Article{{ID: uuid.New(), Title: "Postgres"}}, 1, nil)
+		search.On("Facets", mock.Anything, model.ArticleQuery{Query: "postgres"}).Return(&model.Facets{
+			Authors: []*model.FacetCount{{Value: uuid.NewString(), Label: "Gopher", Count: 1}},
+			Tags:    []*model.FacetCount{{Value: "databases", Label: "Databases", Count: 1}},
+			Months:  []*model.FacetCount{{Value: "2025-08", Count: 1}},
+		}, nil)
+
+		require.NoError(t, handler.getAll(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NotContains(t, rec.Body.String(), "did_you_mean")
+		require.Contains(t, rec.Body.String(), `"label":"Gopher"`)
+		require.Contains(t, rec.Body.String(), `"months":[{"value":"2025-08","count":1}]`)
+		search.AssertNotCalled(t, "DidYouMean", mock.Anything, mock.Anything)
+	})
+
+	t.Run("search error cancels the facet count", func(t *testing.T) {
+		service := new(MockArticleService)
+		search := new(MockSearchService)
+		handler := NewArticleHandler(service, new(MockViewService), search)
+
+		req := httptest.NewRequest(http.MethodGet, "/article?query=postgres", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var dummy []*model.Article
+		service.On("FindAll", mock.Anything, model.ArticleQuery{Query: "postgres"}).Return(dummy, 0, errors.New("db error"))
+		cancelled := make(chan struct{})
+		search.On("Facets", mock.Anything, model.ArticleQuery{Query: "postgres"}).
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+				close(cancelled)
+			}).
+			Return(nil, context.Canceled)
+
+		require.NoError(t, handler.getAll(c))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("facet count was not cancelled")
+		}
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		service := new(MockArticleService)
-		handler := NewArticleHandler(service)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
 
 		req := httptest.NewRequest(http.MethodGet, "/article?page=1&limit=10", nil)
 		rec := httptest.NewRecorder()
@@ -186,7 +338,7 @@ func TestArticleHandler_GetAll(t *testing.T) {
 
 func TestArticleHandler_Register(t *testing.T) {
 	service := new(MockArticleService)
-	handler := NewArticleHandler(service)
+	handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
 
 	e := echo.New()
 	g := e.Group("/api")
@@ -208,4 +360,447 @@ func TestArticleHandler_Register(t *testing.T) {
 	}
 	require.True(t, foundGetRoute, "GET route should be registered")
 	require.True(t, foundPostRoute, "POST route should be registered")
+
+	service.On("Import", mock.Anything, mock.Anything).Return(&model.ArticleImportResult{}, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/article:batchImport?format=ndjson", strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestArticleHandler_BatchImport(t *testing.T) {
+	e := echo.New()
+
+	t.Run("ndjson", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		body := `{"author":"Jane Doe","title":"First","body":"One"}` + "\n" + `{"author":"Jane Doe","title":"Second","body":"Two"}`
+		req := httptest.NewRequest(http.MethodPost, "/article:batchImport", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, "application/x-ndjson")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var titles []string
+		service.On("Import", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				rows := args.Get(1).(model.ArticleImportReader)
+				for {
+					row, err := rows.Next()
+					if err != nil {
+						require.ErrorIs(t, err, io.EOF)
+						break
+					}
+					titles = append(titles, row.Title)
+				}
+			}).
+			Return(&model.ArticleImportResult{Imported: 2, Errors: []model.ArticleImportError{}}, nil)
+
+		err := handler.batchImport(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, []string{"First", "Second"}, titles)
+		require.Contains(t, rec.Body.String(), `"imported":2`)
+	})
+
+	t.Run("markdown zip", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		var archive bytes.Buffer
+		zw := zip.NewWriter(&archive)
+		f, err := zw.Create("posts/hello.md")
+		require.NoError(t, err)
+		_, err = f.Write([]byte("---\nauthor: Jane Doe\ntitle: Hello\ntags: [go]\n---\n\n# Hello\n"))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/article:batchImport", &archive)
+		req.Header.Set(echo.HeaderContentType, "application/zip")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var row *model.ArticleImportRow
+		service.On("Import", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				row, _ = args.Get(1).(model.ArticleImportReader).Next()
+			}).
+			Return(&model.ArticleImportResult{Imported: 1}, nil)
+
+		err = handler.batchImport(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, row)
+		require.Equal(t, "posts/hello.md", row.Source)
+		require.Equal(t, "Hello", row.Title)
+		require.Equal(t, []string{"go"}, row.Tags)
+		require.Equal(t, "# Hello", row.Body)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodPost, "/article:batchImport", strings.NewReader("{}"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.batchImport(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		service.AssertNotCalled(t, "Import", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid zip", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodPost, "/article:batchImport?format=markdown", strings.NewReader("not a zip"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.batchImport(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodPost, "/article:batchImport?format=csv", strings.NewReader("title\nHello"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var dummy *model.ArticleImportResult
+		service.On("Import", mock.Anything, mock.Anything).
+			Return(dummy, customErr.New(customErr.ErrInvalidData, "CSV header is missing the author column"))
+
+		err := handler.batchImport(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestArticleHandler_Export(t *testing.T) {
+	e := echo.New()
+
+	t.Run("csv", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodGet, "/article/export?format=csv&tag=go", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		created := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+		service.On("Export", mock.Anything, mock.MatchedBy(func(q model.ArticleQuery) bool { return q.Tag == "go" }), mock.Anything).
+			Run(func(args mock.Arguments) {
+				w := args.Get(2).(model.ArticleExportWriter)
+				require.NoError(t, w.Write(&model.Article{
+					ID:        uuid.New(),
+					Author:    "Jane Doe",
+					Title:     "Hello",
+					Slug:      "hello",
+					Language:  "en",
+					Body:      "Hello, world",
+					Tags:      []string{"go", "testing"},
+					CreatedAt: created,
+					UpdatedAt: created,
+				}))
+				require.NoError(t, w.Close())
+			}).
+			Return(nil)
+
+		err := handler.export(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+		require.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), ".csv")
+
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		require.Equal(t, "id,author,title,slug,language,summary,category_id,tags,created_at,updated_at,body", lines[0])
+		require.Contains(t, lines[1], `Jane Doe,Hello,hello,en,,,"go,testing",2024-05-01T08:00:00Z`)
+	})
+
+	t.Run("empty ndjson", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodGet, "/article/export", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		service.On("Export", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		err := handler.export(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+		require.Empty(t, rec.Body.String())
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodGet, "/article/export?format=xml", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.export(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("service error before output", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodGet, "/article/export?sort=bogus", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		service.On("Export", mock.Anything, mock.Anything, mock.Anything).
+			Return(customErr.New(customErr.ErrInvalidData, "sort must be one of ..."))
+
+		err := handler.export(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+	})
+}
+
+func TestArticleHandler_GetBySlug(t *testing.T) {
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		service := new(MockArticleService)
+		views := new(MockViewService)
+		handler := NewArticleHandler(service, views, new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/article/slug/hello", nil)
+		req.Header.Set(middleware.HeaderUserID, "user-1")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("slug")
+		c.SetParamValues("hello")
+
+		article := &model.Article{ID: uuid.New(), Slug: "hello"}
+		service.On("FindBySlug", mock.Anything, "hello", model.ArticleReadOptions{}).Return(article, "hello", nil)
+		views.On("Record", mock.Anything, article.ID, "user:user-1").Return(nil)
+
+		require.NoError(t, handler.getBySlug(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		views.AssertExpectations(t)
+	})
+
+	t.Run("redirects old slug", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/article/slug/old?format=html", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("slug")
+		c.SetParamValues("old")
+
+		var dummy *model.Article
+		service.On("FindBySlug", mock.Anything, "old", model.ArticleReadOptions{Format: "html"}).Return(dummy, "new", nil)
+
+		require.NoError(t, handler.getBySlug(c))
+		require.Equal(t, http.StatusMovedPermanently, rec.Code)
+		require.Equal(t, "/api/v1/article/slug/new?format=html", rec.Header().Get(echo.HeaderLocation))
+	})
+
+	t.Run("negotiates language", func(t *testing.T) {
+		service := new(MockArticleService)
+		views := new(MockViewService)
+		handler := NewArticleHandler(service, views, new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/article/slug/hello", nil)
+		req.Header.Set("Accept-Language", "id-ID, en;q=0.8")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("slug")
+		c.SetParamValues("hello")
+
+		// The translation is served at the slug that was asked for, without a redirect.
+		article := &model.Article{ID: uuid.New(), Slug: "halo", Language: "id"}
+		opts := model.ArticleReadOptions{Languages: []string{"id", "en"}}
+		service.On("FindBySlug", mock.Anything, "hello", opts).Return(article, "hello", nil)
+		views.On("Record", mock.Anything, article.ID, mock.Anything).Return(nil)
+
+		require.NoError(t, handler.getBySlug(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "id", rec.Header().Get("Content-Language"))
+		require.Contains(t, rec.Body.String(), `"slug":"halo"`)
+	})
+}
+
+func TestArticleHandler_GetByID(t *testing.T) {
+	e := echo.New()
+	service := new(MockArticleService)
+	handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+	id := uuid.New().String()
+
+	req := httptest.NewRequest(http.MethodGet, "/article/"+id, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+
+	var dummy *model.Article
+	service.On("FindByID", mock.Anything, id, model.ArticleReadOptions{}).Return(dummy, customErr.New(customErr.ErrRecordNotFound, "article not found"))
+
+	require.NoError(t, handler.getByID(c))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestArticleHandler_GetByIDRecordsView(t *testing.T) {
+	e := echo.New()
+	service := new(MockArticleService)
+	views := new(MockViewService)
+	NewArticleHandler(service, views, new(MockSearchService)).Register(e.Group("/api/v1"))
+
+	article := &model.Article{ID: uuid.New(), Title: "Viewed"}
+	service.On("FindByID", mock.Anything, article.ID.String(), model.ArticleReadOptions{}).Return(article, nil)
+	views.On("Record", mock.Anything, article.ID, "ip:203.0.113.7").Return(errors.New("redis down"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/article/"+article.ID.String(), nil)
+	req.RemoteAddr = "203.0.113.7:4711"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// A failed count does not fail the read.
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"title":"Viewed"`)
+	views.AssertExpectations(t)
+}
+
+func TestArticleHandler_GetByIDLanguage(t *testing.T) {
+	e := echo.New()
+	service := new(MockArticleService)
+	views := new(MockViewService)
+	NewArticleHandler(service, views, new(MockSearchService)).Register(e.Group("/api/v1"))
+
+	article := &model.Article{ID: uuid.New(), Title: "Halo", Language: "id"}
+	opts := model.ArticleReadOptions{Languages: []string{"id", "en"}}
+	service.On("FindByID", mock.Anything, article.ID.String(), opts).Return(article, nil)
+	views.On("Record", mock.Anything, article.ID, mock.Anything).Return(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/article/"+article.ID.String()+"?lang=ID", nil)
+	req.Header.Set("Accept-Language", "en-US,id;q=0.5")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "id", rec.Header().Get("Content-Language"))
+	require.Equal(t, "Accept-Language", rec.Header().Get(echo.HeaderVary))
+	service.AssertExpectations(t)
+}
+
+func TestArticleHandler_GetRelated(t *testing.T) {
+	setup := func() (*echo.Echo, *MockArticleService) {
+		e := echo.New()
+		service := new(MockArticleService)
+		NewArticleHandler(service, new(MockViewService), new(MockSearchService)).Register(e.Group("/api/v1"))
+		return e, service
+	}
+	id := uuid.New().String()
+
+	t.Run("success", func(t *testing.T) {
+		e, service := setup()
+		service.On("FindRelated", mock.Anything, id, model.RelatedQuery{Limit: 3}).
+			Return([]*model.Article{{ID: uuid.New(), Title: "Close Match", Tags: []string{"go"}}}, nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/article/"+id+"/related?limit=3", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"title":"Close Match"`)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("unknown article", func(t *testing.T) {
+		e, service := setup()
+		service.On("FindRelated", mock.Anything, id, model.RelatedQuery{}).
+			Return(nil, customErr.New(customErr.ErrRecordNotFound, "article not found"))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/article/"+id+"/related", nil))
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestArticleHandler_GetTrending(t *testing.T) {
+	setup := func() (*echo.Echo, *MockViewService) {
+		e := echo.New()
+		views := new(MockViewService)
+		NewArticleHandler(new(MockArticleService), views, new(MockSearchService)).Register(e.Group("/api/v1"))
+		return e, views
+	}
+
+	t.Run("success", func(t *testing.T) {
+		e, views := setup()
+		views.On("Trending", mock.Anything, model.TrendingQuery{Window: "7d", Fields: []string{"id,title"}}).
+			Return([]*model.Article{{ID: uuid.New(), Title: "Hot", Excerpt: "Everyone reads it."}}, nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/article/trending?window=7d&fields=id,title", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"title":"Hot"`)
+		require.NotContains(t, rec.Body.String(), "excerpt")
+		views.AssertExpectations(t)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		e, views := setup()
+		views.On("Trending", mock.Anything, model.TrendingQuery{Window: "1y"}).
+			Return(nil, customErr.New(customErr.ErrInvalidData, "window must be one of 24h, 7d"))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/article/trending?window=1y", nil))
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestArticleHandler_Update(t *testing.T) {
+	e := echo.New()
+	e.Validator = &model.CustomValidator{Validator: validator.New()}
+
+	t.Run("success", func(t *testing.T) {
+		service := new(MockArticleService)
+		handler := NewArticleHandler(service, new(MockViewService), new(MockSearchService))
+		id := uuid.New().String()
+
+		req := httptest.NewRequest(http.MethodPut, "/article/"+id, strings.NewReader(`{"title":"New Title","body":"Body"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+
+		service.On("Update", mock.Anything, id, mock.Anything).Return(&model.Article{Title: "New Title", Slug: "new-title"}, nil)
+
+		require.NoError(t, handler.update(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		handler := NewArticleHandler(new(MockArticleService), new(MockViewService), new(MockSearchService))
+
+		req := httptest.NewRequest(http.MethodPut, "/article/x", strings.NewReader(`{"title":"T"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.update(c))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
 }
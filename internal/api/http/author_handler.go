@@ -5,10 +5,10 @@ import (
 
 	"github.com/bagasss3/go-article/internal/config"
 	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/logger"
 	"github.com/bagasss3/go-article/pkg/model"
 	"github.com/bagasss3/go-article/pkg/response"
 	"github.com/labstack/echo/v4"
-	log "github.com/sirupsen/logrus"
 )
 
 type authorHandler struct {
@@ -33,18 +33,18 @@ func (h *authorHandler) create(c echo.Context) error {
 	var req *model.CreateAuthorRequest
 
 	if err := c.Bind(&req); err != nil {
-		log.Error(err)
+		logger.FromContext(c.Request().Context()).Error(err)
 		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
 	}
 
 	if err := c.Validate(req); err != nil {
-		log.Error(err)
+		logger.FromContext(c.Request().Context()).Error(err)
 		return response.ResponseInterfaceError(c, http.StatusBadRequest, config.BadRequest, helper.GetValueBetween(err.Error(), "Error:", "tag"))
 	}
 
 	result, err := h.authorService.Create(c.Request().Context(), req)
 	if err != nil {
-		log.Error(err)
+		logger.FromContext(c.Request().Context()).Error(err)
 		return handleError(c, err)
 	}
 
@@ -54,11 +54,23 @@ func (h *authorHandler) create(c echo.Context) error {
 func (h *authorHandler) getByID(c echo.Context) error {
 	id := c.Param("id")
 
-	result, err := h.authorService.FindByID(c.Request().Context(), id)
+	var opts model.AuthorReadOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &opts); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	result, err := h.authorService.FindByID(c.Request().Context(), id, opts)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	data, err := helper.SelectFields(result, helper.ParseFields(opts.Fields))
 	if err != nil {
-		log.Error(err)
+		logger.FromContext(c.Request().Context()).Error(err)
 		return handleError(c, err)
 	}
 
-	return response.ResponseInterface(c, http.StatusOK, result, "Find Author By ID")
+	return response.ResponseInterface(c, http.StatusOK, data, "Find Author By ID")
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -26,8 +27,8 @@ func (m *MockAuthorService) Create(ctx context.Context, req *model.CreateAuthorR
 	return args.Get(0).(*model.Author), args.Error(1)
 }
 
-func (m *MockAuthorService) FindByID(ctx context.Context, id string) (*model.Author, error) {
-	args := m.Called(ctx, id)
+func (m *MockAuthorService) FindByID(ctx context.Context, id string, opts model.AuthorReadOptions) (*model.Author, error) {
+	args := m.Called(ctx, id, opts)
 	return args.Get(0).(*model.Author), args.Error(1)
 }
 
@@ -123,13 +124,33 @@ func TestAuthorHandler_GetByID(t *testing.T) {
 			ID:   uuid.MustParse(authorID),
 			Name: "Jane Doe",
 		}
-		service.On("FindByID", mock.Anything, authorID).Return(expected, nil)
+		service.On("FindByID", mock.Anything, authorID, model.AuthorReadOptions{}).Return(expected, nil)
 
 		err := handler.getByID(c)
 		require.NoError(t, err)
 		require.Equal(t, http.StatusOK, rec.Code)
 	})
 
+	t.Run("selected fields", func(t *testing.T) {
+		service := new(MockAuthorService)
+		handler := NewAuthorHandler(service)
+
+		authorID := uuid.New().String()
+		req := httptest.NewRequest(http.MethodGet, "/author/"+authorID+"?fields=name", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(authorID)
+
+		expected := &model.Author{ID: uuid.MustParse(authorID), Name: "Jane Doe"}
+		service.On("FindByID", mock.Anything, authorID, model.AuthorReadOptions{Fields: []string{"name"}}).Return(expected, nil)
+
+		err := handler.getByID(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"data":{"name":"Jane Doe"}`)
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		service := new(MockAuthorService)
 		handler := NewAuthorHandler(service)
@@ -142,7 +163,7 @@ func TestAuthorHandler_GetByID(t *testing.T) {
 		c.SetParamValues(authorID)
 
 		var dummy *model.Author
-		service.On("FindByID", mock.Anything, authorID).Return(dummy, echo.NewHTTPError(http.StatusInternalServerError, "fail"))
+		service.On("FindByID", mock.Anything, authorID, model.AuthorReadOptions{}).Return(dummy, echo.NewHTTPError(http.StatusInternalServerError, "fail"))
 
 		err := handler.getByID(c)
 		require.NoError(t, err)
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/bagasss3/go-article/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type categoryHandler struct {
+	categoryService model.CategoryMethodService
+}
+
+func NewCategoryHandler(categoryService model.CategoryMethodService) *categoryHandler {
+	return &categoryHandler{
+		categoryService: categoryService,
+	}
+}
+
+func (h *categoryHandler) Register(g *echo.Group) {
+	api := g.Group("/category")
+	{
+		api.GET("", h.getAll)
+		api.POST("", h.create)
+		api.GET("/:id", h.getByID)
+		api.PUT("/:id", h.update)
+		api.DELETE("/:id", h.delete)
+		api.GET("/:slug/articles", h.getArticles)
+	}
+}
+
+func (h *categoryHandler) getAll(c echo.Context) error {
+	categories, err := h.categoryService.FindAll(c.Request().Context())
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, categories, "List Category")
+}
+
+func (h *categoryHandler) getByID(c echo.Context) error {
+	result, err := h.categoryService.FindByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, result, "Find Category By ID")
+}
+
+func (h *categoryHandler) getArticles(c echo.Context) error {
+	var query model.ArticleQuery
+	if err := c.Bind(&query); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	result, total, err := h.categoryService.FindArticles(c.Request().Context(), c.Param("slug"), query)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterfaceTotal(c, http.StatusOK, result, "List Category Article", total)
+}
+
+func (h *categoryHandler) create(c echo.Context) error {
+	var req *model.CreateCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, config.BadRequest, helper.GetValueBetween(err.Error(), "Error:", "tag"))
+	}
+
+	result, err := h.categoryService.Create(c.Request().Context(), req)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusCreated, result, "Store Category")
+}
+
+func (h *categoryHandler) update(c echo.Context) error {
+	var req *model.UpdateCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, config.BadRequest, helper.GetValueBetween(err.Error(), "Error:", "tag"))
+	}
+
+	result, err := h.categoryService.Update(c.Request().Context(), c.Param("id"), req)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, result, "Update Category")
+}
+
+func (h *categoryHandler) delete(c echo.Context) error {
+	if err := h.categoryService.Delete(c.Request().Context(), c.Param("id")); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, nil, "Delete Category")
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	customErr "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCategoryService struct {
+	mock.Mock
+}
+
+func (m *MockCategoryService) FindAll(ctx context.Context) ([]*model.Category, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*model.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) FindByID(ctx context.Context, id string) (*model.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*model.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) FindArticles(ctx context.Context, slug string, filter model.ArticleQuery) (*model.CategoryArticles, int, error) {
+	args := m.Called(ctx, slug, filter)
+	return args.Get(0).(*model.CategoryArticles), args.Int(1), args.Error(2)
+}
+
+func (m *MockCategoryService) Create(ctx context.Context, req *model.CreateCategoryRequest) (*model.Category, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*model.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) Update(ctx context.Context, id string, req *model.UpdateCategoryRequest) (*model.Category, error) {
+	args := m.Called(ctx, id, req)
+	return args.Get(0).(*model.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestCategoryHandler_Create(t *testing.T) {
+	e := echo.New()
+	e.Validator = &model.CustomValidator{Validator: validator.New()}
+
+	t.Run("success", func(t *testing.T) {
+		service := new(MockCategoryService)
+		handler := NewCategoryHandler(service)
+
+		req := httptest.NewRequest(http.MethodPost, "/category", strings.NewReader(`{"name":"Tech"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		service.On("Create", mock.Anything, mock.Anything).Return(&model.Category{ID: uuid.New(), Name: "Tech", Slug: "tech"}, nil)
+
+		require.NoError(t, handler.create(c))
+		require.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		handler := NewCategoryHandler(new(MockCategoryService))
+
+		req := httptest.NewRequest(http.MethodPost, "/category", strings.NewReader(`{"name":"T","parent_id":"bad"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.create(c))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestCategoryHandler_Update(t *testing.T) {
+	e := echo.New()
+	e.Validator = &model.CustomValidator{Validator: validator.New()}
+
+	t.Run("cycle rejected", func(t *testing.T) {
+		service := new(MockCategoryService)
+		handler := NewCategoryHandler(service)
+		id := uuid.New().String()
+
+		req := httptest.NewRequest(http.MethodPut, "/category/"+id, strings.NewReader(`{"name":"Tech"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+
+		var dummy *model.Category
+		service.On("Update", mock.Anything, id, mock.Anything).
+			Return(dummy, customErr.New(customErr.ErrInvalidData, "parent category is a descendant of this category"))
+
+		require.NoError(t, handler.update(c))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestCategoryHandler_Delete(t *testing.T) {
+	e := echo.New()
+	service := new(MockCategoryService)
+	handler := NewCategoryHandler(service)
+	id := uuid.New().String()
+
+	req := httptest.NewRequest(http.MethodDelete, "/category/"+id, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+
+	service.On("Delete", mock.Anything, id).Return(nil)
+
+	require.NoError(t, handler.delete(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestCategoryHandler_GetArticles(t *testing.T) {
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		service := new(MockCategoryService)
+		handler := NewCategoryHandler(service)
+
+		req := httptest.NewRequest(http.MethodGet, "/category/go/articles?page=1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("slug")
+		c.SetParamValues("go")
+
+		result := &model.CategoryArticles{
+			Category:    &model.Category{Slug: "go"},
+			Breadcrumbs: []*model.Category{{Slug: "tech"}, {Slug: "go"}},
+			Articles:    []*model.Article{},
+		}
+		service.On("FindArticles", mock.Anything, "go", model.ArticleQuery{Page: 1}).Return(result, 0, nil)
+
+		require.NoError(t, handler.getArticles(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"breadcrumbs"`)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := new(MockCategoryService)
+		handler := NewCategoryHandler(service)
+
+		req := httptest.NewRequest(http.MethodGet, "/category/missing/articles", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("slug")
+		c.SetParamValues("missing")
+
+		var dummy *model.CategoryArticles
+		service.On("FindArticles", mock.Anything, "missing", model.ArticleQuery{}).
+			Return(dummy, 0, customErr.New(customErr.ErrRecordNotFound, "category not found"))
+
+		require.NoError(t, handler.getArticles(c))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestCategoryHandler_Register(t *testing.T) {
+	handler := NewCategoryHandler(new(MockCategoryService))
+
+	e := echo.New()
+	handler.Register(e.Group("/api"))
+
+	expected := map[string]bool{
+		"GET /api/category":                false,
+		"POST /api/category":               false,
+		"GET /api/category/:id":            false,
+		"PUT /api/category/:id":            false,
+		"DELETE /api/category/:id":         false,
+		"GET /api/category/:slug/articles": false,
+	}
+	for _, route := range e.Routes() {
+		key := route.Method + " " + route.Path
+		if _, ok := expected[key]; ok {
+			expected[key] = true
+		}
+	}
+	for route, found := range expected {
+		require.True(t, found, route+" should be registered")
+	}
+}
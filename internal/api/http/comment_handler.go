@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/internal/middleware"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/bagasss3/go-article/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type commentHandler struct {
+	commentService model.CommentMethodService
+}
+
+func NewCommentHandler(commentService model.CommentMethodService) *commentHandler {
+	return &commentHandler{
+		commentService: commentService,
+	}
+}
+
+// Register mounts the comment routes. Writes act on behalf of the user in the
+// X-User-ID header.
+func (h *commentHandler) Register(g *echo.Group) {
+	g.GET("/article/:id/comments", h.getByArticle)
+	g.POST("/article/:id/comments", h.create)
+
+	api := g.Group("/comment")
+	{
+		api.PUT("/:id", h.update)
+		api.DELETE("/:id", h.delete)
+		api.PUT("/:id/status", h.moderate)
+	}
+}
+
+func (h *commentHandler) getByArticle(c echo.Context) error {
+	var query model.CommentQuery
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &query); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	result, err := h.commentService.FindByArticle(c.Request().Context(), c.Param("id"), query)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, result, "List Comment")
+}
+
+func (h *commentHandler) create(c echo.Context) error {
+	var req *model.CreateCommentRequest
+	if err := c.Bind(&req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, config.BadRequest, helper.GetValueBetween(err.Error(), "Error:", "tag"))
+	}
+
+	result, err := h.commentService.Create(c.Request().Context(), c.Param("id"), userID(c), req)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusCreated, result, "Store Comment")
+}
+
+func (h *commentHandler) update(c echo.Context) error {
+	var req *model.UpdateCommentRequest
+	if err := c.Bind(&req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, config.BadRequest, helper.GetValueBetween(err.Error(), "Error:", "tag"))
+	}
+
+	result, err := h.commentService.Update(c.Request().Context(), c.Param("id"), userID(c), req)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, result, "Update Comment")
+}
+
+func (h *commentHandler) delete(c echo.Context) error {
+	if err := h.commentService.Delete(c.Request().Context(), c.Param("id"), userID(c)); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, nil, "Delete Comment")
+}
+
+func (h *commentHandler) moderate(c echo.Context) error {
+	var req *model.ModerateCommentRequest
+	if err := c.Bind(&req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, config.BadRequest, helper.GetValueBetween(err.Error(), "Error:", "tag"))
+	}
+
+	result, err := h.commentService.Moderate(c.Request().Context(), c.Param("id"), userID(c), req)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, result, "Moderate Comment")
+}
+
+// userID returns the calling user as identified by the gateway.
+func userID(c echo.Context) string {
+	return c.Request().Header.Get(middleware.HeaderUserID)
+}
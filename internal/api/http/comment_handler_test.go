@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	customErr "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/middleware"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCommentService struct {
+	mock.Mock
+}
+
+func (m *MockCommentService) FindByArticle(ctx context.Context, articleID string, query model.CommentQuery) (*model.CommentPage, error) {
+	args := m.Called(ctx, articleID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CommentPage), args.Error(1)
+}
+
+func (m *MockCommentService) Create(ctx context.Context, articleID, userID string, req *model.CreateCommentRequest) (*model.Comment, error) {
+	args := m.Called(ctx, articleID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) Update(ctx context.Context, id, userID string, req *model.UpdateCommentRequest) (*model.Comment, error) {
+	args := m.Called(ctx, id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) Delete(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockCommentService) Moderate(ctx context.Context, id, userID string, req *model.ModerateCommentRequest) (*model.Comment, error) {
+	args := m.Called(ctx, id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Comment), args.Error(1)
+}
+
+func TestCommentHandler(t *testing.T) {
+	setup := func() (*echo.Echo, *MockCommentService) {
+		e := echo.New()
+		e.Validator = &model.CustomValidator{Validator: validator.New()}
+		service := new(MockCommentService)
+		NewCommentHandler(service).Register(e.Group("/api/v1"))
+		return e, service
+	}
+
+	articleID := uuid.New().String()
+	commentID := uuid.New().String()
+
+	t.Run("list with cursor", func(t *testing.T) {
+		e, service := setup()
+		service.On("FindByArticle", mock.Anything, articleID, model.CommentQuery{Cursor: "abc", Limit: 5}).
+			Return(&model.CommentPage{Comments: []*model.Comment{}, NextCursor: "def"}, nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/article/"+articleID+"/comments?cursor=abc&limit=5", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"next_cursor":"def"`)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("create passes the user", func(t *testing.T) {
+		e, service := setup()
+		service.On("Create", mock.Anything, articleID, "user-1", &model.CreateCommentRequest{Body: "Nice"}).
+			Return(&model.Comment{ID: uuid.New(), Body: "Nice", Status: model.CommentStatusPending}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/article/"+articleID+"/comments", strings.NewReader(`{"body":"Nice"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(middleware.HeaderUserID, "user-1")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("create validation error", func(t *testing.T) {
+		e, service := setup()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/article/"+articleID+"/comments", strings.NewReader(`{"parent_id":"bad"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		service.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("update by another user", func(t *testing.T) {
+		e, service := setup()
+		service.On("Update", mock.Anything, commentID, "user-2", &model.UpdateCommentRequest{Body: "Edited"}).
+			Return(nil, customErr.New(customErr.ErrPermissionDenied, "only the author of a comment can change it"))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/comment/"+commentID, strings.NewReader(`{"body":"Edited"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(middleware.HeaderUserID, "user-2")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("delete without user", func(t *testing.T) {
+		e, service := setup()
+		service.On("Delete", mock.Anything, commentID, "").
+			Return(customErr.New(customErr.ErrUnauthorized, "a user ID is required"))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/comment/"+commentID, nil))
+
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("moderate", func(t *testing.T) {
+		e, service := setup()
+		service.On("Moderate", mock.Anything, commentID, "mod", &model.ModerateCommentRequest{Status: model.CommentStatusSpam}).
+			Return(&model.Comment{Status: model.CommentStatusSpam}, nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/comment/"+commentID+"/status", strings.NewReader(`{"status":"spam"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(middleware.HeaderUserID, "mod")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("moderate rejects unknown status", func(t *testing.T) {
+		e, service := setup()
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/comment/"+commentID+"/status", strings.NewReader(`{"status":"hidden"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		service.AssertNotCalled(t, "Moderate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/labstack/echo/v4"
+)
+
+type feedHandler struct {
+	feedService model.FeedMethodService
+}
+
+func NewFeedHandler(feedService model.FeedMethodService) *feedHandler {
+	return &feedHandler{
+		feedService: feedService,
+	}
+}
+
+// Register mounts the feeds at the root of g, as readers expect them next to the site.
+func (h *feedHandler) Register(g *echo.Group) {
+	for _, format := range model.FeedFormats {
+		g.GET("/feed."+format, h.get(format))
+		g.GET("/author/:id/feed."+format, h.get(format))
+		g.GET("/tag/:slug/feed."+format, h.get(format))
+	}
+}
+
+func (h *feedHandler) get(format string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		feed, err := h.feedService.Render(c.Request().Context(), model.FeedQuery{
+			Format:   format,
+			AuthorID: c.Param("id"),
+			Tag:      c.Param("slug"),
+			Content:  c.QueryParam("content"),
+		})
+		if err != nil {
+			logger.FromContext(c.Request().Context()).Error(err)
+			return handleError(c, err)
+		}
+
+		header := c.Response().Header()
+		header.Set(echo.HeaderContentType, feed.ContentType)
+		header.Set("ETag", feed.ETag)
+		if !feed.LastModified.IsZero() {
+			header.Set(echo.HeaderLastModified, feed.LastModified.UTC().Format(http.TimeFormat))
+		}
+
+		if notModified(c.Request(), feed) {
+			return c.NoContent(http.StatusNotModified)
+		}
+
+		return c.Blob(http.StatusOK, feed.ContentType, feed.Body)
+	}
+}
+
+// notModified evaluates the conditional headers of req against feed. If-None-Match
+// takes precedence over If-Modified-Since.
+func notModified(req *http.Request, feed *model.Feed) bool {
+	if match := req.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == feed.ETag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if since := req.Header.Get(echo.HeaderIfModifiedSince); since != "" && !feed.LastModified.IsZero() {
+		t, err := http.ParseTime(since)
+		return err == nil && !feed.LastModified.After(t)
+	}
+
+	return false
+}
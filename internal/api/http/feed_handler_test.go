@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	customErr "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockFeedService struct {
+	mock.Mock
+}
+
+func (m *MockFeedService) Render(ctx context.Context, query model.FeedQuery) (*model.Feed, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(*model.Feed), args.Error(1)
+}
+
+func TestFeedHandler(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	feed := &model.Feed{
+		Body:         []byte(`<rss version="2.0"></rss>`),
+		ContentType:  "application/rss+xml; charset=utf-8",
+		ETag:         `"abc123"`,
+		LastModified: modified,
+	}
+
+	setup := func() (*echo.Echo, *MockFeedService) {
+		e := echo.New()
+		service := new(MockFeedService)
+		NewFeedHandler(service).Register(e.Group(""))
+		return e, service
+	}
+
+	t.Run("site feed", func(t *testing.T) {
+		e, service := setup()
+		service.On("Render", mock.Anything, model.FeedQuery{Format: model.FeedFormatRSS, Content: "full"}).Return(feed, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feed.rss?content=full", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, feed.ContentType, rec.Header().Get(echo.HeaderContentType))
+		require.Equal(t, `"abc123"`, rec.Header().Get("ETag"))
+		require.Equal(t, "Wed, 01 May 2024 08:00:00 GMT", rec.Header().Get(echo.HeaderLastModified))
+		require.Equal(t, string(feed.Body), rec.Body.String())
+	})
+
+	t.Run("author and tag feeds", func(t *testing.T) {
+		e, service := setup()
+		service.On("Render", mock.Anything, model.FeedQuery{Format: model.FeedFormatAtom, AuthorID: "42"}).Return(feed, nil).Once()
+		service.On("Render", mock.Anything, model.FeedQuery{Format: model.FeedFormatJSON, Tag: "go"}).Return(feed, nil).Once()
+
+		for _, target := range []string{"/author/42/feed.atom", "/tag/go/feed.json"} {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+			require.Equal(t, http.StatusOK, rec.Code, target)
+		}
+		service.AssertExpectations(t)
+	})
+
+	t.Run("matching etag", func(t *testing.T) {
+		e, service := setup()
+		service.On("Render", mock.Anything, mock.Anything).Return(feed, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feed.rss", nil)
+		req.Header.Set("If-None-Match", `"other", W/"abc123"`)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotModified, rec.Code)
+		require.Empty(t, rec.Body.String())
+	})
+
+	t.Run("stale etag wins over date", func(t *testing.T) {
+		e, service := setup()
+		service.On("Render", mock.Anything, mock.Anything).Return(feed, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feed.rss", nil)
+		req.Header.Set("If-None-Match", `"other"`)
+		req.Header.Set(echo.HeaderIfModifiedSince, modified.Format(http.TimeFormat))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not modified since", func(t *testing.T) {
+		e, service := setup()
+		service.On("Render", mock.Anything, mock.Anything).Return(feed, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feed.rss", nil)
+		req.Header.Set(echo.HeaderIfModifiedSince, modified.Format(http.TimeFormat))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotModified, rec.Code)
+	})
+
+	t.Run("modified since", func(t *testing.T) {
+		e, service := setup()
+		service.On("Render", mock.Anything, mock.Anything).Return(feed, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feed.rss", nil)
+		req.Header.Set(echo.HeaderIfModifiedSince, modified.Add(-time.Hour).Format(http.TimeFormat))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		e, service := setup()
+		var dummy *model.Feed
+		service.On("Render", mock.Anything, mock.Anything).Return(dummy, customErr.New(customErr.ErrRecordNotFound, "author not found"))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/author/42/feed.rss", nil))
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
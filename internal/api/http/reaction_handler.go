@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/bagasss3/go-article/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type reactionHandler struct {
+	reactionService model.ReactionMethodService
+}
+
+func NewReactionHandler(reactionService model.ReactionMethodService) *reactionHandler {
+	return &reactionHandler{
+		reactionService: reactionService,
+	}
+}
+
+// Register mounts reactions and bookmarks, which act on behalf of the user in the
+// X-User-ID header.
+func (h *reactionHandler) Register(g *echo.Group) {
+	api := g.Group("/article/:id")
+	{
+		api.PUT("/reactions/:type", h.react)
+		api.DELETE("/reactions/:type", h.unreact)
+		api.PUT("/bookmark", h.bookmark)
+		api.DELETE("/bookmark", h.unbookmark)
+	}
+
+	g.GET("/me/bookmarks", h.getBookmarks)
+}
+
+func (h *reactionHandler) react(c echo.Context) error {
+	if err := h.reactionService.React(c.Request().Context(), c.Param("id"), userID(c), c.Param("type")); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, nil, "React Article")
+}
+
+func (h *reactionHandler) unreact(c echo.Context) error {
+	if err := h.reactionService.Unreact(c.Request().Context(), c.Param("id"), userID(c), c.Param("type")); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, nil, "Unreact Article")
+}
+
+func (h *reactionHandler) bookmark(c echo.Context) error {
+	if err := h.reactionService.Bookmark(c.Request().Context(), c.Param("id"), userID(c)); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, nil, "Bookmark Article")
+}
+
+func (h *reactionHandler) unbookmark(c echo.Context) error {
+	if err := h.reactionService.Unbookmark(c.Request().Context(), c.Param("id"), userID(c)); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, nil, "Unbookmark Article")
+}
+
+func (h *reactionHandler) getBookmarks(c echo.Context) error {
+	var query model.ArticleQuery
+	if err := c.Bind(&query); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	articles, total, err := h.reactionService.Bookmarks(c.Request().Context(), userID(c), query)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	data, err := helper.SelectFields(articles, helper.ParseFields(query.Fields))
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterfaceTotal(c, http.StatusOK, data, "List Bookmark", total)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	customErr "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/middleware"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReactionService struct {
+	mock.Mock
+}
+
+func (m *MockReactionService) React(ctx context.Context, articleID, userID, reaction string) error {
+	args := m.Called(ctx, articleID, userID, reaction)
+	return args.Error(0)
+}
+
+func (m *MockReactionService) Unreact(ctx context.Context, articleID, userID, reaction string) error {
+	args := m.Called(ctx, articleID, userID, reaction)
+	return args.Error(0)
+}
+
+func (m *MockReactionService) Bookmark(ctx context.Context, articleID, userID string) error {
+	args := m.Called(ctx, articleID, userID)
+	return args.Error(0)
+}
+
+func (m *MockReactionService) Unbookmark(ctx context.Context, articleID, userID string) error {
+	args := m.Called(ctx, articleID, userID)
+	return args.Error(0)
+}
+
+func (m *MockReactionService) Bookmarks(ctx context.Context, userID string, filter model.ArticleQuery) ([]*model.Article, int, error) {
+	args := m.Called(ctx, userID, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*model.Article), args.Int(1), args.Error(2)
+}
+
+func (m *MockReactionService) Flush(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func TestReactionHandler(t *testing.T) {
+	setup := func() (*echo.Echo, *MockReactionService) {
+		e := echo.New()
+		service := new(MockReactionService)
+		NewReactionHandler(service).Register(e.Group("/api/v1"))
+		return e, service
+	}
+
+	articleID := uuid.New().String()
+
+	request := func(method, target, user string) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		if user != "" {
+			req.Header.Set(middleware.HeaderUserID, user)
+		}
+		return req
+	}
+
+	t.Run("react", func(t *testing.T) {
+		e, service := setup()
+		service.On("React", mock.Anything, articleID, "user-1", "like").Return(nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, request(http.MethodPut, "/api/v1/article/"+articleID+"/reactions/like", "user-1"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("unknown reaction", func(t *testing.T) {
+		e, service := setup()
+		service.On("React", mock.Anything, articleID, "user-1", "angry").
+			Return(customErr.New(customErr.ErrInvalidData, "reaction must be one of like, love, insightful, funny"))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, request(http.MethodPut, "/api/v1/article/"+articleID+"/reactions/angry", "user-1"))
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("unreact", func(t *testing.T) {
+		e, service := setup()
+		service.On("Unreact", mock.Anything, articleID, "user-1", "love").Return(nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, request(http.MethodDelete, "/api/v1/article/"+articleID+"/reactions/love", "user-1"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("bookmark without user", func(t *testing.T) {
+		e, service := setup()
+		service.On("Bookmark", mock.Anything, articleID, "").
+			Return(customErr.New(customErr.ErrUnauthorized, "a user ID is required"))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, request(http.MethodPut, "/api/v1/article/"+articleID+"/bookmark", ""))
+
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("unbookmark", func(t *testing.T) {
+		e, service := setup()
+		service.On("Unbookmark", mock.Anything, articleID, "user-1").Return(nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, request(http.MethodDelete, "/api/v1/article/"+articleID+"/bookmark", "user-1"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("my bookmarks", func(t *testing.T) {
+		e, service := setup()
+		service.On("Bookmarks", mock.Anything, "user-1", model.ArticleQuery{Page: 2}).
+			Return([]*model.Article{{ID: uuid.New(), Title: "Saved"}}, 1, nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, request(http.MethodGet, "/api/v1/me/bookmarks?page=2", "user-1"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"title":"Saved"`)
+		require.Contains(t, rec.Body.String(), `"total":1`)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/bagasss3/go-article/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type searchHandler struct {
+	searchService model.SearchMethodService
+}
+
+func NewSearchHandler(searchService model.SearchMethodService) *searchHandler {
+	return &searchHandler{
+		searchService: searchService,
+	}
+}
+
+func (h *searchHandler) Register(g *echo.Group) {
+	api := g.Group("/search")
+	{
+		api.GET("/suggest", h.suggest)
+	}
+}
+
+func (h *searchHandler) suggest(c echo.Context) error {
+	var query model.SuggestQuery
+	if err := c.Bind(&query); err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return response.ResponseInterfaceError(c, http.StatusBadRequest, err.Error(), config.BadRequest)
+	}
+
+	suggestions, err := h.searchService.Suggest(c.Request().Context(), query)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, suggestions, "Suggest Search")
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	customErr "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSearchService) Suggest(ctx context.Context, query model.SuggestQuery) (*model.Suggestions, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Suggestions), args.Error(1)
+}
+
+func (m *MockSearchService) DidYouMean(ctx context.Context, query string) (string, error) {
+	args := m.Called(ctx, query)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSearchService) RefreshWords(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockSearchService) Facets(ctx context.Context, filter model.ArticleQuery) (*model.Facets, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Facets), args.Error(1)
+}
+
+func TestSearchHandler_Suggest(t *testing.T) {
+	setup := func() (*echo.Echo, *MockSearchService) {
+		e := echo.New()
+		service := new(MockSearchService)
+		NewSearchHandler(service).Register(e.Group("/api/v1"))
+		return e, service
+	}
+
+	t.Run("success", func(t *testing.T) {
+		e, service := setup()
+		service.On("Suggest", mock.Anything, model.SuggestQuery{Q: "go", Limit: 3}).Return(&model.Suggestions{
+			Articles: []*model.ArticleSuggestion{{ID: uuid.New(), Title: "Go Generics", Slug: "go-generics"}},
+			Authors:  []*model.AuthorSuggestion{{ID: uuid.New(), Name: "Gopher"}},
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/search/suggest?q=go&limit=3", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"title":"Go Generics"`)
+		require.Contains(t, rec.Body.String(), `"name":"Gopher"`)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("prefix too long", func(t *testing.T) {
+		e, service := setup()
+		service.On("Suggest", mock.Anything, mock.Anything).
+			Return(nil, customErr.New(customErr.ErrInvalidData, "q must be at most 100 characters"))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/search/suggest?q=long", nil))
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
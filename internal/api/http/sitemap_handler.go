@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/labstack/echo/v4"
+)
+
+type sitemapHandler struct {
+	sitemapService model.SitemapMethodService
+}
+
+func NewSitemapHandler(sitemapService model.SitemapMethodService) *sitemapHandler {
+	return &sitemapHandler{
+		sitemapService: sitemapService,
+	}
+}
+
+// Register mounts the sitemaps at the root of g, where crawlers look for them.
+func (h *sitemapHandler) Register(g *echo.Group) {
+	g.GET("/sitemap.xml", h.index)
+	// Echo parameters run to the next slash, so the page number is cut from sitemap-<n>.xml.
+	g.GET("/sitemap-:file", h.page)
+}
+
+func (h *sitemapHandler) index(c echo.Context) error {
+	body, err := h.sitemapService.Index(c.Request().Context())
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, body)
+}
+
+func (h *sitemapHandler) page(c echo.Context) error {
+	number, ok := strings.CutSuffix(c.Param("file"), ".xml")
+	page, err := strconv.Atoi(number)
+	if !ok || err != nil {
+		return echo.ErrNotFound
+	}
+
+	body, err := h.sitemapService.Page(c.Request().Context(), page)
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, body)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	customErr "github.com/bagasss3/go-article/internal/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSitemapService struct {
+	mock.Mock
+}
+
+func (m *MockSitemapService) Index(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSitemapService) Page(ctx context.Context, page int) ([]byte, error) {
+	args := m.Called(ctx, page)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestSitemapHandler(t *testing.T) {
+	setup := func() (*echo.Echo, *MockSitemapService) {
+		e := echo.New()
+		service := new(MockSitemapService)
+		NewSitemapHandler(service).Register(e.Group(""))
+		return e, service
+	}
+
+	t.Run("index", func(t *testing.T) {
+		e, service := setup()
+		service.On("Index", mock.Anything).Return([]byte("<urlset></urlset>"), nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, echo.MIMEApplicationXMLCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+		require.Equal(t, "<urlset></urlset>", rec.Body.String())
+	})
+
+	t.Run("page", func(t *testing.T) {
+		e, service := setup()
+		service.On("Page", mock.Anything, 2).Return([]byte("<urlset></urlset>"), nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sitemap-2.xml", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("malformed page", func(t *testing.T) {
+		e, service := setup()
+
+		for _, target := range []string{"/sitemap-two.xml", "/sitemap-2.txt"} {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+			require.Equal(t, http.StatusNotFound, rec.Code, target)
+		}
+		service.AssertNotCalled(t, "Page", mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing page", func(t *testing.T) {
+		e, service := setup()
+		var dummy []byte
+		service.On("Page", mock.Anything, 9).Return(dummy, customErr.New(customErr.ErrRecordNotFound, "sitemap page not found"))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sitemap-9.xml", nil))
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
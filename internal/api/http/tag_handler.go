@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"github.com/bagasss3/go-article/internal/logger"
+	"net/http"
+
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/bagasss3/go-article/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type tagHandler struct {
+	tagService model.TagMethodService
+}
+
+func NewTagHandler(tagService model.TagMethodService) *tagHandler {
+	return &tagHandler{
+		tagService: tagService,
+	}
+}
+
+func (h *tagHandler) Register(g *echo.Group) {
+	api := g.Group("/tag")
+	{
+		api.GET("", h.getAll)
+	}
+}
+
+func (h *tagHandler) getAll(c echo.Context) error {
+	tags, err := h.tagService.FindAll(c.Request().Context())
+	if err != nil {
+		logger.FromContext(c.Request().Context()).Error(err)
+		return handleError(c, err)
+	}
+
+	return response.ResponseInterface(c, http.StatusOK, tags, "List Tag")
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTagService struct {
+	mock.Mock
+}
+
+func (m *MockTagService) FindAll(ctx context.Context) ([]*model.Tag, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*model.Tag), args.Error(1)
+}
+
+func TestTagHandler_GetAll(t *testing.T) {
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		service := new(MockTagService)
+		handler := NewTagHandler(service)
+
+		req := httptest.NewRequest(http.MethodGet, "/tag", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		expected := []*model.Tag{{ID: uuid.New(), Name: "go", Slug: "go", ArticleCount: 1}}
+		service.On("FindAll", mock.Anything).Return(expected, nil)
+
+		err := handler.getAll(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"article_count":1`)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service := new(MockTagService)
+		handler := NewTagHandler(service)
+
+		req := httptest.NewRequest(http.MethodGet, "/tag", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var dummy []*model.Tag
+		service.On("FindAll", mock.Anything).Return(dummy, echo.NewHTTPError(http.StatusInternalServerError, "fail"))
+
+		err := handler.getAll(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestTagHandler_Register(t *testing.T) {
+	handler := NewTagHandler(new(MockTagService))
+
+	e := echo.New()
+	handler.Register(e.Group("/api"))
+
+	found := false
+	for _, route := range e.Routes() {
+		if route.Method == http.MethodGet && route.Path == "/api/tag" {
+			found = true
+		}
+	}
+	require.True(t, found, "GET route should be registered")
+}
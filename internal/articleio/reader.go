@@ -0,0 +1,225 @@
+// Package articleio reads and writes articles in the bulk import and export formats:
+// NDJSON, CSV and Markdown files with YAML front matter.
+package articleio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// maxLineSize bounds a single NDJSON line, body included.
+const maxLineSize = 16 << 20
+
+// csvColumns are the columns an import CSV may have, in export order; author, title and
+// body are required.
+var csvColumns = []string{"id", "author", "title", "slug", "language", "summary", "category_id", "tags", "created_at", "updated_at", "body"}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewNDJSONReader reads one article per line. Blank lines are skipped.
+func NewNDJSONReader(r io.Reader) model.ArticleImportReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return &ndjsonReader{scanner: scanner}
+}
+
+func (r *ndjsonReader) Next() (*model.ArticleImportRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		row := &model.ArticleImportRow{Row: r.line}
+		if err := json.Unmarshal(line, row); err != nil {
+			return nil, &model.ArticleImportError{Row: r.line, Message: "invalid JSON: " + err.Error()}
+		}
+		return row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	err     error
+}
+
+// NewCSVReader reads articles from a CSV with a header row naming its columns. Tags are
+// separated by commas within their cell.
+func NewCSVReader(r io.Reader) model.ArticleImportReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return &csvReader{err: io.EOF}
+	}
+	if err != nil {
+		return &csvReader{err: errors.New(errors.ErrInvalidData, "invalid CSV header: "+err.Error())}
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"author", "title", "body"} {
+		if _, ok := columns[name]; !ok {
+			return &csvReader{err: errors.New(errors.ErrInvalidData, fmt.Sprintf("CSV header is missing the %s column", name))}
+		}
+	}
+
+	return &csvReader{reader: reader, columns: columns}
+}
+
+func (r *csvReader) Next() (*model.ArticleImportRow, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if parseErr, ok := err.(*csv.ParseError); ok {
+		// The reader resumes at the next record after a malformed one.
+		return nil, &model.ArticleImportError{Row: parseErr.StartLine, Message: parseErr.Err.Error()}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	field := func(name string) string {
+		i, ok := r.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	row := &model.ArticleImportRow{
+		Row:        line,
+		Author:     field("author"),
+		Title:      field("title"),
+		Body:       field("body"),
+		Summary:    field("summary"),
+		CategoryID: field("category_id"),
+		Language:   field("language"),
+	}
+	if tags := field("tags"); strings.TrimSpace(tags) != "" {
+		row.Tags = strings.Split(tags, ",")
+	}
+	if createdAt := strings.TrimSpace(field("created_at")); createdAt != "" {
+		t, err := time.Parse(time.RFC3339, createdAt)
+		if err != nil {
+			return nil, &model.ArticleImportError{Row: line, Message: "created_at must be formatted as RFC 3339"}
+		}
+		row.CreatedAt = &t
+	}
+
+	return row, nil
+}
+
+type markdownReader struct {
+	fsys  fs.FS
+	paths []string
+	next  int
+	err   error
+}
+
+// NewMarkdownReader reads every .md file of fsys, such as a directory or a zip archive,
+// in lexical order. Each file starts with YAML front matter between --- lines, followed
+// by the Markdown body.
+func NewMarkdownReader(fsys fs.FS) model.ArticleImportReader {
+	r := &markdownReader{fsys: fsys}
+	r.err = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Skip hidden entries such as the __MACOSX folder of archives made on a Mac.
+		if p != "." && (strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), "__")) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && strings.EqualFold(path.Ext(p), ".md") {
+			r.paths = append(r.paths, p)
+		}
+		return nil
+	})
+	return r
+}
+
+func (r *markdownReader) Next() (*model.ArticleImportRow, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.next >= len(r.paths) {
+		return nil, io.EOF
+	}
+
+	p := r.paths[r.next]
+	r.next++
+
+	content, err := fs.ReadFile(r.fsys, p)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := parseMarkdown(content)
+	if err != nil {
+		return nil, &model.ArticleImportError{Row: r.next, Source: p, Message: err.Error()}
+	}
+	row.Row = r.next
+	row.Source = p
+
+	return row, nil
+}
+
+// parseMarkdown splits a document into its front matter and body.
+func parseMarkdown(content []byte) (*model.ArticleImportRow, error) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+
+	rest, ok := bytes.CutPrefix(content, []byte("---\n"))
+	if !ok {
+		return nil, fmt.Errorf("missing front matter")
+	}
+	frontMatter, body, ok := bytes.Cut(rest, []byte("\n---\n"))
+	if !ok {
+		// A document may end right after the closing line.
+		if frontMatter, ok = bytes.CutSuffix(rest, []byte("\n---")); !ok {
+			return nil, fmt.Errorf("unterminated front matter")
+		}
+	}
+
+	row := &model.ArticleImportRow{}
+	if err := yaml.Unmarshal(frontMatter, row); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+	row.Body = strings.TrimSpace(string(body))
+
+	return row, nil
+}
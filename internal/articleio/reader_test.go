@@ -0,0 +1,194 @@
+package articleio
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readAll drains r, collecting the rows and the per-row errors it reports in order.
+func readAll(t *testing.T, r model.ArticleImportReader) ([]*model.ArticleImportRow, []*model.ArticleImportError) {
+	t.Helper()
+
+	var (
+		rows   []*model.ArticleImportRow
+		failed []*model.ArticleImportError
+	)
+	for {
+		row, err := r.Next()
+		if err == io.EOF {
+			return rows, failed
+		}
+		var rowErr *model.ArticleImportError
+		if errors.As(err, &rowErr) {
+			failed = append(failed, rowErr)
+			continue
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestNDJSONReader(t *testing.T) {
+	t.Run("rows keep their line", func(t *testing.T) {
+		input := `{"author":"Jane","title":"First","body":"One","tags":["go"],"language":"id"}
+
+{"author":"John","title":"Second","body":"Two","created_at":"2024-05-01T08:00:00Z"}
+`
+		rows, failed := readAll(t, NewNDJSONReader(strings.NewReader(input)))
+		require.Empty(t, failed)
+		require.Len(t, rows, 2)
+
+		assert.Equal(t, 1, rows[0].Row)
+		assert.Equal(t, "First", rows[0].Title)
+		assert.Equal(t, []string{"go"}, rows[0].Tags)
+		assert.Equal(t, "id", rows[0].Language)
+
+		// The blank line still counts.
+		assert.Equal(t, 3, rows[1].Row)
+		require.NotNil(t, rows[1].CreatedAt)
+		assert.Equal(t, time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC), rows[1].CreatedAt.UTC())
+	})
+
+	t.Run("malformed lines are reported and skipped", func(t *testing.T) {
+		input := `{"author":"Jane","title":"First","body":"One"}
+{"author": "Jane", "title":
+{"author":"Jane","title":"Third","body":"Three","tags":"go"}
+{"author":"Jane","title":"Fourth","body":"Four"}`
+
+		rows, failed := readAll(t, NewNDJSONReader(strings.NewReader(input)))
+		require.Len(t, rows, 2)
+		assert.Equal(t, "First", rows[0].Title)
+		assert.Equal(t, "Fourth", rows[1].Title)
+
+		require.Len(t, failed, 2)
+		assert.Equal(t, 2, failed[0].Row)
+		assert.Contains(t, failed[0].Message, "invalid JSON")
+		assert.Equal(t, 3, failed[1].Row)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		rows, failed := readAll(t, NewNDJSONReader(strings.NewReader("\n\n")))
+		assert.Empty(t, rows)
+		assert.Empty(t, failed)
+	})
+}
+
+func TestCSVReader(t *testing.T) {
+	t.Run("columns are matched by name", func(t *testing.T) {
+		input := "Title, Body ,author,tags,unknown,created_at\n" +
+			"Hello,\"Multi\nline\",Jane,\"go,testing\",x,2024-05-01T08:00:00Z\n" +
+			"Short,Body,John,,,\n"
+
+		rows, failed := readAll(t, NewCSVReader(strings.NewReader(input)))
+		require.Empty(t, failed)
+		require.Len(t, rows, 2)
+
+		assert.Equal(t, 2, rows[0].Row)
+		assert.Equal(t, "Hello", rows[0].Title)
+		assert.Equal(t, "Multi\nline", rows[0].Body)
+		assert.Equal(t, "Jane", rows[0].Author)
+		assert.Equal(t, []string{"go", "testing"}, rows[0].Tags)
+		require.NotNil(t, rows[0].CreatedAt)
+
+		// The quoted body spans two lines.
+		assert.Equal(t, 4, rows[1].Row)
+		assert.Nil(t, rows[1].Tags)
+		assert.Nil(t, rows[1].CreatedAt)
+	})
+
+	t.Run("short records leave missing columns empty", func(t *testing.T) {
+		rows, failed := readAll(t, NewCSVReader(strings.NewReader("author,title,body,summary\nJane,Hello\n")))
+		require.Empty(t, failed)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "Hello", rows[0].Title)
+		assert.Empty(t, rows[0].Body)
+		assert.Empty(t, rows[0].Summary)
+	})
+
+	t.Run("malformed rows are reported and skipped", func(t *testing.T) {
+		input := "author,title,body,created_at\n" +
+			"Jane,First,One,\n" +
+			"Jane,Sec\"ond,Two,\n" +
+			"Jane,Third,Three,yesterday\n"
+
+		rows, failed := readAll(t, NewCSVReader(strings.NewReader(input)))
+		require.Len(t, rows, 1)
+		assert.Equal(t, "First", rows[0].Title)
+
+		require.Len(t, failed, 2)
+		assert.Equal(t, 3, failed[0].Row)
+		assert.Equal(t, 4, failed[1].Row)
+		assert.Equal(t, "created_at must be formatted as RFC 3339", failed[1].Message)
+	})
+
+	t.Run("header without a required column", func(t *testing.T) {
+		_, err := NewCSVReader(strings.NewReader("author,title\nJane,Hello\n")).Next()
+		var customErr *customErrors.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.ErrorIs(t, err, customErrors.ErrInvalidData)
+		assert.Equal(t, "CSV header is missing the body column", customErr.MessageDeveloper)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		_, err := NewCSVReader(strings.NewReader("")).Next()
+		assert.Equal(t, io.EOF, err)
+	})
+}
+
+func TestMarkdownReader(t *testing.T) {
+	fsys := fstest.MapFS{
+		"b.md": {Data: []byte("---\nauthor: Jane\ntitle: Second\ntags: [go, testing]\n---\n\n# Heading\n\nBody text\n")},
+		"a.md": {Data: []byte("---\r\nauthor: Jane\r\ntitle: First\r\n---\r\nWindows body\r\n")},
+		"c.md": {Data: []byte("---\nauthor: Jane\ntitle: No body\n---")},
+		"d.md": {Data: []byte("# No front matter\n")},
+		"e.md": {Data: []byte("---\nauthor: Jane\ntitle: Open\n")},
+		"f.md": {Data: []byte("---\nauthor: [Jane\n---\nBody\n")},
+
+		"notes.txt":          {Data: []byte("not an article")},
+		".hidden.md":         {Data: []byte("---\ntitle: Hidden\n---\n")},
+		"__MACOSX/._a.md":    {Data: []byte("resource fork")},
+		"nested/g.MD":        {Data: []byte("---\nauthor: Jane\ntitle: Nested\ncreated_at: 2024-05-01T08:00:00Z\n---\nNested body")},
+		"nested/.git/x.md":   {Data: []byte("---\ntitle: Ignored\n---\n")},
+		"nested/readme.json": {Data: []byte("{}")},
+	}
+
+	rows, failed := readAll(t, NewMarkdownReader(fsys))
+
+	titles := make([]string, 0, len(rows))
+	for _, row := range rows {
+		titles = append(titles, row.Title)
+	}
+	assert.Equal(t, []string{"First", "Second", "No body", "Nested"}, titles)
+
+	assert.Equal(t, "Windows body", rows[0].Body)
+	assert.Equal(t, 1, rows[0].Row)
+	assert.Equal(t, "a.md", rows[0].Source)
+
+	assert.Equal(t, "# Heading\n\nBody text", rows[1].Body)
+	assert.Equal(t, []string{"go", "testing"}, rows[1].Tags)
+
+	// A document may end right after its closing line.
+	assert.Empty(t, rows[2].Body)
+
+	assert.Equal(t, "nested/g.MD", rows[3].Source)
+	assert.Equal(t, 7, rows[3].Row)
+	require.NotNil(t, rows[3].CreatedAt)
+
+	require.Len(t, failed, 3)
+	assert.Equal(t, "d.md", failed[0].Source)
+	assert.Equal(t, 4, failed[0].Row)
+	assert.Equal(t, "missing front matter", failed[0].Message)
+	assert.Equal(t, "e.md", failed[1].Source)
+	assert.Equal(t, "unterminated front matter", failed[1].Message)
+	assert.Equal(t, "f.md", failed[2].Source)
+	assert.Contains(t, failed[2].Message, "invalid front matter")
+}
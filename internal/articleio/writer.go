@@ -0,0 +1,193 @@
+package articleio
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bagasss3/go-article/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// record is an exported article. Its fields are a superset of an import row, so an
+// export can be imported again.
+type record struct {
+	ID         string    `json:"id" yaml:"id"`
+	Author     string    `json:"author" yaml:"author"`
+	Title      string    `json:"title" yaml:"title"`
+	Slug       string    `json:"slug" yaml:"slug"`
+	Language   string    `json:"language" yaml:"language"`
+	Summary    string    `json:"summary" yaml:"summary"`
+	CategoryID string    `json:"category_id,omitempty" yaml:"category_id,omitempty"`
+	Tags       []string  `json:"tags" yaml:"tags"`
+	CreatedAt  time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" yaml:"updated_at"`
+	Body       string    `json:"body" yaml:"-"`
+}
+
+func newRecord(a *model.Article) record {
+	r := record{
+		ID:        a.ID.String(),
+		Author:    a.Author,
+		Title:     a.Title,
+		Slug:      a.Slug,
+		Language:  a.Language,
+		Summary:   a.Excerpt,
+		Tags:      a.Tags,
+		CreatedAt: a.CreatedAt.UTC(),
+		UpdatedAt: a.UpdatedAt.UTC(),
+		Body:      a.Body,
+	}
+	if a.CategoryID != nil {
+		r.CategoryID = a.CategoryID.String()
+	}
+	if r.Tags == nil {
+		r.Tags = []string{}
+	}
+	return r
+}
+
+// NewWriter returns a writer encoding articles in format to w.
+func NewWriter(format string, w io.Writer) (model.ArticleExportWriter, error) {
+	switch format {
+	case model.TransferFormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case model.TransferFormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case model.TransferFormatMarkdown:
+		return &markdownWriter{zip: zip.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// ContentType returns the media type of an export in format.
+func ContentType(format string) string {
+	switch format {
+	case model.TransferFormatCSV:
+		return "text/csv; charset=utf-8"
+	case model.TransferFormatMarkdown:
+		return "application/zip"
+	}
+	return "application/x-ndjson"
+}
+
+// Extension returns the file extension of an export in format, dot included.
+func Extension(format string) string {
+	switch format {
+	case model.TransferFormatCSV:
+		return ".csv"
+	case model.TransferFormatMarkdown:
+		return ".zip"
+	}
+	return ".ndjson"
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(a *model.Article) error {
+	return w.encoder.Encode(newRecord(a))
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
+
+type csvWriter struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func (w *csvWriter) header() error {
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	return w.writer.Write(csvColumns)
+}
+
+func (w *csvWriter) Write(a *model.Article) error {
+	if err := w.header(); err != nil {
+		return err
+	}
+
+	r := newRecord(a)
+	return w.writer.Write([]string{
+		r.ID,
+		r.Author,
+		r.Title,
+		r.Slug,
+		r.Language,
+		r.Summary,
+		r.CategoryID,
+		strings.Join(r.Tags, ","),
+		r.CreatedAt.Format(time.RFC3339),
+		r.UpdatedAt.Format(time.RFC3339),
+		r.Body,
+	})
+}
+
+func (w *csvWriter) Close() error {
+	// An empty export still names its columns.
+	if err := w.header(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type markdownWriter struct {
+	zip   *zip.Writer
+	names map[string]int
+}
+
+func (w *markdownWriter) Write(a *model.Article) error {
+	r := newRecord(a)
+
+	frontMatter, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	var doc bytes.Buffer
+	doc.WriteString("---\n")
+	doc.Write(frontMatter)
+	doc.WriteString("---\n\n")
+	doc.WriteString(r.Body)
+	doc.WriteString("\n")
+
+	file, err := w.zip.CreateHeader(&zip.FileHeader{
+		Name:     w.name(r.Slug),
+		Method:   zip.Deflate,
+		Modified: r.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(doc.Bytes())
+	return err
+}
+
+// name returns the file name of an article. Slugs are unique, but guard against
+// duplicates so no file is shadowed in the archive.
+func (w *markdownWriter) name(slug string) string {
+	if w.names == nil {
+		w.names = make(map[string]int)
+	}
+	w.names[slug]++
+	if n := w.names[slug]; n > 1 {
+		return slug + "-" + strconv.Itoa(n) + ".md"
+	}
+	return slug + ".md"
+}
+
+func (w *markdownWriter) Close() error {
+	return w.zip.Close()
+}
@@ -0,0 +1,150 @@
+package articleio
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportedArticles() []*model.Article {
+	created := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	categoryID := uuid.New()
+	return []*model.Article{
+		{
+			ID:         uuid.New(),
+			Author:     "Jane Doe",
+			Title:      "Hello, \"world\"",
+			Slug:       "hello-world",
+			Language:   "en",
+			Excerpt:    "A greeting.",
+			CategoryID: &categoryID,
+			Tags:       []string{"go", "testing"},
+			Body:       "# Hello\n\n---\n\nA body with a rule, \"quotes\" and, commas.",
+			CreatedAt:  created,
+			UpdatedAt:  created.Add(time.Hour),
+		},
+		{
+			ID:        uuid.New(),
+			Author:    "John Doe",
+			Title:     "Halo",
+			Slug:      "halo",
+			Language:  "id",
+			Body:      "Isi",
+			CreatedAt: created.In(time.FixedZone("WIB", 7*60*60)),
+			UpdatedAt: created,
+		},
+	}
+}
+
+// export writes articles in format and returns the output.
+func export(t *testing.T, format string, articles []*model.Article) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+	for _, a := range articles {
+		require.NoError(t, w.Write(a))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// assertImported checks that rows read back from an export match the exported articles.
+func assertImported(t *testing.T, articles []*model.Article, rows []*model.ArticleImportRow) {
+	t.Helper()
+
+	require.Len(t, rows, len(articles))
+	for i, a := range articles {
+		row := rows[i]
+		assert.Equal(t, a.Author, row.Author)
+		assert.Equal(t, a.Title, row.Title)
+		assert.Equal(t, a.Body, row.Body)
+		assert.Equal(t, a.Excerpt, row.Summary)
+		assert.Equal(t, a.Language, row.Language)
+		if a.CategoryID != nil {
+			assert.Equal(t, a.CategoryID.String(), row.CategoryID)
+		} else {
+			assert.Empty(t, row.CategoryID)
+		}
+		if len(a.Tags) > 0 {
+			assert.Equal(t, a.Tags, row.Tags)
+		} else {
+			assert.Empty(t, row.Tags)
+		}
+		require.NotNil(t, row.CreatedAt)
+		assert.True(t, a.CreatedAt.Equal(*row.CreatedAt))
+	}
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	articles := exportedArticles()
+
+	t.Run("ndjson", func(t *testing.T) {
+		out := export(t, model.TransferFormatNDJSON, articles)
+		assert.Equal(t, 2, strings.Count(string(out), "\n"))
+
+		rows, failed := readAll(t, NewNDJSONReader(bytes.NewReader(out)))
+		require.Empty(t, failed)
+		assertImported(t, articles, rows)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		out := export(t, model.TransferFormatCSV, articles)
+		assert.True(t, strings.HasPrefix(string(out), strings.Join(csvColumns, ",")+"\n"))
+
+		rows, failed := readAll(t, NewCSVReader(bytes.NewReader(out)))
+		require.Empty(t, failed)
+		assertImported(t, articles, rows)
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		out := export(t, model.TransferFormatMarkdown, articles)
+
+		archive, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+		require.NoError(t, err)
+		require.Len(t, archive.File, 2)
+		assert.Equal(t, "hello-world.md", archive.File[0].Name)
+
+		// Files are read in lexical order of their names.
+		rows, failed := readAll(t, NewMarkdownReader(archive))
+		require.Empty(t, failed)
+		assertImported(t, []*model.Article{articles[1], articles[0]}, rows)
+	})
+}
+
+func TestWriter_Empty(t *testing.T) {
+	assert.Empty(t, export(t, model.TransferFormatNDJSON, nil))
+
+	// An empty CSV export still names its columns.
+	assert.Equal(t, strings.Join(csvColumns, ",")+"\n", string(export(t, model.TransferFormatCSV, nil)))
+
+	out := export(t, model.TransferFormatMarkdown, nil)
+	archive, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+	assert.Empty(t, archive.File)
+}
+
+func TestMarkdownWriter_DuplicateSlugs(t *testing.T) {
+	articles := exportedArticles()
+	articles[1].Slug = articles[0].Slug
+
+	out := export(t, model.TransferFormatMarkdown, articles)
+	archive, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+	require.Len(t, archive.File, 2)
+	assert.Equal(t, "hello-world.md", archive.File[0].Name)
+	assert.Equal(t, "hello-world-2.md", archive.File[1].Name)
+}
+
+func TestNewWriter_UnknownFormat(t *testing.T) {
+	_, err := NewWriter("xml", &bytes.Buffer{})
+	assert.EqualError(t, err, `unknown format "xml"`)
+}
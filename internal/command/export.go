@@ -0,0 +1,99 @@
+package command
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/bagasss3/go-article/internal/articleio"
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/database"
+	"github.com/bagasss3/go-article/internal/repository"
+	"github.com/bagasss3/go-article/internal/service"
+	"github.com/bagasss3/go-article/pkg/model"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "export articles to a file",
+	Long: `Export the articles matching the filters, oldest first, as NDJSON, CSV or a zip archive
+of Markdown files with YAML front matter. The output can be imported again.`,
+	Args: cobra.NoArgs,
+	RunE: exportArticles,
+}
+
+func init() {
+	exportCmd.Flags().String("format", model.TransferFormatNDJSON, "ndjson, csv or markdown")
+	exportCmd.Flags().StringP("output", "o", "-", "file to write, - for standard output")
+	exportCmd.Flags().String("query", "", "only articles containing this text")
+	exportCmd.Flags().String("lang", "", "only articles written in this language")
+	exportCmd.Flags().String("author", "", "only articles of authors matching this name")
+	exportCmd.Flags().String("category", "", "only articles in this category or its descendants")
+	exportCmd.Flags().String("tag", "", "only articles with this tag")
+	exportCmd.Flags().String("created-from", "", "only articles created at or after 2006-01-02T15:04")
+	exportCmd.Flags().String("created-to", "", "only articles created at or before 2006-01-02T15:04")
+	RootCmd.AddCommand(exportCmd)
+}
+
+func exportArticles(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	var filter model.ArticleQuery
+	filter.Query, _ = cmd.Flags().GetString("query")
+	filter.Lang, _ = cmd.Flags().GetString("lang")
+	filter.Author, _ = cmd.Flags().GetString("author")
+	filter.Category, _ = cmd.Flags().GetString("category")
+	filter.Tag, _ = cmd.Flags().GetString("tag")
+	filter.CreatedFrom, _ = cmd.Flags().GetString("created-from")
+	filter.CreatedTo, _ = cmd.Flags().GetString("created-to")
+
+	format, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+
+	var out io.Writer = os.Stdout
+	if output != "-" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	w, err := articleio.NewWriter(format, out)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	db, err := database.InitDB(ctx, config.DBDSN())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	redisConn := database.NewRedisConn(config.RedisHost())
+	defer redisConn.Close()
+
+	cacher := cache.NewRedisCache(redisConn)
+	articleService := service.NewArticleService(
+		repository.NewArticleRepository(db, cacher),
+		repository.NewAuthorRepository(db, cacher),
+		repository.NewCategoryRepository(db, cacher),
+		repository.NewPostgresSearchIndex(db),
+		nil,
+	)
+
+	if err := articleService.Export(ctx, filter, w); err != nil {
+		return err
+	}
+
+	if output != "-" {
+		log.WithField("output", output).Info("Export finished")
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package command
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/articleio"
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/database"
+	"github.com/bagasss3/go-article/internal/repository"
+	"github.com/bagasss3/go-article/internal/service"
+	"github.com/bagasss3/go-article/pkg/model"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <path>",
+	Short: "import articles from a file or directory",
+	Long: `Import articles from an NDJSON or CSV file, or from a directory or zip archive of
+Markdown files with YAML front matter. Authors are matched by name and created when missing.
+Rows that fail are reported without stopping the import. Use - to read standard input.`,
+	Args: cobra.ExactArgs(1),
+	RunE: importArticles,
+}
+
+func init() {
+	importCmd.Flags().String("format", "", "ndjson, csv or markdown (default: from the file extension)")
+	RootCmd.AddCommand(importCmd)
+}
+
+func importArticles(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	source := args[0]
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		format = importFormat(source)
+	}
+	if !slices.Contains(model.TransferFormats, format) {
+		return fmt.Errorf("cannot tell the format of %s, set --format to one of %s", source, strings.Join(model.TransferFormats, ", "))
+	}
+
+	rows, closeSource, err := openImport(source, format)
+	if err != nil {
+		return err
+	}
+	defer closeSource()
+
+	ctx := context.Background()
+
+	db, err := database.InitDB(ctx, config.DBDSN())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	redisConn := database.NewRedisConn(config.RedisHost())
+	defer redisConn.Close()
+
+	cacher := cache.NewRedisCache(redisConn)
+	indexer, closeIndexer, err := openIndexer(db, cacher)
+	if err != nil {
+		return err
+	}
+	defer closeIndexer()
+
+	// Imported articles are indexed one by one, as when created through the API.
+	var events model.ArticleEventHandler
+	if indexer != nil {
+		events = indexer
+	}
+	articleService := service.NewArticleService(
+		repository.NewArticleRepository(db, cacher),
+		repository.NewAuthorRepository(db, cacher),
+		repository.NewCategoryRepository(db, cacher),
+		repository.NewPostgresSearchIndex(db),
+		events,
+	)
+
+	start := time.Now()
+	result, err := articleService.Import(ctx, rows)
+	if err != nil {
+		return err
+	}
+
+	for _, rowErr := range result.Errors {
+		log.Warn(rowErr.Error())
+	}
+	log.WithFields(log.Fields{
+		"imported": result.Imported,
+		"failed":   result.Failed,
+		"duration": time.Since(start),
+	}).Info("Import finished")
+
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d articles failed to import", result.Failed, result.Imported+result.Failed)
+	}
+	return nil
+}
+
+// importFormat infers the format of an import source from its extension.
+func importFormat(source string) string {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return model.TransferFormatMarkdown
+	}
+
+	switch strings.ToLower(filepath.Ext(source)) {
+	case ".ndjson", ".jsonl":
+		return model.TransferFormatNDJSON
+	case ".csv":
+		return model.TransferFormatCSV
+	case ".zip":
+		return model.TransferFormatMarkdown
+	}
+	return ""
+}
+
+// openImport opens source as a reader of format and returns the func that closes it.
+func openImport(source, format string) (model.ArticleImportReader, func(), error) {
+	if format == model.TransferFormatMarkdown {
+		if info, err := os.Stat(source); err == nil && info.IsDir() {
+			return articleio.NewMarkdownReader(os.DirFS(source)), func() {}, nil
+		}
+
+		archive, err := zip.OpenReader(source)
+		if err != nil {
+			return nil, nil, err
+		}
+		return articleio.NewMarkdownReader(archive), func() { archive.Close() }, nil
+	}
+
+	var file io.ReadCloser = os.Stdin
+	if source != "-" {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, nil, err
+		}
+		file = f
+	}
+
+	if format == model.TransferFormatCSV {
+		return articleio.NewCSVReader(file), func() { file.Close() }, nil
+	}
+	return articleio.NewNDJSONReader(file), func() { file.Close() }, nil
+}
@@ -2,12 +2,19 @@ package command
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/bagasss3/go-article/database/migrations"
 	"github.com/bagasss3/go-article/internal/config"
 	"github.com/bagasss3/go-article/internal/infrastructure/database"
-	"github.com/pressly/goose"
+	"github.com/pressly/goose/v3"
 	"github.com/spf13/cobra"
 )
 
@@ -15,45 +22,344 @@ var migrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "run migrate database",
 	Long:  "Start migrate database",
-	Run:   migration,
+	Args:  cobra.NoArgs,
+	// Usage is only useful for argument errors, which cobra reports before this runs.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		goose.SetBaseFS(migrationsFS(cmd))
+		goose.SetTableName(database.MigrationsTable)
+		return goose.SetDialect("postgres")
+	},
+	RunE: migration,
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "print the status of every migration",
+	Args:  cobra.NoArgs,
+	RunE:  migrationStatus,
+}
+
+var migrateUpToCmd = &cobra.Command{
+	Use:   "up-to <version>",
+	Short: "apply the pending migrations up to and including version",
+	Args:  cobra.ExactArgs(1),
+	RunE:  migrationUpTo,
+}
+
+var migrateDownToCmd = &cobra.Command{
+	Use:   "down-to <version>",
+	Short: "roll back the migrations newer than version; 0 rolls back all",
+	Args:  cobra.ExactArgs(1),
+	RunE:  migrationDownTo,
+}
+
+var migrateRedoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "roll back the latest applied migration and apply it again",
+	Args:  cobra.NoArgs,
+	RunE:  migrationRedo,
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "create a timestamped SQL migration",
+	Args:  cobra.ExactArgs(1),
+	RunE:  migrationCreate,
+}
+
+var migrateValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "check the migration files without running them",
+	Args:  cobra.NoArgs,
+	RunE:  migrationValidate,
 }
 
 func init() {
-	migrateCmd.PersistentFlags().String("direction", "up", "migration direction up/down")
+	migrateCmd.Flags().String("direction", "up", "migration direction up/down")
+	migrateCmd.PersistentFlags().String("dir", "", "read migrations from this directory instead of the embedded ones")
+	migrateCmd.PersistentFlags().Bool("dry-run", false, "print the SQL that would run instead of running it")
+
+	migrateCmd.AddCommand(migrateStatusCmd, migrateUpToCmd, migrateDownToCmd, migrateRedoCmd, migrateCreateCmd, migrateValidateCmd)
 	RootCmd.AddCommand(migrateCmd)
 }
 
-func migration(cmd *cobra.Command, args []string) {
+// migrationStep is one migration run in one direction.
+type migrationStep struct {
+	migration *goose.Migration
+	up        bool
+}
+
+func migration(cmd *cobra.Command, args []string) error {
 	direction := cmd.Flag("direction").Value.String()
+	if direction != "up" && direction != "down" {
+		return fmt.Errorf("invalid direction %q, must be up or down", direction)
+	}
+
+	return withDB(cmd, func(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+		if dryRun(cmd) {
+			current, err := database.CurrentMigration(ctx, db)
+			if err != nil {
+				return err
+			}
+
+			var steps []migrationStep
+			if direction == "up" {
+				steps, err = planUpTo(current, goose.MaxVersion)
+			} else {
+				steps, err = planDownOne(current)
+			}
+			if err != nil {
+				return err
+			}
+			return printPlan(fsys, steps)
+		}
+
+		var err error
+		if direction == "up" {
+			err = goose.UpContext(ctx, db, ".")
+		} else {
+			err = goose.DownContext(ctx, db, ".")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to migrate database %s: %w", direction, err)
+		}
+
+		log.WithFields(log.Fields{
+			"direction": direction,
+		}).Info("Success applied migrations!")
+		return nil
+	})
+}
 
-	err := goose.SetDialect("postgres")
+func migrationStatus(cmd *cobra.Command, args []string) error {
+	return withDB(cmd, func(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+		return goose.StatusContext(ctx, db, ".")
+	})
+}
+
+func migrationUpTo(cmd *cobra.Command, args []string) error {
+	version, err := parseVersion(args[0])
 	if err != nil {
-		log.Error(err)
+		return err
 	}
-	goose.SetTableName("schema_migrations")
+
+	return withDB(cmd, func(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+		if dryRun(cmd) {
+			current, err := database.CurrentMigration(ctx, db)
+			if err != nil {
+				return err
+			}
+			steps, err := planUpTo(current, version)
+			if err != nil {
+				return err
+			}
+			return printPlan(fsys, steps)
+		}
+
+		if err := goose.UpToContext(ctx, db, ".", version); err != nil {
+			return fmt.Errorf("failed to migrate database up to %d: %w", version, err)
+		}
+		log.WithField("version", version).Info("Success applied migrations!")
+		return nil
+	})
+}
+
+func migrationDownTo(cmd *cobra.Command, args []string) error {
+	version, err := parseVersion(args[0])
+	if err != nil {
+		return err
+	}
+
+	return withDB(cmd, func(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+		if dryRun(cmd) {
+			current, err := database.CurrentMigration(ctx, db)
+			if err != nil {
+				return err
+			}
+			steps, err := planDownTo(current, version)
+			if err != nil {
+				return err
+			}
+			return printPlan(fsys, steps)
+		}
+
+		if err := goose.DownToContext(ctx, db, ".", version); err != nil {
+			return fmt.Errorf("failed to migrate database down to %d: %w", version, err)
+		}
+		log.WithField("version", version).Info("Success rolled back migrations!")
+		return nil
+	})
+}
+
+func migrationRedo(cmd *cobra.Command, args []string) error {
+	return withDB(cmd, func(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+		if dryRun(cmd) {
+			current, err := database.CurrentMigration(ctx, db)
+			if err != nil {
+				return err
+			}
+
+			steps, err := planDownOne(current)
+			if err != nil {
+				return err
+			}
+			if len(steps) == 1 {
+				steps = append(steps, migrationStep{migration: steps[0].migration, up: true})
+			}
+			return printPlan(fsys, steps)
+		}
+
+		if err := goose.RedoContext(ctx, db, "."); err != nil {
+			return fmt.Errorf("failed to redo migration: %w", err)
+		}
+		log.Info("Success redid the latest migration!")
+		return nil
+	})
+}
+
+func migrationCreate(cmd *cobra.Command, args []string) error {
+	dir := cmd.Flag("dir").Value.String()
+	if dir == "" {
+		dir = database.MigrationsDir
+	}
+	if err := goose.Create(nil, dir, args[0], "sql"); err != nil {
+		return fmt.Errorf("failed to create migration: %w", err)
+	}
+	return nil
+}
+
+func migrationValidate(cmd *cobra.Command, args []string) error {
+	count, err := database.ValidateMigrations(migrationsFS(cmd))
+	if err != nil {
+		return fmt.Errorf("invalid migrations: %w", err)
+	}
+
+	log.WithField("migrations", count).Info("Migrations are valid")
+	return nil
+}
+
+// withDB runs fn against the configured database and the migrations selected by the
+// --dir flag.
+func withDB(cmd *cobra.Command, fn func(ctx context.Context, db *sql.DB, fsys fs.FS) error) error {
 	ctx := context.Background()
 
 	db, err := database.InitDB(ctx, config.DBDSN())
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer db.Close()
 
-	var dir string = "./database/migrations"
-	if direction == "up" {
-		err = goose.Up(db, dir)
-	} else {
-		err = goose.Down(db, dir)
+	return fn(ctx, db, migrationsFS(cmd))
+}
+
+// migrationsFS returns the migrations embedded in the binary, or the directory of the
+// --dir flag. Goose reads either from the root, ".".
+func migrationsFS(cmd *cobra.Command) fs.FS {
+	if dir := cmd.Flag("dir").Value.String(); dir != "" {
+		return os.DirFS(dir)
 	}
+	return migrations.FS
+}
+
+func dryRun(cmd *cobra.Command) bool {
+	dry, _ := cmd.Flags().GetBool("dry-run")
+	return dry
+}
 
+func parseVersion(arg string) (int64, error) {
+	version, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid version %q", arg)
+	}
+	return version, nil
+}
+
+// planUpTo lists the migrations newer than current and not newer than target, oldest first.
+func planUpTo(current, target int64) ([]migrationStep, error) {
+	if target <= current {
+		return nil, nil
+	}
+
+	migrations, err := collectMigrations(current, target)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"direction": direction}).
-			Fatal("Failed to migrate database: ", err)
+		return nil, err
+	}
+
+	steps := make([]migrationStep, 0, len(migrations))
+	for _, m := range migrations {
+		steps = append(steps, migrationStep{migration: m, up: true})
+	}
+	return steps, nil
+}
+
+// planDownTo lists the migrations not newer than current and newer than target, newest first.
+func planDownTo(current, target int64) ([]migrationStep, error) {
+	if target >= current {
+		return nil, nil
+	}
+
+	migrations, err := collectMigrations(current, target)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]migrationStep, 0, len(migrations))
+	for i := len(migrations) - 1; i >= 0; i-- {
+		steps = append(steps, migrationStep{migration: migrations[i], up: false})
 	}
+	return steps, nil
+}
 
-	log.WithFields(log.Fields{
-		"direction": direction,
-	}).Info("Success applied migrations!")
+// planDownOne lists the current migration, the only one goose down rolls back.
+func planDownOne(current int64) ([]migrationStep, error) {
+	if current == 0 {
+		return nil, nil
+	}
 
+	migrations, err := collectMigrations(0, goose.MaxVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := migrations.Current(current)
+	if err != nil {
+		return nil, fmt.Errorf("no migration file for version %d: %w", current, err)
+	}
+	return []migrationStep{{migration: m, up: false}}, nil
+}
+
+// collectMigrations is goose.CollectMigrations without the error for an empty result.
+func collectMigrations(current, target int64) (goose.Migrations, error) {
+	migrations, err := goose.CollectMigrations(".", current, target)
+	if err == goose.ErrNoMigrationFiles {
+		return nil, nil
+	}
+	return migrations, err
+}
+
+func printPlan(fsys fs.FS, steps []migrationStep) error {
+	if len(steps) == 0 {
+		fmt.Println("-- no migrations to run")
+		return nil
+	}
+
+	for _, step := range steps {
+		direction := "Down"
+		if step.up {
+			direction = "Up"
+		}
+
+		if filepath.Ext(step.migration.Source) != ".sql" {
+			fmt.Printf("-- %s (%s): Go migration, SQL not available\n\n", filepath.Base(step.migration.Source), direction)
+			continue
+		}
+
+		stmt, err := database.MigrationSQL(fsys, step.migration.Source, step.up)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("-- %s (%s)\n%s\n\n", filepath.Base(step.migration.Source), direction, stmt)
+	}
+	return nil
 }
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/database"
+	"github.com/bagasss3/go-article/internal/repository"
+	"github.com/bagasss3/go-article/internal/service"
+	"github.com/bagasss3/go-article/pkg/model"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var reindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "rebuild the search index",
+	Long: `Rebuild the search index from the articles in the database. The bleve index can only be
+opened by one process, so stop the server first.`,
+	Args: cobra.NoArgs,
+	RunE: reindex,
+}
+
+func init() {
+	RootCmd.AddCommand(reindexCmd)
+}
+
+func reindex(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	if config.SearchBackend() == model.SearchBackendPostgres {
+		log.Info("The postgres search backend reads the articles directly; there is nothing to reindex")
+		return nil
+	}
+
+	ctx := context.Background()
+
+	db, err := database.InitDB(ctx, config.DBDSN())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	searchIndex, err := openSearchIndex(db)
+	if err != nil {
+		return err
+	}
+	defer searchIndex.Close()
+
+	redisConn := database.NewRedisConn(config.RedisHost())
+	defer redisConn.Close()
+
+	cacher := cache.NewRedisCache(redisConn)
+	searchIndexService := service.NewSearchIndexService(
+		searchIndex,
+		repository.NewArticleRepository(db, cacher),
+		repository.NewCategoryRepository(db, cacher),
+	)
+
+	start := time.Now()
+	indexed, err := searchIndexService.Reindex(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"articles": indexed,
+		"duration": time.Since(start),
+	}).Info("Reindex finished")
+	return nil
+}
+
+// openSearchIndex opens the search index of the configured backend.
+func openSearchIndex(db *sql.DB) (model.SearchIndex, error) {
+	switch backend := config.SearchBackend(); backend {
+	case model.SearchBackendPostgres:
+		return repository.NewPostgresSearchIndex(db), nil
+	case model.SearchBackendBleve:
+		return repository.NewBleveSearchIndex(config.SearchIndexPath())
+	default:
+		return nil, fmt.Errorf("unknown search backend %q", backend)
+	}
+}
+
+// openIndexer opens the search index for a command that writes articles, so that they
+// are indexed as the server indexes its writes. The postgres backend reads the articles
+// directly and needs no indexer. A bleve index held by the running server cannot be
+// opened, and the command fails before writing anything.
+func openIndexer(db *sql.DB, cacher cache.Cache) (model.SearchIndexMethodService, func(), error) {
+	if config.SearchBackend() == model.SearchBackendPostgres {
+		return nil, func() {}, nil
+	}
+
+	searchIndex, err := openSearchIndex(db)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w; stop the server first, or write through the API", err)
+	}
+
+	indexer := service.NewSearchIndexService(
+		searchIndex,
+		repository.NewArticleRepository(db, cacher),
+		repository.NewCategoryRepository(db, cacher),
+	)
+	return indexer, func() { searchIndex.Close() }, nil
+}
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"os"
+
 	"github.com/bagasss3/go-article/internal/config"
 
 	log "github.com/sirupsen/logrus"
@@ -11,6 +13,8 @@ var RootCmd = &cobra.Command{
 	Use:   "test",
 	Short: "Example Cobra",
 	Long:  "Example of using CLI created by Cobra",
+	// Errors are logged by Execute.
+	SilenceErrors: true,
 }
 
 func init() {
@@ -21,5 +25,6 @@ func init() {
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
 		log.Error(err)
+		os.Exit(1)
 	}
 }
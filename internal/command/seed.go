@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/database"
+	"github.com/bagasss3/go-article/internal/repository"
+	"github.com/bagasss3/go-article/internal/service"
+	"github.com/bagasss3/go-article/pkg/model"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "seed fake authors and articles",
+	Long:  "Create fake authors and articles for demos and load testing. The same --seed creates the same data, so rerunning a seed needs --wipe.",
+	Args:  cobra.NoArgs,
+	RunE:  seed,
+}
+
+func init() {
+	seedCmd.Flags().Int("authors", 10, "number of authors to create")
+	seedCmd.Flags().Int("articles", 100, "number of articles to create")
+	seedCmd.Flags().Int("batch-size", 1000, "rows per COPY batch")
+	seedCmd.Flags().Int64("seed", 1, "random seed")
+	seedCmd.Flags().Bool("wipe", false, "delete every author and article first")
+	RootCmd.AddCommand(seedCmd)
+}
+
+func seed(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	var opts model.SeedOptions
+	opts.Authors, _ = cmd.Flags().GetInt("authors")
+	opts.Articles, _ = cmd.Flags().GetInt("articles")
+	opts.BatchSize, _ = cmd.Flags().GetInt("batch-size")
+	opts.Seed, _ = cmd.Flags().GetInt64("seed")
+	opts.Wipe, _ = cmd.Flags().GetBool("wipe")
+
+	ctx := context.Background()
+
+	db, err := database.InitDB(ctx, config.DBDSN())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	redisConn := database.NewRedisConn(config.RedisHost())
+	defer redisConn.Close()
+
+	// Seeding goes through the repositories so cached listings are dropped as on any write.
+	cacher := cache.NewRedisCache(redisConn)
+	indexer, closeIndexer, err := openIndexer(db, cacher)
+	if err != nil {
+		return err
+	}
+	defer closeIndexer()
+
+	seedService := service.NewSeedService(
+		repository.NewArticleRepository(db, cacher),
+		repository.NewAuthorRepository(db, cacher),
+	)
+
+	start := time.Now()
+	result, err := seedService.Seed(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"authors":  result.Authors,
+		"articles": result.Articles,
+		"seed":     opts.Seed,
+		"duration": time.Since(start),
+	}).Info("Success seeded database!")
+
+	if indexer == nil {
+		return nil
+	}
+
+	// Seeding copies articles in bulk and may wipe the old ones, so the index is rebuilt.
+	start = time.Now()
+	indexed, err := indexer.Reindex(ctx)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"articles": indexed,
+		"duration": time.Since(start),
+	}).Info("Reindex finished")
+	return nil
+}
@@ -12,7 +12,11 @@ import (
 	"github.com/bagasss3/go-article/internal/config"
 	"github.com/bagasss3/go-article/internal/infrastructure/cache"
 	"github.com/bagasss3/go-article/internal/infrastructure/database"
+	"github.com/bagasss3/go-article/internal/infrastructure/health"
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
 	"github.com/bagasss3/go-article/internal/infrastructure/server"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
 	"github.com/bagasss3/go-article/internal/middleware"
 	"github.com/bagasss3/go-article/internal/repository"
 	"github.com/bagasss3/go-article/internal/service"
@@ -35,13 +39,17 @@ func init() {
 }
 
 func runServer(cmd *cobra.Command, args []string) {
-	log.SetReportCaller(true)
-	log.SetFormatter(&log.JSONFormatter{})
+	logger.Init()
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	shutdownTracing, err := tracing.Init(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Initialize DBs
 	db, err := database.InitDB(ctx, config.DBDSN())
 	if err != nil {
@@ -49,11 +57,23 @@ func runServer(cmd *cobra.Command, args []string) {
 	}
 	defer db.Close()
 
+	if config.AutoMigrate() {
+		if err := database.Migrate(ctx, db); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := metrics.RegisterDB(db, config.DBDatabase()); err != nil {
+		log.WithError(err).Warn("failed to register database metrics")
+	}
+
 	redisConn := database.NewRedisConn(config.RedisHost())
 	defer redisConn.Close()
 
 	// cache
-	cacher := cache.NewRedisCache(redisConn)
+	cacher := cache.NewTracedCache(cache.NewInstrumentedCache(cache.NewRedisCache(redisConn)))
+	counter := cache.NewRedisCounter(redisConn)
+	visits := cache.NewRedisVisitCounter(redisConn)
 
 	// Initialize Echo
 	httpServer := server.NewHTTPServer()
@@ -64,11 +84,59 @@ func runServer(cmd *cobra.Command, args []string) {
 
 	articleRepository := repository.NewArticleRepository(db, cacher)
 	authorRepository := repository.NewAuthorRepository(db, cacher)
+	tagRepository := repository.NewTagRepository(db, cacher)
+	categoryRepository := repository.NewCategoryRepository(db, cacher)
+	commentRepository := repository.NewCommentRepository(db, cacher)
+	reactionRepository := repository.NewReactionRepository(db, cacher)
+	viewRepository := repository.NewViewRepository(db, cacher)
+	searchRepository := repository.NewSearchRepository(db)
+
+	searchIndex, err := openSearchIndex(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer searchIndex.Close()
+	searchIndexService := service.NewSearchIndexService(searchIndex, articleRepository, categoryRepository)
 
-	articleService := service.NewArticleService(articleRepository, authorRepository)
+	articleService := service.NewArticleService(articleRepository, authorRepository, categoryRepository, searchIndex, searchIndexService)
 	authorService := service.NewAuthorService(authorRepository)
+	tagService := service.NewTagService(tagRepository)
+	categoryService := service.NewCategoryService(categoryRepository, articleRepository, searchIndexService)
+	commentService := service.NewCommentService(commentRepository, articleRepository)
+	reactionService := service.NewReactionService(reactionRepository, articleRepository, articleService, counter)
+	viewService := service.NewViewService(viewRepository, articleRepository, visits, cacher)
+	searchService := service.NewSearchService(searchRepository, searchIndex, cacher)
+
+	feedService := service.NewFeedService(articleService, authorRepository, cacher)
+	sitemapService := service.NewSitemapService(articleRepository, authorRepository, cacher)
+
+	registerHandlers(httpServer.Engine(), articleService, viewService, searchService, authorService, tagService, categoryService, commentService, reactionService, feedService, sitemapService)
+
+	checker := health.NewChecker(config.HealthTimeout())
+	checker.Add("postgres", health.Postgres(db))
+	checker.Add("redis", health.Redis(redisConn))
+	checker.Add("migrations", health.Migrations(db))
+	checker.Register(httpServer.Engine())
+
+	// Metrics go to the admin port when one is configured, so they are not public.
+	adminServer := newAdminServer()
+	if adminServer == nil {
+		httpServer.Engine().GET("/metrics", echo.WrapHandler(metrics.Handler()))
+	} else {
+		go func() {
+			log.WithField("addr", adminServer.Addr).Info("Starting admin server")
+
+			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.WithError(err).Error("Admin server failed unexpectedly")
+			}
+		}()
+	}
 
-	registerHandlers(httpServer.Engine(), articleService, authorService)
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	go runEvery(jobsCtx, config.ReactionFlushInterval(), reactionService.Flush, "failed to flush reaction counts")
+	go runEvery(jobsCtx, config.ViewFlushInterval(), viewService.Flush, "failed to flush article views")
+	go runEvery(jobsCtx, config.SearchWordsRefreshInterval(), searchService.RefreshWords, "failed to refresh search words")
 
 	// Setup signal handling
 	signalCh := make(chan os.Signal, 1)
@@ -87,6 +155,11 @@ func runServer(cmd *cobra.Command, args []string) {
 	<-signalCh
 	log.Info("Received shutdown signal")
 
+	// Fail readiness first and keep serving while load balancers notice.
+	checker.Drain()
+	log.WithField("delay", config.HealthDrainDelay()).Info("Draining traffic")
+	time.Sleep(config.HealthDrainDelay())
+
 	// Gracefully shutdown the server
 	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 10*time.Second)
 	defer shutdownCancel()
@@ -95,12 +168,74 @@ func runServer(cmd *cobra.Command, args []string) {
 		log.WithError(err).Error("Server shutdown failed")
 	}
 
+	// Write the reactions and views counted since the last flush.
+	stopJobs()
+	if err := reactionService.Flush(shutdownCtx); err != nil {
+		log.WithError(err).Error("Reaction flush failed")
+	}
+	if err := viewService.Flush(shutdownCtx); err != nil {
+		log.WithError(err).Error("View flush failed")
+	}
+
+	if adminServer != nil {
+		if err := adminServer.Shutdown(shutdownCtx); err != nil {
+			log.WithError(err).Error("Admin server shutdown failed")
+		}
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.WithError(err).Error("Tracing shutdown failed")
+	}
+
 	log.Info("Server shutdown complete")
 }
 
-func registerHandlers(e *echo.Echo, articleSvc model.ArticleMethodService, authorSvc model.AuthorMethodService) {
+// runEvery calls job every interval until ctx ends, logging failures with message.
+func runEvery(ctx context.Context, interval time.Duration, job func(context.Context) error, message string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				log.WithError(err).Warn(message)
+			}
+		}
+	}
+}
+
+// newAdminServer returns the server for operational endpoints, or nil when no admin
+// port is configured.
+func newAdminServer() *http.Server {
+	if config.AdminPort() == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	return &http.Server{
+		Addr:              ":" + config.AdminPort(),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+func registerHandlers(e *echo.Echo, articleSvc model.ArticleMethodService, viewSvc model.ViewMethodService, searchSvc model.SearchMethodService, authorSvc model.AuthorMethodService, tagSvc model.TagMethodService, categorySvc model.CategoryMethodService, commentSvc model.CommentMethodService, reactionSvc model.ReactionMethodService, feedSvc model.FeedMethodService, sitemapSvc model.SitemapMethodService) {
 	v1 := e.Group("/api/v1")
 
-	handler.NewArticleHandler(articleSvc).Register(v1)
+	handler.NewArticleHandler(articleSvc, viewSvc, searchSvc).Register(v1)
+	handler.NewSearchHandler(searchSvc).Register(v1)
 	handler.NewAuthorHandler(authorSvc).Register(v1)
+	handler.NewTagHandler(tagSvc).Register(v1)
+	handler.NewCategoryHandler(categorySvc).Register(v1)
+	handler.NewCommentHandler(commentSvc).Register(v1)
+	handler.NewReactionHandler(reactionSvc).Register(v1)
+
+	root := e.Group("")
+	handler.NewFeedHandler(feedSvc).Register(root)
+	handler.NewSitemapHandler(sitemapSvc).Register(root)
 }
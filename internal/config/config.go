@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bagasss3/go-article/internal/helper"
@@ -22,6 +23,29 @@ func InitConfig() {
 	log.Info("Using config file: ", viper.ConfigFileUsed())
 }
 
+func Env() string {
+	if !viper.IsSet("env") {
+		return "development"
+	}
+	return viper.GetString("env")
+}
+
+// LogLevel is a logrus level name (debug, info, warn, error).
+func LogLevel() string {
+	if viper.GetString("log.level") != "" {
+		return viper.GetString("log.level")
+	}
+	return DefaultLogLevel
+}
+
+// LogFormat is json or text.
+func LogFormat() string {
+	if viper.GetString("log.format") != "" {
+		return viper.GetString("log.format")
+	}
+	return DefaultLogFormat
+}
+
 func Port() string {
 	if !viper.IsSet("port") {
 		return "8080"
@@ -29,6 +53,41 @@ func Port() string {
 	return viper.GetString("port")
 }
 
+// AdminPort is the port of the admin server exposing /metrics. When it is empty the
+// metrics are served by the main server.
+// AutoMigrate makes the server apply pending migrations at startup.
+func AutoMigrate() bool {
+	return viper.GetBool("auto_migrate")
+}
+
+func AdminPort() string {
+	return viper.GetString("admin.port")
+}
+
+// TracingExporter is one of none (default), otlp, stdout or file.
+func TracingExporter() string {
+	return viper.GetString("tracing.exporter")
+}
+
+// TracingEndpoint is the OTLP/HTTP collector URL, e.g. http://otel-collector:4318.
+func TracingEndpoint() string {
+	return viper.GetString("tracing.endpoint")
+}
+
+func TracingFile() string {
+	if viper.GetString("tracing.file") != "" {
+		return viper.GetString("tracing.file")
+	}
+	return DefaultTracingFile
+}
+
+func TracingSampleRatio() float64 {
+	if !viper.IsSet("tracing.sampleRatio") {
+		return DefaultTracingSampleRatio
+	}
+	return viper.GetFloat64("tracing.sampleRatio")
+}
+
 func DBHost() string {
 	return viper.GetString("database.host")
 }
@@ -90,6 +149,19 @@ func DBPingInterval() time.Duration {
 	return helper.ParseTimeDuration(cfg, DefaultDBPingInterval)
 }
 
+// HealthTimeout bounds each dependency check of the readiness probe.
+func HealthTimeout() time.Duration {
+	cfg := viper.GetString("health.timeout")
+	return helper.ParseTimeDuration(cfg, DefaultHealthTimeout)
+}
+
+// HealthDrainDelay is how long readiness reports failure before the server stops
+// accepting requests on shutdown, so load balancers stop routing to it first.
+func HealthDrainDelay() time.Duration {
+	cfg := viper.GetString("health.drainDelay")
+	return helper.ParseTimeDuration(cfg, DefaultHealthDrainDelay)
+}
+
 func RedisHost() string {
 	return viper.GetString("redis.host")
 }
@@ -110,3 +182,147 @@ func RedisDB() int {
 func DisableCaching() bool {
 	return viper.GetBool("disable_caching")
 }
+
+func MaxArticleTags() int {
+	if viper.GetInt("article.maxTags") > 0 {
+		return viper.GetInt("article.maxTags")
+	}
+	return DefaultMaxArticleTags
+}
+
+func ExcerptLength() int {
+	if viper.GetInt("article.excerptLength") > 0 {
+		return viper.GetInt("article.excerptLength")
+	}
+	return DefaultExcerptLength
+}
+
+func WordsPerMinute() int {
+	if viper.GetInt("article.wordsPerMinute") > 0 {
+		return viper.GetInt("article.wordsPerMinute")
+	}
+	return DefaultWordsPerMinute
+}
+
+// ImportMaxBytes limits the request body of an article import.
+func ImportMaxBytes() int64 {
+	if viper.GetInt64("article.importMaxBytes") > 0 {
+		return viper.GetInt64("article.importMaxBytes")
+	}
+	return DefaultImportMaxBytes
+}
+
+// SiteURL is the public base URL used for absolute links, without a trailing slash.
+func SiteURL() string {
+	if viper.GetString("site.url") != "" {
+		return strings.TrimRight(viper.GetString("site.url"), "/")
+	}
+	return DefaultSiteURL
+}
+
+func SiteTitle() string {
+	if viper.GetString("site.title") != "" {
+		return viper.GetString("site.title")
+	}
+	return DefaultSiteTitle
+}
+
+func SiteDescription() string {
+	return viper.GetString("site.description")
+}
+
+// ArticleURL returns the public URL of the article with slug.
+func ArticleURL(slug string) string {
+	articlePath := DefaultArticlePath
+	if viper.GetString("site.articlePath") != "" {
+		articlePath = viper.GetString("site.articlePath")
+	}
+	return SiteURL() + articlePath + slug
+}
+
+// AuthorURL returns the public URL of the author page with id.
+func AuthorURL(id string) string {
+	authorPath := DefaultAuthorPath
+	if viper.GetString("site.authorPath") != "" {
+		authorPath = viper.GetString("site.authorPath")
+	}
+	return SiteURL() + authorPath + id
+}
+
+// FeedLimit is the number of newest articles in a feed.
+func FeedLimit() int {
+	if viper.GetInt("feed.limit") > 0 {
+		return viper.GetInt("feed.limit")
+	}
+	return DefaultFeedLimit
+}
+
+// SitemapPageSize is the number of URLs per sitemap file, at most the 50,000 the
+// protocol allows.
+func SitemapPageSize() int {
+	if size := viper.GetInt("sitemap.pageSize"); size > 0 && size < DefaultSitemapPageSize {
+		return size
+	}
+	return DefaultSitemapPageSize
+}
+
+// CommentMaxDepth is the depth of the deepest reply allowed; top-level comments have
+// depth 0.
+func CommentMaxDepth() int {
+	if viper.IsSet("comment.maxDepth") && viper.GetInt("comment.maxDepth") >= 0 {
+		return viper.GetInt("comment.maxDepth")
+	}
+	return DefaultCommentMaxDepth
+}
+
+// CommentAutoApprove publishes new and edited comments without moderation.
+func CommentAutoApprove() bool {
+	return viper.GetBool("comment.autoApprove")
+}
+
+// CommentModerators are the user IDs allowed to moderate comments.
+func CommentModerators() []string {
+	return viper.GetStringSlice("comment.moderators")
+}
+
+// ReactionFlushInterval is how often reaction counts move from Redis to Postgres.
+func ReactionFlushInterval() time.Duration {
+	cfg := viper.GetString("reaction.flushInterval")
+	return helper.ParseTimeDuration(cfg, DefaultReactionFlush)
+}
+
+// ViewFlushInterval is how often the unique views counted in Redis are rolled up into
+// Postgres.
+func ViewFlushInterval() time.Duration {
+	cfg := viper.GetString("view.flushInterval")
+	return helper.ParseTimeDuration(cfg, DefaultViewFlush)
+}
+
+// SearchWordsRefreshInterval is how often the words offered as "did you mean"
+// suggestions are rebuilt from the articles.
+func SearchWordsRefreshInterval() time.Duration {
+	cfg := viper.GetString("search.wordsRefreshInterval")
+	return helper.ParseTimeDuration(cfg, DefaultSearchWordsRefresh)
+}
+
+// SearchBackend is the search index, postgres (default) or bleve.
+func SearchBackend() string {
+	if viper.GetString("search.backend") != "" {
+		return viper.GetString("search.backend")
+	}
+	return DefaultSearchBackend
+}
+
+// SearchIndexPath is the directory of the bleve search index.
+func SearchIndexPath() string {
+	if viper.GetString("search.indexPath") != "" {
+		return viper.GetString("search.indexPath")
+	}
+	return DefaultSearchIndexPath
+}
+
+// TrendingCacheTTL is how long a trending list is served from the cache.
+func TrendingCacheTTL() time.Duration {
+	cfg := viper.GetString("view.trendingTTL")
+	return helper.ParseTimeDuration(cfg, DefaultTrendingCache)
+}
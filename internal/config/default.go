@@ -11,6 +11,40 @@ const (
 	DefaultRedisExpiredDuration time.Duration = 5 * time.Minute
 	DefaultDBRetryAttempts      int           = 3
 	DefaultDBPingInterval       time.Duration = 1 * time.Second
+	DefaultMaxArticleTags       int           = 10
+	DefaultExcerptLength        int           = 200
+	DefaultWordsPerMinute       int           = 200
+	DefaultImportMaxBytes       int64         = 32 << 20 // 32 MiB
+	DefaultSiteURL              string        = "http://localhost:8000"
+	DefaultSiteTitle            string        = "go-article"
+	DefaultArticlePath          string        = "/api/v1/article/slug/"
+	DefaultAuthorPath           string        = "/api/v1/author/"
+	DefaultFeedLimit            int           = 20
+	DefaultSitemapPageSize      int           = 50000
+	DefaultCommentMaxDepth      int           = 3
+	DefaultCommentLimit         int           = 20
+	MaxCommentLimit             int           = 100
+	DefaultReactionFlush        time.Duration = 10 * time.Second
+	DefaultViewFlush            time.Duration = time.Minute
+	DefaultTrendingCache        time.Duration = time.Minute
+	DefaultTrendingLimit        int           = 10
+	MaxTrendingLimit            int           = 50
+	DefaultRelatedLimit         int           = 5
+	MaxRelatedLimit             int           = 20
+	DefaultSuggestLimit         int           = 5
+	MaxSuggestLimit             int           = 10
+	DefaultFacetLimit           int           = 10
+	DefaultArticleLimit         int           = 10
+	DefaultArticleLanguage      string        = "en"
+	DefaultSearchWordsRefresh   time.Duration = 10 * time.Minute
+	DefaultSearchBackend        string        = "postgres"
+	DefaultSearchIndexPath      string        = "data/search.bleve"
+	DefaultTracingSampleRatio   float64       = 1
+	DefaultTracingFile          string        = "traces.json"
+	DefaultLogLevel             string        = "info"
+	DefaultLogFormat            string        = "json"
+	DefaultHealthTimeout        time.Duration = 2 * time.Second
+	DefaultHealthDrainDelay     time.Duration = 5 * time.Second
 
 	// Status
 	InternalServerError string = "Internal Server Error"
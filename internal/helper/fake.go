@@ -0,0 +1,161 @@
+package helper
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	fakeFirstNames = []string{
+		"Ava", "Bagas", "Chen", "Dewi", "Elena", "Farid", "Grace", "Hiro", "Intan", "James",
+		"Kirana", "Liam", "Maya", "Nadia", "Omar", "Putri", "Quinn", "Rafael", "Sari", "Tomas",
+	}
+	fakeLastNames = []string{
+		"Anderson", "Budiman", "Costa", "Dubois", "Eriksen", "Fischer", "Gunawan", "Hartono",
+		"Ivanova", "Jensen", "Kusuma", "Lestari", "Moreau", "Nakamura", "Okafor", "Pratama",
+	}
+	fakeAdjectives = []string{
+		"practical", "modern", "hidden", "simple", "complete", "quiet", "essential", "pragmatic",
+		"surprising", "reliable", "lightweight", "scalable", "forgotten", "honest", "gentle",
+	}
+	fakeNouns = []string{
+		"guide", "pattern", "pipeline", "database", "cache", "service", "garden", "kitchen",
+		"journey", "habit", "workflow", "migration", "index", "interface", "deadline", "team",
+	}
+	fakeTopics = []string{
+		"Go", "PostgreSQL", "Redis", "remote work", "coffee", "open source", "testing",
+		"observability", "cooking", "travel", "productivity", "code review", "APIs", "writing",
+	}
+	fakeTitleTemplates = []string{
+		"A %s %s to %s",
+		"Why %s matters more than you think",
+		"The %s %s behind %s",
+		"Notes on a %s %s for %s",
+		"What I learned about %s",
+		"How to build a %s %s with %s",
+	}
+	fakeWords = strings.Fields(`
+		the a of to and in that is for it with as was on be at by this from or have an
+		they which one you had not but what all were when we there can more if will each
+		about how up out them then she many some so these would other into has her like
+		time could people two see way first been call who its now find long down day did
+		system request query latency cache index table schema deploy release review metric
+		team morning garden recipe journey habit coffee window river mountain city street
+		simple careful quick steady clear honest gentle bright quiet useful small large
+	`)
+	fakeTags = []string{
+		"go", "postgres", "redis", "devops", "testing", "performance", "career", "travel",
+		"food", "design", "security", "tutorial", "opinion", "open-source", "productivity",
+	}
+)
+
+// Faker generates plausible demo content. The same seed always yields the same
+// sequence of values.
+type Faker struct {
+	rnd *rand.Rand
+}
+
+func NewFaker(seed int64) *Faker {
+	return &Faker{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// Intn returns a number in [0, n).
+func (f *Faker) Intn(n int) int {
+	return f.rnd.Intn(n)
+}
+
+func (f *Faker) pick(values []string) string {
+	return values[f.rnd.Intn(len(values))]
+}
+
+// UUID returns a random version 4 UUID drawn from the seeded source.
+func (f *Faker) UUID() uuid.UUID {
+	id, err := uuid.NewRandomFromReader(f.rnd)
+	if err != nil {
+		// The seeded source never fails to read.
+		panic(err)
+	}
+	return id
+}
+
+func (f *Faker) Name() string {
+	return f.pick(fakeFirstNames) + " " + f.pick(fakeLastNames)
+}
+
+func (f *Faker) Title() string {
+	template := f.pick(fakeTitleTemplates)
+	args := make([]any, strings.Count(template, "%s"))
+	for i := range args {
+		switch {
+		case i == len(args)-1:
+			args[i] = f.pick(fakeTopics)
+		case i%2 == 0:
+			args[i] = f.pick(fakeAdjectives)
+		default:
+			args[i] = f.pick(fakeNouns)
+		}
+	}
+
+	title := fmt.Sprintf(template, args...)
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+// Body returns a Markdown document of a few paragraphs, sometimes with a heading and a list.
+func (f *Faker) Body() string {
+	var b strings.Builder
+
+	paragraphs := 3 + f.rnd.Intn(5)
+	for i := 0; i < paragraphs; i++ {
+		if i > 0 && f.rnd.Intn(4) == 0 {
+			fmt.Fprintf(&b, "## %s\n\n", f.sentence(3, 6))
+		}
+		if f.rnd.Intn(6) == 0 {
+			for j := 0; j < 2+f.rnd.Intn(3); j++ {
+				fmt.Fprintf(&b, "- %s\n", f.sentence(4, 9))
+			}
+			b.WriteString("\n")
+		}
+
+		sentences := 3 + f.rnd.Intn(5)
+		for j := 0; j < sentences; j++ {
+			if j > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(f.sentence(8, 20))
+			b.WriteString(".")
+		}
+		b.WriteString("\n\n")
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+func (f *Faker) sentence(minWords, maxWords int) string {
+	n := minWords + f.rnd.Intn(maxWords-minWords+1)
+	words := make([]string, n)
+	for i := range words {
+		words[i] = f.pick(fakeWords)
+	}
+
+	sentence := strings.Join(words, " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:]
+}
+
+// Tags returns up to limit distinct tag slugs.
+func (f *Faker) Tags(limit int) []string {
+	n := f.rnd.Intn(limit + 1)
+	tags := make([]string, 0, n)
+	for _, i := range f.rnd.Perm(len(fakeTags))[:min(n, len(fakeTags))] {
+		tags = append(tags, fakeTags[i])
+	}
+	return tags
+}
+
+// Time returns a moment within the given period before now, truncated to the second.
+func (f *Faker) Time(now time.Time, within time.Duration) time.Time {
+	return now.Add(-time.Duration(f.rnd.Int63n(int64(within)))).Truncate(time.Second)
+}
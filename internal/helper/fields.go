@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ParseFields splits repeated or comma separated field names into a lowercase list
+// without duplicates.
+func ParseFields(raw []string) []string {
+	var (
+		fields []string
+		seen   = make(map[string]bool)
+	)
+	for _, value := range raw {
+		for _, part := range strings.Split(value, ",") {
+			field := strings.ToLower(strings.TrimSpace(part))
+			if field == "" || seen[field] {
+				continue
+			}
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// SelectFields returns the JSON representation of v, an object or a list of objects,
+// reduced to the given keys. v is returned unchanged when fields is empty.
+func SelectFields(v any, fields []string) (any, error) {
+	if len(fields) == 0 {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var items []map[string]json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			pick(item, fields)
+		}
+		return items, nil
+	}
+
+	var item map[string]json.RawMessage
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+	return pick(item, fields), nil
+}
+
+func pick(item map[string]json.RawMessage, fields []string) map[string]json.RawMessage {
+	keep := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		keep[f] = true
+	}
+	for key := range item {
+		if !keep[key] {
+			delete(item, key)
+		}
+	}
+	return item
+}
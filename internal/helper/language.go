@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParseAcceptLanguage returns the primary language subtags of an Accept-Language
+// header, lowercased and most preferred first ("id-ID, en;q=0.8" gives id, en).
+// Wildcards, languages with a zero quality and malformed entries are left out.
+func ParseAcceptLanguage(header string) []string {
+	type preference struct {
+		language string
+		quality  float64
+	}
+
+	var preferences []preference
+	for _, entry := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if language == "" || language == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		preferences = append(preferences, preference{language: language, quality: quality})
+	}
+
+	// Stable, so languages of equal quality keep the order they were listed in.
+	slices.SortStableFunc(preferences, func(a, b preference) int {
+		return cmp.Compare(b.quality, a.quality)
+	})
+
+	languages := make([]string, 0, len(preferences))
+	for _, p := range preferences {
+		if !slices.Contains(languages, p.language) {
+			languages = append(languages, p.language)
+		}
+	}
+	return languages
+}
@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+var (
+	// Raw HTML is passed through by goldmark and left to the sanitizer, so authors can
+	// still use the harmless subset (e.g. <sup>, <details>).
+	markdown = goldmark.New(
+		goldmark.WithExtensions(
+			// extension.GFM, with table alignment as attributes since the sanitizer drops styles.
+			extension.Linkify,
+			extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+			extension.Strikethrough,
+			extension.TaskList,
+		),
+		goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+	)
+
+	htmlPolicy = newHTMLPolicy()
+	textPolicy = bluemonday.StrictPolicy()
+)
+
+func newHTMLPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// Keep fenced code languages ("language-go") and GFM table alignment.
+	p.AllowAttrs("class").Matching(bluemonday.SpaceSeparatedTokens).OnElements("code")
+	p.AllowAttrs("align").Matching(bluemonday.CellAlign).OnElements("td", "th")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.AllowAttrs("type").Matching(regexp.MustCompile("^checkbox$")).OnElements("input")
+	return p
+}
+
+// RenderMarkdown renders CommonMark with GitHub Flavored Markdown extensions to HTML
+// and sanitizes the result with an allowlist policy.
+func RenderMarkdown(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return htmlPolicy.Sanitize(buf.String()), nil
+}
+
+// PlainText strips every tag from rendered HTML and collapses whitespace.
+func PlainText(renderedHTML string) string {
+	text := html.UnescapeString(textPolicy.Sanitize(renderedHTML))
+	return strings.Join(strings.Fields(text), " ")
+}
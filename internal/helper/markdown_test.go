@@ -0,0 +1,27 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMarkdown_TaskListInputs(t *testing.T) {
+	t.Run("task list checkboxes are kept", func(t *testing.T) {
+		html, err := RenderMarkdown("- [x] done\n- [ ] todo")
+		require.NoError(t, err)
+		assert.Contains(t, html, `<input checked="" disabled="" type="checkbox"> done`)
+		assert.Contains(t, html, `<input disabled="" type="checkbox"> todo`)
+	})
+
+	for _, kind := range []string{"text", "password", "submit", "hidden", "file"} {
+		t.Run(kind+" inputs lose their type", func(t *testing.T) {
+			html, err := RenderMarkdown(`<input type="` + kind + `" name="secret" value="x">`)
+			require.NoError(t, err)
+			assert.NotContains(t, html, "type=")
+			assert.NotContains(t, html, "name=")
+			assert.NotContains(t, html, "value=")
+		})
+	}
+}
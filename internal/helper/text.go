@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Excerpt shortens text to at most limit characters. It prefers to end after the last
+// complete sentence and otherwise cuts at a word boundary and appends an ellipsis.
+func Excerpt(text string, limit int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if limit <= 0 || len(runes) <= limit {
+		return string(runes)
+	}
+
+	cut := runes[:limit]
+	for i := len(cut) - 1; i > 0; i-- {
+		if !isSentenceEnd(cut[i]) {
+			continue
+		}
+		// Only a terminator followed by a space ends a sentence ("3.14" does not).
+		if i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			continue
+		}
+		// Keep going when the first sentence is too short to stand on its own.
+		if i+1 >= limit/3 {
+			return string(cut[:i+1])
+		}
+		break
+	}
+
+	if i := strings.LastIndexFunc(string(cut), unicode.IsSpace); i > 0 {
+		cut = []rune(string(cut)[:i])
+	}
+	return strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+func isSentenceEnd(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '。'
+}
+
+// WordCount counts the whitespace separated words of text.
+func WordCount(text string) int {
+	return len(strings.Fields(text))
+}
+
+// ReadingTime estimates the minutes needed to read words at wordsPerMinute, rounded up.
+func ReadingTime(words, wordsPerMinute int) int {
+	if words <= 0 || wordsPerMinute <= 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
@@ -2,11 +2,18 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrCacheMiss is returned by Get when the key does not exist.
+var ErrCacheMiss = errors.New("cache miss")
+
 type Cache interface {
 	Set(ctx context.Context, key string, value any, ttl time.Duration) error
 	Get(ctx context.Context, key string, target any) error
 	Delete(ctx context.Context, key string) error
+	// DeleteByPrefix removes every key starting with prefix. Keys are grouped by the
+	// namespace before their first colon, which prefix must include.
+	DeleteByPrefix(ctx context.Context, prefix string) error
 }
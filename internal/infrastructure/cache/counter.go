@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Counter accumulates increments in Redis hashes until they are drained, so hot
+// counters can be written to the database in batches.
+type Counter interface {
+	// IncrBy adds delta to field of the hash at key.
+	IncrBy(ctx context.Context, key, field string, delta int64) error
+	// Drain removes every hash whose key starts with prefix and returns its fields,
+	// keyed by the rest of the key. Increments made while draining are kept for the
+	// next drain. On error, the hashes already removed are returned with it. As with
+	// Cache.DeleteByPrefix, prefix must include the namespace of the keys.
+	Drain(ctx context.Context, prefix string) (map[string]map[string]int64, error)
+}
+
+// drainScript reads and deletes the hash at KEYS[1] and drops it from the index at
+// KEYS[2] in one step, so no increment is lost between the two.
+var drainScript = redis.NewScript(`
+local values = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+redis.call('SREM', KEYS[2], KEYS[1])
+return values
+`)
+
+type redisCounter struct {
+	client *redis.Client
+}
+
+func NewRedisCounter(client *redis.Client) Counter {
+	return &redisCounter{client: client}
+}
+
+// Pending hashes are listed in the index of their namespace, which Drain reads
+// instead of scanning the whole keyspace. They have no TTL, so neither has the index.
+func (r *redisCounter) IncrBy(ctx context.Context, key, field string, delta int64) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, key, field, delta)
+		pipe.SAdd(ctx, indexKey(key), key)
+		return nil
+	})
+	return err
+}
+
+func (r *redisCounter) Drain(ctx context.Context, prefix string) (map[string]map[string]int64, error) {
+	index := indexKey(prefix)
+	members, err := r.client.SMembers(ctx, index).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	drained := make(map[string]map[string]int64, len(members))
+	for _, key := range members {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		values, err := drainScript.Run(ctx, r.client, []string{key, index}).StringSlice()
+		if err != nil {
+			return drained, err
+		}
+
+		fields := make(map[string]int64, len(values)/2)
+		for i := 0; i+1 < len(values); i += 2 {
+			n, err := strconv.ParseInt(values[i+1], 10, 64)
+			if err != nil {
+				return drained, err
+			}
+			fields[values[i]] = n
+		}
+		drained[strings.TrimPrefix(key, prefix)] = fields
+	}
+
+	return drained, nil
+}
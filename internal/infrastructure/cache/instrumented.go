@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+)
+
+type instrumentedCache struct {
+	next Cache
+}
+
+// NewInstrumentedCache wraps next and counts hits, misses and errors of every operation.
+func NewInstrumentedCache(next Cache) Cache {
+	return &instrumentedCache{next: next}
+}
+
+func (c *instrumentedCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	err := c.next.Set(ctx, key, value, ttl)
+	metrics.ObserveCache("set", result(err, metrics.CacheOK))
+	return err
+}
+
+func (c *instrumentedCache) Get(ctx context.Context, key string, target any) error {
+	err := c.next.Get(ctx, key, target)
+	if errors.Is(err, ErrCacheMiss) {
+		metrics.ObserveCache("get", metrics.CacheMiss)
+		return err
+	}
+	metrics.ObserveCache("get", result(err, metrics.CacheHit))
+	return err
+}
+
+func (c *instrumentedCache) Delete(ctx context.Context, key string) error {
+	err := c.next.Delete(ctx, key)
+	metrics.ObserveCache("delete", result(err, metrics.CacheOK))
+	return err
+}
+
+func (c *instrumentedCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	err := c.next.DeleteByPrefix(ctx, prefix)
+	metrics.ObserveCache("delete_prefix", result(err, metrics.CacheOK))
+	return err
+}
+
+func result(err error, success string) string {
+	if err != nil {
+		return metrics.CacheError
+	}
+	return success
+}
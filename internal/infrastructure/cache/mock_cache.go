@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"sync"
 	"time"
 )
 
 type MockCache struct {
 	store          map[string][]byte
+	counters       map[string]map[string]int64
+	visits         map[string]map[string]struct{}
 	mu             sync.RWMutex
 	SetShouldError bool
 	DelShouldError bool
@@ -17,7 +20,9 @@ type MockCache struct {
 
 func NewMockCache() *MockCache {
 	return &MockCache{
-		store: make(map[string][]byte),
+		store:    make(map[string][]byte),
+		counters: make(map[string]map[string]int64),
+		visits:   make(map[string]map[string]struct{}),
 	}
 }
 
@@ -43,7 +48,7 @@ func (m *MockCache) Get(_ context.Context, key string, target any) error {
 
 	data, ok := m.store[key]
 	if !ok {
-		return errors.New("cache miss")
+		return ErrCacheMiss
 	}
 
 	return json.Unmarshal(data, target)
@@ -59,3 +64,73 @@ func (m *MockCache) Delete(_ context.Context, key string) error {
 	delete(m.store, key)
 	return nil
 }
+
+func (m *MockCache) DeleteByPrefix(_ context.Context, prefix string) error {
+	if m.DelShouldError {
+		return errors.New("mock delete error")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.store {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.store, key)
+		}
+	}
+	return nil
+}
+
+func (m *MockCache) IncrBy(_ context.Context, key, field string, delta int64) error {
+	if m.SetShouldError {
+		return errors.New("mock incr error")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.counters[key] == nil {
+		m.counters[key] = make(map[string]int64)
+	}
+	m.counters[key][field] += delta
+	return nil
+}
+
+func (m *MockCache) Drain(_ context.Context, prefix string) (map[string]map[string]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	drained := make(map[string]map[string]int64)
+	for key, fields := range m.counters {
+		if strings.HasPrefix(key, prefix) {
+			drained[strings.TrimPrefix(key, prefix)] = fields
+			delete(m.counters, key)
+		}
+	}
+	return drained, nil
+}
+
+func (m *MockCache) Visit(_ context.Context, key, visitor string, _ time.Duration) error {
+	if m.SetShouldError {
+		return errors.New("mock visit error")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.visits[key] == nil {
+		m.visits[key] = make(map[string]struct{})
+	}
+	m.visits[key][visitor] = struct{}{}
+	return nil
+}
+
+func (m *MockCache) Visits(_ context.Context, prefix string) (map[string]int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	visits := make(map[string]int64)
+	for key, visitors := range m.visits {
+		if strings.HasPrefix(key, prefix) {
+			visits[strings.TrimPrefix(key, prefix)] = int64(len(visitors))
+		}
+	}
+	return visits, nil
+}
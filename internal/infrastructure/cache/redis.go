@@ -3,6 +3,8 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -21,11 +23,22 @@ func (r *redisCache) Set(ctx context.Context, key string, value any, ttl time.Du
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, key, data, ttl).Err()
+
+	// Every key is listed in the index of its namespace, so DeleteByPrefix reads the
+	// keys of one namespace instead of scanning the whole keyspace.
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, data, ttl)
+		addToIndex(ctx, pipe, key, ttl)
+		return nil
+	})
+	return err
 }
 
 func (r *redisCache) Get(ctx context.Context, key string, target any) error {
 	data, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return ErrCacheMiss
+	}
 	if err != nil {
 		return err
 	}
@@ -33,5 +46,67 @@ func (r *redisCache) Get(ctx context.Context, key string, target any) error {
 }
 
 func (r *redisCache) Delete(ctx context.Context, key string) error {
-	return r.client.Del(ctx, key).Err()
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.SRem(ctx, indexKey(key), key)
+		return nil
+	})
+	return err
+}
+
+func (r *redisCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	index := indexKey(prefix)
+	members, err := r.client.SMembers(ctx, index).Result()
+	if err != nil {
+		return err
+	}
+
+	// Members whose key has expired are dropped from the index along with the rest.
+	var keys []string
+	for _, key := range members {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		pipe.SRem(ctx, index, keys)
+		return nil
+	})
+	return err
+}
+
+// indexScript adds KEYS[2] to the index at KEYS[1] and keeps the index alive at least
+// ARGV[1] milliseconds, so it expires once every key it lists has. A key without a
+// TTL makes the index persistent.
+var indexScript = redis.NewScript(`
+local fresh = redis.call('EXISTS', KEYS[1]) == 0
+redis.call('SADD', KEYS[1], KEYS[2])
+local ttl = tonumber(ARGV[1])
+if ttl <= 0 then
+	redis.call('PERSIST', KEYS[1])
+	return 0
+end
+local current = redis.call('PTTL', KEYS[1])
+if fresh or (current >= 0 and current < ttl) then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 0
+`)
+
+// addToIndex queues indexScript on pipe. Eval rather than EvalSha, since a missing
+// script only surfaces when the pipeline is executed.
+func addToIndex(ctx context.Context, pipe redis.Pipeliner, key string, ttl time.Duration) {
+	indexScript.Eval(ctx, pipe, []string{indexKey(key), key}, ttl.Milliseconds())
+}
+
+// indexKey names the set listing the keys of the namespace of key, the part before its
+// first colon.
+func indexKey(key string) string {
+	namespace, _, _ := strings.Cut(key, ":")
+	return "keys:" + namespace
 }
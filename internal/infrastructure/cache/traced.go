@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracedCache struct {
+	next Cache
+}
+
+// NewTracedCache wraps next and records a span for every operation.
+func NewTracedCache(next Cache) Cache {
+	return &tracedCache{next: next}
+}
+
+func (c *tracedCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	ctx, span := tracing.Start(ctx, "cache.Set", attribute.String("cache.key", key))
+	defer span.End()
+
+	err := c.next.Set(ctx, key, value, ttl)
+	recordError(span, err)
+	return err
+}
+
+func (c *tracedCache) Get(ctx context.Context, key string, target any) error {
+	ctx, span := tracing.Start(ctx, "cache.Get", attribute.String("cache.key", key))
+	defer span.End()
+
+	err := c.next.Get(ctx, key, target)
+	span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+	if !errors.Is(err, ErrCacheMiss) {
+		recordError(span, err)
+	}
+	return err
+}
+
+func (c *tracedCache) Delete(ctx context.Context, key string) error {
+	ctx, span := tracing.Start(ctx, "cache.Delete", attribute.String("cache.key", key))
+	defer span.End()
+
+	err := c.next.Delete(ctx, key)
+	recordError(span, err)
+	return err
+}
+
+func (c *tracedCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	ctx, span := tracing.Start(ctx, "cache.DeleteByPrefix", attribute.String("cache.prefix", prefix))
+	defer span.End()
+
+	err := c.next.DeleteByPrefix(ctx, prefix)
+	recordError(span, err)
+	return err
+}
+
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// VisitCounter counts distinct visitors in Redis HyperLogLogs. Counts are estimates,
+// but take the same few kilobytes however many visitors a key sees.
+type VisitCounter interface {
+	// Visit adds visitor to the set at key, which expires ttl after its last visit.
+	Visit(ctx context.Context, key, visitor string, ttl time.Duration) error
+	// Visits returns the estimated number of visitors of every key starting with
+	// prefix, keyed by the rest of the key. Keys are left in place. As with
+	// Cache.DeleteByPrefix, prefix must include the namespace of the keys.
+	Visits(ctx context.Context, prefix string) (map[string]int64, error)
+}
+
+type redisVisitCounter struct {
+	client *redis.Client
+}
+
+func NewRedisVisitCounter(client *redis.Client) VisitCounter {
+	return &redisVisitCounter{client: client}
+}
+
+// Every key is listed in the index of its namespace, which Visits reads instead of
+// scanning the whole keyspace, and which expires with the last key it lists.
+func (r *redisVisitCounter) Visit(ctx context.Context, key, visitor string, ttl time.Duration) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.PFAdd(ctx, key, visitor)
+		pipe.Expire(ctx, key, ttl)
+		addToIndex(ctx, pipe, key, ttl)
+		return nil
+	})
+	return err
+}
+
+func (r *redisVisitCounter) Visits(ctx context.Context, prefix string) (map[string]int64, error) {
+	index := indexKey(prefix)
+	members, err := r.client.SMembers(ctx, index).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, key := range members {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return map[string]int64{}, nil
+	}
+
+	counts := make([]*redis.IntCmd, len(keys))
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			counts[i] = pipe.PFCount(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A key that saw a visitor never counts zero, so a zero means it has expired and
+	// is dropped from the index.
+	visits := make(map[string]int64, len(keys))
+	var expired []string
+	for i, key := range keys {
+		n := counts[i].Val()
+		if n == 0 {
+			expired = append(expired, key)
+			continue
+		}
+		visits[strings.TrimPrefix(key, prefix)] = n
+	}
+	if len(expired) > 0 {
+		if err := r.client.SRem(ctx, index, expired).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return visits, nil
+}
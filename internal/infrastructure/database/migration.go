@@ -0,0 +1,216 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/bagasss3/go-article/database/migrations"
+	"github.com/lib/pq"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// MigrationsDir is where new migrations are created; the binary runs the copies
+	// embedded from it.
+	MigrationsDir   = "./database/migrations"
+	MigrationsTable = "schema_migrations"
+)
+
+// undefinedTable is the Postgres error code for a missing relation.
+const undefinedTable = "42P01"
+
+// Migrate applies the pending embedded migrations. A Postgres advisory lock serializes
+// replicas migrating at the same time; the ones that wait find nothing left to apply.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return err
+	}
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.FS,
+		goose.WithTableName(MigrationsTable),
+		goose.WithSessionLocker(locker),
+	)
+	if err != nil {
+		return err
+	}
+
+	results, err := provider.Up(ctx)
+	for _, result := range results {
+		log.WithFields(log.Fields{
+			"migration": result.Source.Path,
+			"duration":  result.Duration,
+		}).Info("Applied migration")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
+
+// LatestMigration returns the version of the newest embedded migration.
+func LatestMigration() (int64, error) {
+	files, err := fs.Glob(migrations.FS, "*.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, file := range files {
+		version, err := goose.NumericComponent(file)
+		if err != nil {
+			return 0, err
+		}
+		latest = max(latest, version)
+	}
+	if latest == 0 {
+		return 0, goose.ErrNoMigrationFiles
+	}
+	return latest, nil
+}
+
+// CurrentMigration returns the version the database is migrated to, reading the goose
+// table the way goose does: the newest row wins unless a later row rolled it back. A
+// database without the goose table is at version 0. Unlike goose it never creates the
+// table.
+func CurrentMigration(ctx context.Context, db *sql.DB) (int64, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT version_id, is_applied FROM %s ORDER BY id DESC", MigrationsTable))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == undefinedTable {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer rows.Close()
+
+	rolledBack := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		var applied bool
+		if err := rows.Scan(&version, &applied); err != nil {
+			return 0, err
+		}
+
+		if rolledBack[version] {
+			continue
+		}
+		if applied {
+			return version, rows.Err()
+		}
+		rolledBack[version] = true
+	}
+	return 0, rows.Err()
+}
+
+// MigrationSQL returns the statements of the up or down section of a SQL migration,
+// without the goose annotations. It fails on files goose would reject or misapply:
+// a missing Up section, unknown annotations and unbalanced statement blocks.
+func MigrationSQL(fsys fs.FS, path string, up bool) (string, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var (
+		section   string
+		inBlock   bool
+		hasUp     bool
+		statement strings.Builder
+		lineNo    int
+	)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+
+		annotation, ok := strings.CutPrefix(strings.TrimSpace(line), "-- +goose ")
+		if !ok {
+			if section == "" && strings.TrimSpace(line) != "" && !strings.HasPrefix(strings.TrimSpace(line), "--") {
+				return "", fmt.Errorf("%s:%d: statement before the Up annotation", path, lineNo)
+			}
+			if (section == "up") == up && section != "" {
+				statement.WriteString(line)
+				statement.WriteByte('\n')
+			}
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(annotation)) {
+		case "up":
+			if section != "" {
+				return "", fmt.Errorf("%s:%d: unexpected Up annotation", path, lineNo)
+			}
+			section, hasUp = "up", true
+		case "down":
+			if section != "up" || inBlock {
+				return "", fmt.Errorf("%s:%d: unexpected Down annotation", path, lineNo)
+			}
+			section = "down"
+		case "statementbegin":
+			if section == "" || inBlock {
+				return "", fmt.Errorf("%s:%d: unexpected StatementBegin", path, lineNo)
+			}
+			inBlock = true
+		case "statementend":
+			if !inBlock {
+				return "", fmt.Errorf("%s:%d: StatementEnd without StatementBegin", path, lineNo)
+			}
+			inBlock = false
+		case "no transaction", "envsub on", "envsub off":
+		default:
+			return "", fmt.Errorf("%s:%d: unknown annotation %q", path, lineNo, annotation)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	if !hasUp {
+		return "", fmt.Errorf("%s: missing Up annotation", path)
+	}
+	if inBlock {
+		return "", fmt.Errorf("%s: StatementBegin without StatementEnd", path)
+	}
+	return strings.TrimSpace(statement.String()), nil
+}
+
+// ValidateMigrations checks every SQL migration in fsys without running it: versioned
+// file names, unique versions and the annotations of each file. It returns the number
+// of migrations.
+func ValidateMigrations(fsys fs.FS) (int, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return 0, err
+	}
+	if len(files) == 0 {
+		return 0, goose.ErrNoMigrationFiles
+	}
+
+	seen := make(map[int64]string, len(files))
+	for _, file := range files {
+		version, err := goose.NumericComponent(file)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", file, err)
+		}
+		if other, ok := seen[version]; ok {
+			return 0, fmt.Errorf("duplicate version %d in %s and %s", version, other, file)
+		}
+		seen[version] = file
+
+		if _, err := MigrationSQL(fsys, file, true); err != nil {
+			return 0, err
+		}
+	}
+	return len(files), nil
+}
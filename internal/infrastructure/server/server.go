@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	appmiddleware "github.com/bagasss3/go-article/internal/middleware"
 	"github.com/bagasss3/go-article/pkg/model"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -16,7 +19,13 @@ type HTTPServer struct {
 
 func NewHTTPServer() *HTTPServer {
 	e := echo.New()
-	e.Use(middleware.RequestID())
+	// The request span must exist before the request ID is linked to it.
+	e.Use(tracing.Middleware())
+	e.Use(middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: tracing.LinkRequestID,
+	}))
+	e.Use(appmiddleware.RequestLogger())
+	e.Use(metrics.Middleware())
 	e.Use(middleware.Recover())
 	e.Use(middleware.Secure())
 
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+type contextKey struct{}
+
+// Init configures the global logger from the log section of the config.
+func Init() {
+	logrus.SetReportCaller(true)
+
+	level, err := logrus.ParseLevel(config.LogLevel())
+	if err != nil {
+		logrus.WithError(err).Warn("invalid log level, using info")
+		level = logrus.InfoLevel
+	}
+	logrus.SetLevel(level)
+
+	switch config.LogFormat() {
+	case "text":
+		logrus.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	default:
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+}
+
+// WithContext returns a copy of ctx carrying entry.
+func WithContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, entry)
+}
+
+// FromContext returns the request-scoped logger stored in ctx, or the global logger
+// outside of a request.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if ctx != nil {
+		if entry, ok := ctx.Value(contextKey{}).(*logrus.Entry); ok {
+			return entry
+		}
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HeaderUserID carries the ID of the calling user, set by the gateway in front of the API.
+const HeaderUserID = "X-User-ID"
+
+// RequestLogger stores a logger carrying the request and trace IDs in the request
+// context and writes one access log line per request. It must run after the request ID
+// and tracing middlewares.
+func RequestLogger() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+
+			fields := logrus.Fields{
+				"request_id": c.Response().Header().Get(echo.HeaderXRequestID),
+			}
+			if sc := trace.SpanContextFromContext(req.Context()); sc.HasTraceID() {
+				fields["trace_id"] = sc.TraceID().String()
+			}
+			if user := req.Header.Get(HeaderUserID); user != "" {
+				fields["user"] = user
+			}
+
+			entry := logrus.WithFields(fields)
+			c.SetRequest(req.WithContext(logger.WithContext(req.Context(), entry)))
+
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				status = http.StatusInternalServerError
+				var he *echo.HTTPError
+				if errors.As(err, &he) {
+					status = he.Code
+				}
+			}
+
+			access := entry.WithFields(logrus.Fields{
+				"method":     req.Method,
+				"path":       req.URL.Path,
+				"route":      c.Path(),
+				"status":     status,
+				"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+				"bytes":      c.Response().Size,
+				"remote_ip":  c.RealIP(),
+			})
+			if status >= http.StatusInternalServerError {
+				access.Error("request completed")
+			} else {
+				access.Info("request completed")
+			}
+
+			return err
+		}
+	}
+}
@@ -14,9 +14,46 @@ import (
 	reflect "reflect"
 
 	model "github.com/bagasss3/go-article/pkg/model"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockArticleEventHandler is a mock of ArticleEventHandler interface.
+type MockArticleEventHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleEventHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleEventHandlerMockRecorder is the mock recorder for MockArticleEventHandler.
+type MockArticleEventHandlerMockRecorder struct {
+	mock *MockArticleEventHandler
+}
+
+// NewMockArticleEventHandler creates a new mock instance.
+func NewMockArticleEventHandler(ctrl *gomock.Controller) *MockArticleEventHandler {
+	mock := &MockArticleEventHandler{ctrl: ctrl}
+	mock.recorder = &MockArticleEventHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleEventHandler) EXPECT() *MockArticleEventHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleArticleEvent mocks base method.
+func (m *MockArticleEventHandler) HandleArticleEvent(ctx context.Context, event model.ArticleEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleArticleEvent", ctx, event)
+}
+
+// HandleArticleEvent indicates an expected call of HandleArticleEvent.
+func (mr *MockArticleEventHandlerMockRecorder) HandleArticleEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleArticleEvent", reflect.TypeOf((*MockArticleEventHandler)(nil).HandleArticleEvent), ctx, event)
+}
+
 // MockArticleMethodService is a mock of ArticleMethodService interface.
 type MockArticleMethodService struct {
 	ctrl     *gomock.Controller
@@ -56,6 +93,20 @@ func (mr *MockArticleMethodServiceMockRecorder) Create(ctx, req any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleMethodService)(nil).Create), ctx, req)
 }
 
+// Export mocks base method.
+func (m *MockArticleMethodService) Export(ctx context.Context, filter model.ArticleQuery, w model.ArticleExportWriter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockArticleMethodServiceMockRecorder) Export(ctx, filter, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockArticleMethodService)(nil).Export), ctx, filter, w)
+}
+
 // FindAll mocks base method.
 func (m *MockArticleMethodService) FindAll(ctx context.Context, filter model.ArticleQuery) ([]*model.Article, int, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +123,82 @@ func (mr *MockArticleMethodServiceMockRecorder) FindAll(ctx, filter any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockArticleMethodService)(nil).FindAll), ctx, filter)
 }
 
+// FindByID mocks base method.
+func (m *MockArticleMethodService) FindByID(ctx context.Context, id string, opts model.ArticleReadOptions) (*model.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, opts)
+	ret0, _ := ret[0].(*model.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockArticleMethodServiceMockRecorder) FindByID(ctx, id, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockArticleMethodService)(nil).FindByID), ctx, id, opts)
+}
+
+// FindBySlug mocks base method.
+func (m *MockArticleMethodService) FindBySlug(ctx context.Context, slug string, opts model.ArticleReadOptions) (*model.Article, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySlug", ctx, slug, opts)
+	ret0, _ := ret[0].(*model.Article)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindBySlug indicates an expected call of FindBySlug.
+func (mr *MockArticleMethodServiceMockRecorder) FindBySlug(ctx, slug, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySlug", reflect.TypeOf((*MockArticleMethodService)(nil).FindBySlug), ctx, slug, opts)
+}
+
+// FindRelated mocks base method.
+func (m *MockArticleMethodService) FindRelated(ctx context.Context, id string, query model.RelatedQuery) ([]*model.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRelated", ctx, id, query)
+	ret0, _ := ret[0].([]*model.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRelated indicates an expected call of FindRelated.
+func (mr *MockArticleMethodServiceMockRecorder) FindRelated(ctx, id, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRelated", reflect.TypeOf((*MockArticleMethodService)(nil).FindRelated), ctx, id, query)
+}
+
+// Import mocks base method.
+func (m *MockArticleMethodService) Import(ctx context.Context, rows model.ArticleImportReader) (*model.ArticleImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, rows)
+	ret0, _ := ret[0].(*model.ArticleImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockArticleMethodServiceMockRecorder) Import(ctx, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockArticleMethodService)(nil).Import), ctx, rows)
+}
+
+// Update mocks base method.
+func (m *MockArticleMethodService) Update(ctx context.Context, id string, req *model.UpdateArticleRequest) (*model.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req)
+	ret0, _ := ret[0].(*model.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArticleMethodServiceMockRecorder) Update(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArticleMethodService)(nil).Update), ctx, id, req)
+}
+
 // MockArticleRepository is a mock of ArticleRepository interface.
 type MockArticleRepository struct {
 	ctrl     *gomock.Controller
@@ -96,6 +223,35 @@ func (m *MockArticleRepository) EXPECT() *MockArticleRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BulkCreate mocks base method.
+func (m *MockArticleRepository) BulkCreate(ctx context.Context, articles []*model.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreate", ctx, articles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkCreate indicates an expected call of BulkCreate.
+func (mr *MockArticleRepositoryMockRecorder) BulkCreate(ctx, articles any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockArticleRepository)(nil).BulkCreate), ctx, articles)
+}
+
+// Count mocks base method.
+func (m *MockArticleRepository) Count(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockArticleRepositoryMockRecorder) Count(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockArticleRepository)(nil).Count), ctx)
+}
+
 // Create mocks base method.
 func (m *MockArticleRepository) Create(ctx context.Context, article *model.Article) (*model.Article, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +267,20 @@ func (mr *MockArticleRepositoryMockRecorder) Create(ctx, article any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleRepository)(nil).Create), ctx, article)
 }
 
+// DeleteAll mocks base method.
+func (m *MockArticleRepository) DeleteAll(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockArticleRepositoryMockRecorder) DeleteAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockArticleRepository)(nil).DeleteAll), ctx)
+}
+
 // FindAll mocks base method.
 func (m *MockArticleRepository) FindAll(ctx context.Context, filter model.ArticleQuery) ([]*model.Article, int, error) {
 	m.ctrl.T.Helper()
@@ -126,3 +296,151 @@ func (mr *MockArticleRepositoryMockRecorder) FindAll(ctx, filter any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockArticleRepository)(nil).FindAll), ctx, filter)
 }
+
+// FindByID mocks base method.
+func (m *MockArticleRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*model.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockArticleRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockArticleRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByIDs mocks base method.
+func (m *MockArticleRepository) FindByIDs(ctx context.Context, ids []uuid.UUID, fields []string) ([]*model.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids, fields)
+	ret0, _ := ret[0].([]*model.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockArticleRepositoryMockRecorder) FindByIDs(ctx, ids, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockArticleRepository)(nil).FindByIDs), ctx, ids, fields)
+}
+
+// FindBySlug mocks base method.
+func (m *MockArticleRepository) FindBySlug(ctx context.Context, slug string) (*model.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySlug", ctx, slug)
+	ret0, _ := ret[0].(*model.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySlug indicates an expected call of FindBySlug.
+func (mr *MockArticleRepositoryMockRecorder) FindBySlug(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySlug", reflect.TypeOf((*MockArticleRepository)(nil).FindBySlug), ctx, slug)
+}
+
+// FindRelated mocks base method.
+func (m *MockArticleRepository) FindRelated(ctx context.Context, id uuid.UUID, limit int) ([]*model.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRelated", ctx, id, limit)
+	ret0, _ := ret[0].([]*model.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRelated indicates an expected call of FindRelated.
+func (mr *MockArticleRepositoryMockRecorder) FindRelated(ctx, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRelated", reflect.TypeOf((*MockArticleRepository)(nil).FindRelated), ctx, id, limit)
+}
+
+// FindSlugRedirect mocks base method.
+func (m *MockArticleRepository) FindSlugRedirect(ctx context.Context, slug string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSlugRedirect", ctx, slug)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSlugRedirect indicates an expected call of FindSlugRedirect.
+func (mr *MockArticleRepositoryMockRecorder) FindSlugRedirect(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSlugRedirect", reflect.TypeOf((*MockArticleRepository)(nil).FindSlugRedirect), ctx, slug)
+}
+
+// FindSlugs mocks base method.
+func (m *MockArticleRepository) FindSlugs(ctx context.Context, base string, excludeID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSlugs", ctx, base, excludeID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSlugs indicates an expected call of FindSlugs.
+func (mr *MockArticleRepositoryMockRecorder) FindSlugs(ctx, base, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSlugs", reflect.TypeOf((*MockArticleRepository)(nil).FindSlugs), ctx, base, excludeID)
+}
+
+// FindTranslations mocks base method.
+func (m *MockArticleRepository) FindTranslations(ctx context.Context, translationID uuid.UUID) ([]*model.ArticleTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTranslations", ctx, translationID)
+	ret0, _ := ret[0].([]*model.ArticleTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTranslations indicates an expected call of FindTranslations.
+func (mr *MockArticleRepositoryMockRecorder) FindTranslations(ctx, translationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTranslations", reflect.TypeOf((*MockArticleRepository)(nil).FindTranslations), ctx, translationID)
+}
+
+// Stream mocks base method.
+func (m *MockArticleRepository) Stream(ctx context.Context, filter model.ArticleQuery, fn func(*model.Article) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockArticleRepositoryMockRecorder) Stream(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockArticleRepository)(nil).Stream), ctx, filter, fn)
+}
+
+// StreamSitemap mocks base method.
+func (m *MockArticleRepository) StreamSitemap(ctx context.Context, offset, limit int, fn func(*model.Article) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSitemap", ctx, offset, limit, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSitemap indicates an expected call of StreamSitemap.
+func (mr *MockArticleRepositoryMockRecorder) StreamSitemap(ctx, offset, limit, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSitemap", reflect.TypeOf((*MockArticleRepository)(nil).StreamSitemap), ctx, offset, limit, fn)
+}
+
+// Update mocks base method.
+func (m *MockArticleRepository) Update(ctx context.Context, article *model.Article) (*model.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, article)
+	ret0, _ := ret[0].(*model.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArticleRepositoryMockRecorder) Update(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArticleRepository)(nil).Update), ctx, article)
+}
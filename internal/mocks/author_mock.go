@@ -42,6 +42,35 @@ func (m *MockAuthorRepository) EXPECT() *MockAuthorRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BulkCreate mocks base method.
+func (m *MockAuthorRepository) BulkCreate(ctx context.Context, authors []*model.Author) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreate", ctx, authors)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkCreate indicates an expected call of BulkCreate.
+func (mr *MockAuthorRepositoryMockRecorder) BulkCreate(ctx, authors any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockAuthorRepository)(nil).BulkCreate), ctx, authors)
+}
+
+// Count mocks base method.
+func (m *MockAuthorRepository) Count(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockAuthorRepositoryMockRecorder) Count(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockAuthorRepository)(nil).Count), ctx)
+}
+
 // Create mocks base method.
 func (m *MockAuthorRepository) Create(ctx context.Context, author *model.Author) (*model.Author, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +86,20 @@ func (mr *MockAuthorRepositoryMockRecorder) Create(ctx, author any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthorRepository)(nil).Create), ctx, author)
 }
 
+// DeleteAll mocks base method.
+func (m *MockAuthorRepository) DeleteAll(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockAuthorRepositoryMockRecorder) DeleteAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockAuthorRepository)(nil).DeleteAll), ctx)
+}
+
 // FindByID mocks base method.
 func (m *MockAuthorRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Author, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +115,35 @@ func (mr *MockAuthorRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAuthorRepository)(nil).FindByID), ctx, id)
 }
 
+// FindByName mocks base method.
+func (m *MockAuthorRepository) FindByName(ctx context.Context, name string) (*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockAuthorRepositoryMockRecorder) FindByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockAuthorRepository)(nil).FindByName), ctx, name)
+}
+
+// StreamSitemap mocks base method.
+func (m *MockAuthorRepository) StreamSitemap(ctx context.Context, offset, limit int, fn func(*model.SitemapAuthor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSitemap", ctx, offset, limit, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSitemap indicates an expected call of StreamSitemap.
+func (mr *MockAuthorRepositoryMockRecorder) StreamSitemap(ctx, offset, limit, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSitemap", reflect.TypeOf((*MockAuthorRepository)(nil).StreamSitemap), ctx, offset, limit, fn)
+}
+
 // MockAuthorMethodService is a mock of AuthorMethodService interface.
 type MockAuthorMethodService struct {
 	ctrl     *gomock.Controller
@@ -112,16 +184,16 @@ func (mr *MockAuthorMethodServiceMockRecorder) Create(ctx, req any) *gomock.Call
 }
 
 // FindByID mocks base method.
-func (m *MockAuthorMethodService) FindByID(ctx context.Context, id string) (*model.Author, error) {
+func (m *MockAuthorMethodService) FindByID(ctx context.Context, id string, opts model.AuthorReadOptions) (*model.Author, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, opts)
 	ret0, _ := ret[0].(*model.Author)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockAuthorMethodServiceMockRecorder) FindByID(ctx, id any) *gomock.Call {
+func (mr *MockAuthorMethodServiceMockRecorder) FindByID(ctx, id, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAuthorMethodService)(nil).FindByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAuthorMethodService)(nil).FindByID), ctx, id, opts)
 }
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/model/feed.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/model/feed.go -destination=internal/mocks/feed_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/bagasss3/go-article/pkg/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedMethodService is a mock of FeedMethodService interface.
+type MockFeedMethodService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedMethodServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeedMethodServiceMockRecorder is the mock recorder for MockFeedMethodService.
+type MockFeedMethodServiceMockRecorder struct {
+	mock *MockFeedMethodService
+}
+
+// NewMockFeedMethodService creates a new mock instance.
+func NewMockFeedMethodService(ctrl *gomock.Controller) *MockFeedMethodService {
+	mock := &MockFeedMethodService{ctrl: ctrl}
+	mock.recorder = &MockFeedMethodServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedMethodService) EXPECT() *MockFeedMethodServiceMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockFeedMethodService) Render(ctx context.Context, query model.FeedQuery) (*model.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", ctx, query)
+	ret0, _ := ret[0].(*model.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockFeedMethodServiceMockRecorder) Render(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockFeedMethodService)(nil).Render), ctx, query)
+}
@@ -0,0 +1,225 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/model/reaction.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/model/reaction.go -destination=internal/mocks/reaction_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/bagasss3/go-article/pkg/model"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReactionRepository is a mock of ReactionRepository interface.
+type MockReactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReactionRepositoryMockRecorder is the mock recorder for MockReactionRepository.
+type MockReactionRepositoryMockRecorder struct {
+	mock *MockReactionRepository
+}
+
+// NewMockReactionRepository creates a new mock instance.
+func NewMockReactionRepository(ctrl *gomock.Controller) *MockReactionRepository {
+	mock := &MockReactionRepository{ctrl: ctrl}
+	mock.recorder = &MockReactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReactionRepository) EXPECT() *MockReactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockReactionRepository) Add(ctx context.Context, articleID uuid.UUID, userID, reaction string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, articleID, userID, reaction)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockReactionRepositoryMockRecorder) Add(ctx, articleID, userID, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockReactionRepository)(nil).Add), ctx, articleID, userID, reaction)
+}
+
+// AddBookmark mocks base method.
+func (m *MockReactionRepository) AddBookmark(ctx context.Context, articleID uuid.UUID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookmark", ctx, articleID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBookmark indicates an expected call of AddBookmark.
+func (mr *MockReactionRepositoryMockRecorder) AddBookmark(ctx, articleID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookmark", reflect.TypeOf((*MockReactionRepository)(nil).AddBookmark), ctx, articleID, userID)
+}
+
+// ApplyCounts mocks base method.
+func (m *MockReactionRepository) ApplyCounts(ctx context.Context, deltas map[uuid.UUID]map[string]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCounts", ctx, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyCounts indicates an expected call of ApplyCounts.
+func (mr *MockReactionRepositoryMockRecorder) ApplyCounts(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCounts", reflect.TypeOf((*MockReactionRepository)(nil).ApplyCounts), ctx, deltas)
+}
+
+// Remove mocks base method.
+func (m *MockReactionRepository) Remove(ctx context.Context, articleID uuid.UUID, userID, reaction string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, articleID, userID, reaction)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockReactionRepositoryMockRecorder) Remove(ctx, articleID, userID, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockReactionRepository)(nil).Remove), ctx, articleID, userID, reaction)
+}
+
+// RemoveBookmark mocks base method.
+func (m *MockReactionRepository) RemoveBookmark(ctx context.Context, articleID uuid.UUID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBookmark", ctx, articleID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBookmark indicates an expected call of RemoveBookmark.
+func (mr *MockReactionRepositoryMockRecorder) RemoveBookmark(ctx, articleID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBookmark", reflect.TypeOf((*MockReactionRepository)(nil).RemoveBookmark), ctx, articleID, userID)
+}
+
+// MockReactionMethodService is a mock of ReactionMethodService interface.
+type MockReactionMethodService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionMethodServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReactionMethodServiceMockRecorder is the mock recorder for MockReactionMethodService.
+type MockReactionMethodServiceMockRecorder struct {
+	mock *MockReactionMethodService
+}
+
+// NewMockReactionMethodService creates a new mock instance.
+func NewMockReactionMethodService(ctrl *gomock.Controller) *MockReactionMethodService {
+	mock := &MockReactionMethodService{ctrl: ctrl}
+	mock.recorder = &MockReactionMethodServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReactionMethodService) EXPECT() *MockReactionMethodServiceMockRecorder {
+	return m.recorder
+}
+
+// Bookmark mocks base method.
+func (m *MockReactionMethodService) Bookmark(ctx context.Context, articleID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bookmark", ctx, articleID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bookmark indicates an expected call of Bookmark.
+func (mr *MockReactionMethodServiceMockRecorder) Bookmark(ctx, articleID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bookmark", reflect.TypeOf((*MockReactionMethodService)(nil).Bookmark), ctx, articleID, userID)
+}
+
+// Bookmarks mocks base method.
+func (m *MockReactionMethodService) Bookmarks(ctx context.Context, userID string, filter model.ArticleQuery) ([]*model.Article, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bookmarks", ctx, userID, filter)
+	ret0, _ := ret[0].([]*model.Article)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Bookmarks indicates an expected call of Bookmarks.
+func (mr *MockReactionMethodServiceMockRecorder) Bookmarks(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bookmarks", reflect.TypeOf((*MockReactionMethodService)(nil).Bookmarks), ctx, userID, filter)
+}
+
+// Flush mocks base method.
+func (m *MockReactionMethodService) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockReactionMethodServiceMockRecorder) Flush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockReactionMethodService)(nil).Flush), ctx)
+}
+
+// React mocks base method.
+func (m *MockReactionMethodService) React(ctx context.Context, articleID, userID, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "React", ctx, articleID, userID, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// React indicates an expected call of React.
+func (mr *MockReactionMethodServiceMockRecorder) React(ctx, articleID, userID, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "React", reflect.TypeOf((*MockReactionMethodService)(nil).React), ctx, articleID, userID, reaction)
+}
+
+// Unbookmark mocks base method.
+func (m *MockReactionMethodService) Unbookmark(ctx context.Context, articleID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unbookmark", ctx, articleID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unbookmark indicates an expected call of Unbookmark.
+func (mr *MockReactionMethodServiceMockRecorder) Unbookmark(ctx, articleID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unbookmark", reflect.TypeOf((*MockReactionMethodService)(nil).Unbookmark), ctx, articleID, userID)
+}
+
+// Unreact mocks base method.
+func (m *MockReactionMethodService) Unreact(ctx context.Context, articleID, userID, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unreact", ctx, articleID, userID, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unreact indicates an expected call of Unreact.
+func (mr *MockReactionMethodServiceMockRecorder) Unreact(ctx, articleID, userID, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unreact", reflect.TypeOf((*MockReactionMethodService)(nil).Unreact), ctx, articleID, userID, reaction)
+}
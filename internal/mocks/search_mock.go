@@ -0,0 +1,326 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/model/search.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/model/search.go -destination=internal/mocks/search_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/bagasss3/go-article/pkg/model"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSearchIndex is a mock of SearchIndex interface.
+type MockSearchIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchIndexMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchIndexMockRecorder is the mock recorder for MockSearchIndex.
+type MockSearchIndexMockRecorder struct {
+	mock *MockSearchIndex
+}
+
+// NewMockSearchIndex creates a new mock instance.
+func NewMockSearchIndex(ctrl *gomock.Controller) *MockSearchIndex {
+	mock := &MockSearchIndex{ctrl: ctrl}
+	mock.recorder = &MockSearchIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchIndex) EXPECT() *MockSearchIndexMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSearchIndex) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSearchIndexMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSearchIndex)(nil).Close))
+}
+
+// Delete mocks base method.
+func (m *MockSearchIndex) Delete(ctx context.Context, ids ...uuid.UUID) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSearchIndexMockRecorder) Delete(ctx any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSearchIndex)(nil).Delete), varargs...)
+}
+
+// Index mocks base method.
+func (m *MockSearchIndex) Index(ctx context.Context, docs ...*model.SearchDocument) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range docs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Index", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockSearchIndexMockRecorder) Index(ctx any, docs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, docs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockSearchIndex)(nil).Index), varargs...)
+}
+
+// Reset mocks base method.
+func (m *MockSearchIndex) Reset(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockSearchIndexMockRecorder) Reset(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockSearchIndex)(nil).Reset), ctx)
+}
+
+// Search mocks base method.
+func (m *MockSearchIndex) Search(ctx context.Context, query model.SearchIndexQuery) (*model.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query)
+	ret0, _ := ret[0].(*model.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchIndexMockRecorder) Search(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchIndex)(nil).Search), ctx, query)
+}
+
+// MockSearchIndexMethodService is a mock of SearchIndexMethodService interface.
+type MockSearchIndexMethodService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchIndexMethodServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchIndexMethodServiceMockRecorder is the mock recorder for MockSearchIndexMethodService.
+type MockSearchIndexMethodServiceMockRecorder struct {
+	mock *MockSearchIndexMethodService
+}
+
+// NewMockSearchIndexMethodService creates a new mock instance.
+func NewMockSearchIndexMethodService(ctrl *gomock.Controller) *MockSearchIndexMethodService {
+	mock := &MockSearchIndexMethodService{ctrl: ctrl}
+	mock.recorder = &MockSearchIndexMethodServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchIndexMethodService) EXPECT() *MockSearchIndexMethodServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleArticleEvent mocks base method.
+func (m *MockSearchIndexMethodService) HandleArticleEvent(ctx context.Context, event model.ArticleEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleArticleEvent", ctx, event)
+}
+
+// HandleArticleEvent indicates an expected call of HandleArticleEvent.
+func (mr *MockSearchIndexMethodServiceMockRecorder) HandleArticleEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleArticleEvent", reflect.TypeOf((*MockSearchIndexMethodService)(nil).HandleArticleEvent), ctx, event)
+}
+
+// Reindex mocks base method.
+func (m *MockSearchIndexMethodService) Reindex(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reindex", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reindex indicates an expected call of Reindex.
+func (mr *MockSearchIndexMethodServiceMockRecorder) Reindex(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reindex", reflect.TypeOf((*MockSearchIndexMethodService)(nil).Reindex), ctx)
+}
+
+// MockSearchRepository is a mock of SearchRepository interface.
+type MockSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchRepositoryMockRecorder is the mock recorder for MockSearchRepository.
+type MockSearchRepositoryMockRecorder struct {
+	mock *MockSearchRepository
+}
+
+// NewMockSearchRepository creates a new mock instance.
+func NewMockSearchRepository(ctrl *gomock.Controller) *MockSearchRepository {
+	mock := &MockSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchRepository) EXPECT() *MockSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// RefreshWords mocks base method.
+func (m *MockSearchRepository) RefreshWords(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshWords", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshWords indicates an expected call of RefreshWords.
+func (mr *MockSearchRepositoryMockRecorder) RefreshWords(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWords", reflect.TypeOf((*MockSearchRepository)(nil).RefreshWords), ctx)
+}
+
+// SimilarWords mocks base method.
+func (m *MockSearchRepository) SimilarWords(ctx context.Context, words []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimilarWords", ctx, words)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimilarWords indicates an expected call of SimilarWords.
+func (mr *MockSearchRepositoryMockRecorder) SimilarWords(ctx, words any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimilarWords", reflect.TypeOf((*MockSearchRepository)(nil).SimilarWords), ctx, words)
+}
+
+// Suggest mocks base method.
+func (m *MockSearchRepository) Suggest(ctx context.Context, prefix string, limit int) (*model.Suggestions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, prefix, limit)
+	ret0, _ := ret[0].(*model.Suggestions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSearchRepositoryMockRecorder) Suggest(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearchRepository)(nil).Suggest), ctx, prefix, limit)
+}
+
+// MockSearchMethodService is a mock of SearchMethodService interface.
+type MockSearchMethodService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchMethodServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchMethodServiceMockRecorder is the mock recorder for MockSearchMethodService.
+type MockSearchMethodServiceMockRecorder struct {
+	mock *MockSearchMethodService
+}
+
+// NewMockSearchMethodService creates a new mock instance.
+func NewMockSearchMethodService(ctrl *gomock.Controller) *MockSearchMethodService {
+	mock := &MockSearchMethodService{ctrl: ctrl}
+	mock.recorder = &MockSearchMethodServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchMethodService) EXPECT() *MockSearchMethodServiceMockRecorder {
+	return m.recorder
+}
+
+// DidYouMean mocks base method.
+func (m *MockSearchMethodService) DidYouMean(ctx context.Context, query string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DidYouMean", ctx, query)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DidYouMean indicates an expected call of DidYouMean.
+func (mr *MockSearchMethodServiceMockRecorder) DidYouMean(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DidYouMean", reflect.TypeOf((*MockSearchMethodService)(nil).DidYouMean), ctx, query)
+}
+
+// Facets mocks base method.
+func (m *MockSearchMethodService) Facets(ctx context.Context, filter model.ArticleQuery) (*model.Facets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Facets", ctx, filter)
+	ret0, _ := ret[0].(*model.Facets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Facets indicates an expected call of Facets.
+func (mr *MockSearchMethodServiceMockRecorder) Facets(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Facets", reflect.TypeOf((*MockSearchMethodService)(nil).Facets), ctx, filter)
+}
+
+// RefreshWords mocks base method.
+func (m *MockSearchMethodService) RefreshWords(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshWords", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshWords indicates an expected call of RefreshWords.
+func (mr *MockSearchMethodServiceMockRecorder) RefreshWords(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWords", reflect.TypeOf((*MockSearchMethodService)(nil).RefreshWords), ctx)
+}
+
+// Suggest mocks base method.
+func (m *MockSearchMethodService) Suggest(ctx context.Context, query model.SuggestQuery) (*model.Suggestions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, query)
+	ret0, _ := ret[0].(*model.Suggestions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSearchMethodServiceMockRecorder) Suggest(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearchMethodService)(nil).Suggest), ctx, query)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/model/sitemap.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/model/sitemap.go -destination=internal/mocks/sitemap_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSitemapMethodService is a mock of SitemapMethodService interface.
+type MockSitemapMethodService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSitemapMethodServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSitemapMethodServiceMockRecorder is the mock recorder for MockSitemapMethodService.
+type MockSitemapMethodServiceMockRecorder struct {
+	mock *MockSitemapMethodService
+}
+
+// NewMockSitemapMethodService creates a new mock instance.
+func NewMockSitemapMethodService(ctrl *gomock.Controller) *MockSitemapMethodService {
+	mock := &MockSitemapMethodService{ctrl: ctrl}
+	mock.recorder = &MockSitemapMethodServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSitemapMethodService) EXPECT() *MockSitemapMethodServiceMockRecorder {
+	return m.recorder
+}
+
+// Index mocks base method.
+func (m *MockSitemapMethodService) Index(ctx context.Context) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", ctx)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockSitemapMethodServiceMockRecorder) Index(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockSitemapMethodService)(nil).Index), ctx)
+}
+
+// Page mocks base method.
+func (m *MockSitemapMethodService) Page(ctx context.Context, page int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Page", ctx, page)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Page indicates an expected call of Page.
+func (mr *MockSitemapMethodServiceMockRecorder) Page(ctx, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Page", reflect.TypeOf((*MockSitemapMethodService)(nil).Page), ctx, page)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/model/view.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/model/view.go -destination=internal/mocks/view_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/bagasss3/go-article/pkg/model"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockViewRepository is a mock of ViewRepository interface.
+type MockViewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockViewRepositoryMockRecorder is the mock recorder for MockViewRepository.
+type MockViewRepositoryMockRecorder struct {
+	mock *MockViewRepository
+}
+
+// NewMockViewRepository creates a new mock instance.
+func NewMockViewRepository(ctrl *gomock.Controller) *MockViewRepository {
+	mock := &MockViewRepository{ctrl: ctrl}
+	mock.recorder = &MockViewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewRepository) EXPECT() *MockViewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SaveDaily mocks base method.
+func (m *MockViewRepository) SaveDaily(ctx context.Context, views []model.DailyViews) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDaily", ctx, views)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDaily indicates an expected call of SaveDaily.
+func (mr *MockViewRepositoryMockRecorder) SaveDaily(ctx, views any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDaily", reflect.TypeOf((*MockViewRepository)(nil).SaveDaily), ctx, views)
+}
+
+// Trending mocks base method.
+func (m *MockViewRepository) Trending(ctx context.Context, since time.Time, halfLife time.Duration, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trending", ctx, since, halfLife, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trending indicates an expected call of Trending.
+func (mr *MockViewRepositoryMockRecorder) Trending(ctx, since, halfLife, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trending", reflect.TypeOf((*MockViewRepository)(nil).Trending), ctx, since, halfLife, limit)
+}
+
+// MockViewMethodService is a mock of ViewMethodService interface.
+type MockViewMethodService struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewMethodServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockViewMethodServiceMockRecorder is the mock recorder for MockViewMethodService.
+type MockViewMethodServiceMockRecorder struct {
+	mock *MockViewMethodService
+}
+
+// NewMockViewMethodService creates a new mock instance.
+func NewMockViewMethodService(ctrl *gomock.Controller) *MockViewMethodService {
+	mock := &MockViewMethodService{ctrl: ctrl}
+	mock.recorder = &MockViewMethodServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewMethodService) EXPECT() *MockViewMethodServiceMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockViewMethodService) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockViewMethodServiceMockRecorder) Flush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockViewMethodService)(nil).Flush), ctx)
+}
+
+// Record mocks base method.
+func (m *MockViewMethodService) Record(ctx context.Context, articleID uuid.UUID, visitor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, articleID, visitor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockViewMethodServiceMockRecorder) Record(ctx, articleID, visitor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockViewMethodService)(nil).Record), ctx, articleID, visitor)
+}
+
+// Trending mocks base method.
+func (m *MockViewMethodService) Trending(ctx context.Context, query model.TrendingQuery) ([]*model.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trending", ctx, query)
+	ret0, _ := ret[0].([]*model.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trending indicates an expected call of Trending.
+func (mr *MockViewMethodServiceMockRecorder) Trending(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trending", reflect.TypeOf((*MockViewMethodService)(nil).Trending), ctx, query)
+}
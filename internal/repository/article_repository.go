@@ -3,14 +3,20 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/bagasss3/go-article/internal/config"
 	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
 	"github.com/bagasss3/go-article/pkg/model"
 	"github.com/google/uuid"
-	log "github.com/sirupsen/logrus"
+	"github.com/lib/pq"
 )
 
 type articleRepository struct {
@@ -25,21 +31,324 @@ func NewArticleRepository(db *sql.DB, cache cache.Cache) model.ArticleRepository
 	}
 }
 
-func (r *articleRepository) FindAll(ctx context.Context, filter model.ArticleQuery) ([]*model.Article, int, error) {
+// articleReactionsColumn reads the reaction counts of an article as a JSON object.
+const articleReactionsColumn = `COALESCE((
+		SELECT jsonb_object_agg(rc.type, rc.count) FROM article_reaction_counts rc
+		WHERE rc.article_id = a.id AND rc.count > 0), '{}')`
+
+// articleSelectColumns are read when no field subset is requested.
+var articleSelectColumns = []string{"a.id", "a.author_id", "au.name", "a.title", "a.slug", "a.body", "a.body_html", "a.excerpt", "a.word_count", "a.reading_time", "a.comment_count", "a.view_count", articleReactionsColumn, "a.category_id", "a.language", "a.translation_id", "a.created_at", "a.updated_at"}
+
+// articleFieldColumns maps the fields of model.ArticleFields to the columns they are read
+// from. Fields without columns are derived after the query.
+var articleFieldColumns = map[string][]string{
+	"id":             {"a.id"},
+	"author_id":      {"a.author_id"},
+	"author":         {"au.name"},
+	"title":          {"a.title"},
+	"slug":           {"a.slug"},
+	"body":           {"a.body", "a.body_html"},
+	"excerpt":        {"a.excerpt"},
+	"word_count":     {"a.word_count"},
+	"reading_time":   {"a.reading_time"},
+	"comment_count":  {"a.comment_count"},
+	"view_count":     {"a.view_count"},
+	"reactions":      {articleReactionsColumn},
+	"category_id":    {"a.category_id"},
+	"language":       {"a.language"},
+	"translation_id": {"a.translation_id"},
+	"created_at":     {"a.created_at"},
+	"updated_at":     {"a.updated_at"},
+}
+
+// articleSortOrder maps the values of model.ArticleSorts to ORDER BY clauses, so user
+// input never reaches the query. "relevance" is built from the search query.
+var articleSortOrder = map[string]string{
+	"created_at":  "a.created_at ASC",
+	"-created_at": "a.created_at DESC",
+	"title":       "a.title ASC",
+	"-title":      "a.title DESC",
+	"updated_at":  "a.updated_at ASC",
+	"-updated_at": "a.updated_at DESC",
+}
+
+// articleColumnsFor returns the columns needed for fields in select order. The id is
+// always read since tags are loaded by it.
+func articleColumnsFor(fields []string) []string {
+	if len(fields) == 0 {
+		return articleSelectColumns
+	}
+
+	wanted := map[string]bool{"a.id": true}
+	for _, field := range fields {
+		for _, column := range articleFieldColumns[field] {
+			wanted[column] = true
+		}
+	}
+
+	columns := make([]string, 0, len(wanted))
+	for _, column := range articleSelectColumns {
+		if wanted[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+func articleSelect(columns []string) string {
+	return fmt.Sprintf(`
+	SELECT %s
+	FROM articles a
+	JOIN authors au ON a.author_id = au.id
+`, strings.Join(columns, ", "))
+}
+
+// articleColumnDest returns the field of a that column is scanned into, or nil for
+// an unknown column.
+func articleColumnDest(a *model.Article, column string) any {
+	switch column {
+	case "a.id":
+		return &a.ID
+	case "a.author_id":
+		return &a.AuthorID
+	case "au.name":
+		return &a.Author
+	case "a.title":
+		return &a.Title
+	case "a.slug":
+		return &a.Slug
+	case "a.body":
+		return &a.Body
+	case "a.body_html":
+		return &a.BodyHTML
+	case "a.excerpt":
+		return &a.Excerpt
+	case "a.word_count":
+		return &a.WordCount
+	case "a.reading_time":
+		return &a.ReadingTime
+	case "a.comment_count":
+		return &a.CommentCount
+	case "a.view_count":
+		return &a.ViewCount
+	case articleReactionsColumn:
+		return jsonColumn{&a.Reactions}
+	case "a.category_id":
+		return &a.CategoryID
+	case "a.language":
+		return &a.Language
+	case "a.translation_id":
+		return &a.TranslationID
+	case "a.created_at":
+		return &a.CreatedAt
+	case "a.updated_at":
+		return &a.UpdatedAt
+	}
+	return nil
+}
+
+// jsonColumn scans a JSON column into target.
+type jsonColumn struct {
+	target any
+}
+
+func (j jsonColumn) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, j.target)
+	case string:
+		return json.Unmarshal([]byte(v), j.target)
+	}
+	return fmt.Errorf("cannot scan %T into a JSON column", src)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanArticle reads a row selected with articleSelect(columns).
+func scanArticle(row rowScanner, columns []string) (*model.Article, error) {
+	var a model.Article
+	dest := make([]any, 0, len(columns))
+	for _, column := range columns {
+		d := articleColumnDest(&a, column)
+		if d == nil {
+			return nil, fmt.Errorf("unknown article column %q", column)
+		}
+		dest = append(dest, d)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// articleListCachePrefix prefixes the keys of the only cached article listing: the
+// unfiltered first page, once per requested field set.
+func articleListCachePrefix() string {
+	return fmt.Sprintf("%s:page=1:limit=%d", model.ArticleKey, model.CacheableLimit)
+}
+
+func firstPageCacheKey(fields []string) string {
+	if len(fields) == 0 {
+		return articleListCachePrefix()
+	}
+	sorted := slices.Clone(fields)
+	slices.Sort(sorted)
+	return fmt.Sprintf("%s:fields=%s", articleListCachePrefix(), strings.Join(sorted, ","))
+}
+
+// deleteListingCache drops every cached article listing, rendered feeds, sitemaps and
+// search facets included.
+func deleteListingCache(ctx context.Context, c cache.Cache) {
+	log := logger.FromContext(ctx)
+
+	if err := c.DeleteByPrefix(ctx, articleListCachePrefix()); err != nil {
+		log.Warn("failed to delete cache articles")
+	}
+	if err := c.DeleteByPrefix(ctx, model.FeedKey+":"); err != nil {
+		log.Warn("failed to delete cache feeds")
+	}
+	if err := c.DeleteByPrefix(ctx, model.FacetKey+":"); err != nil {
+		log.Warn("failed to delete cache facets")
+	}
+	deleteSitemapCache(ctx, c)
+}
+
+// deleteSitemapCache drops the rendered sitemaps.
+func deleteSitemapCache(ctx context.Context, c cache.Cache) {
+	if err := c.DeleteByPrefix(ctx, model.SitemapKey+":"); err != nil {
+		logger.FromContext(ctx).Warn("failed to delete cache sitemaps")
+	}
+}
+
+// articleSearchVector is the text of an article as searched, in the form the
+// idx_articles_search index holds.
+const articleSearchVector = "to_tsvector(article_search_config(a.language), a.title || ' ' || a.body)"
+
+// articleConditions turns the filters of an article listing into WHERE conditions over
+// articles a joined with authors au, numbering their placeholders from $1.
+func articleConditions(filter model.ArticleQuery) ([]string, []any) {
 	var (
 		args       []any
 		conditions []string
 	)
 
+	argPos := 1
+	if filter.Query != "" {
+		// Substrings match as typed; whole words also match in other forms, stemmed the
+		// way the language of each article is.
+		conditions = append(conditions, fmt.Sprintf(
+			"(a.title ILIKE $%d OR a.body ILIKE $%d OR %s @@ plainto_tsquery(article_search_config(a.language), $%d))",
+			argPos, argPos+1, articleSearchVector, argPos+2,
+		))
+		args = append(args, "%"+filter.Query+"%", "%"+filter.Query+"%", filter.Query)
+		argPos += 3
+	}
+	if filter.Lang != "" {
+		conditions = append(conditions, fmt.Sprintf("a.language = $%d", argPos))
+		args = append(args, filter.Lang)
+		argPos++
+	}
+	if filter.Author != "" {
+		conditions = append(conditions, fmt.Sprintf("au.name ILIKE $%d", argPos))
+		args = append(args, "%"+filter.Author+"%")
+		argPos++
+	}
+	if filter.BookmarkedBy != "" {
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM article_bookmarks b WHERE b.article_id = a.id AND b.user_id = $%d)`, argPos))
+		args = append(args, filter.BookmarkedBy)
+		argPos++
+	}
+	if len(filter.AuthorIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf("a.author_id = ANY($%d)", argPos))
+		args = append(args, pq.Array(filter.AuthorIDs))
+		argPos++
+	}
+	if filter.CreatedFrom != "" {
+		conditions = append(conditions, fmt.Sprintf("a.created_at >= $%d::timestamp", argPos))
+		args = append(args, filter.CreatedFrom)
+		argPos++
+	}
+	if filter.CreatedTo != "" {
+		conditions = append(conditions, fmt.Sprintf("a.created_at <= $%d::timestamp", argPos))
+		args = append(args, filter.CreatedTo)
+		argPos++
+	}
+	if filter.Category != "" {
+		// A category matches its own articles and those of every descendant category.
+		conditions = append(conditions, fmt.Sprintf(`a.category_id IN (
+			WITH RECURSIVE tree AS (
+				SELECT id FROM categories WHERE slug = $%d
+				UNION ALL
+				SELECT c.id FROM categories c JOIN tree ON c.parent_id = tree.id
+			)
+			SELECT id FROM tree)`, argPos))
+		args = append(args, filter.Category)
+		argPos++
+	}
+	if filter.Tag != "" {
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM article_tags at JOIN tags t ON t.id = at.tag_id
+			WHERE at.article_id = a.id AND t.slug = $%d)`, argPos))
+		args = append(args, filter.Tag)
+		argPos++
+	}
+	if len(filter.TagsAny) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM article_tags at JOIN tags t ON t.id = at.tag_id
+			WHERE at.article_id = a.id AND t.slug = ANY($%d))`, argPos))
+		args = append(args, pq.Array(filter.TagsAny))
+		argPos++
+	}
+	if len(filter.TagsAll) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`(
+			SELECT COUNT(DISTINCT t.slug) FROM article_tags at JOIN tags t ON t.id = at.tag_id
+			WHERE at.article_id = a.id AND t.slug = ANY($%d)) = $%d`, argPos, argPos+1))
+		args = append(args, pq.Array(filter.TagsAll), len(filter.TagsAll))
+		argPos += 2
+	}
+
+	return conditions, args
+}
+
+// articleOrder returns the ORDER BY clause of a listing, appending its arguments to
+// args, the arguments of the listing's conditions.
+func articleOrder(filter model.ArticleQuery, args []any) (string, []any) {
+	orderBy, ok := articleSortOrder[filter.Sort]
+	if !ok {
+		orderBy = articleSortOrder["-created_at"]
+	}
+	// Without a search query there is nothing to rank against, so relevance keeps the default.
+	if filter.Sort == "relevance" && filter.Query != "" {
+		orderBy = fmt.Sprintf(
+			"ts_rank(%s, plainto_tsquery(article_search_config(a.language), $%d)) DESC, a.created_at DESC",
+			articleSearchVector, len(args)+1,
+		)
+		args = append(args, filter.Query)
+	}
+	return orderBy, args
+}
+
+func (r *articleRepository) FindAll(ctx context.Context, filter model.ArticleQuery) ([]*model.Article, int, error) {
+	log := logger.FromContext(ctx)
+
 	cacheableLimit := filter.Limit
 	if cacheableLimit <= 0 {
 		cacheableLimit = model.CacheableLimit
 	}
 
-	shouldCache := filter.Query == "" && filter.Page == 1 && cacheableLimit == model.CacheableLimit
+	hasTagFilter := filter.Tag != "" || len(filter.TagsAny) > 0 || len(filter.TagsAll) > 0
+	hasAuthorFilter := filter.Author != "" || len(filter.AuthorIDs) > 0 || filter.BookmarkedBy != ""
+	hasDateFilter := filter.CreatedFrom != "" || filter.CreatedTo != ""
+	shouldCache := filter.Query == "" && filter.Category == "" && filter.Lang == "" && !hasTagFilter && !hasAuthorFilter && !hasDateFilter &&
+		filter.Sort == "" && filter.Page == 1 && cacheableLimit == model.CacheableLimit
 	var cacheKey string
 	if shouldCache {
-		cacheKey = fmt.Sprintf("%s:page=%d:limit=%d", model.ArticleKey, filter.Page, filter.Limit)
+		cacheKey = firstPageCacheKey(filter.Fields)
 
 		var cached model.CachedArticles
 		if err := r.cache.Get(ctx, cacheKey, &cached); err == nil {
@@ -47,23 +356,9 @@ func (r *articleRepository) FindAll(ctx context.Context, filter model.ArticleQue
 		}
 	}
 
-	baseQuery := `
-		SELECT a.id, a.author_id, au.name, a.title, a.body, a.created_at
-		FROM articles a
-		JOIN authors au ON a.author_id = au.id
-	`
+	defer metrics.ObserveQuery("article", "FindAll")()
 
-	argPos := 1
-	if filter.Query != "" {
-		conditions = append(conditions, fmt.Sprintf("(a.title ILIKE $%d OR a.body ILIKE $%d)", argPos, argPos+1))
-		args = append(args, "%"+filter.Query+"%", "%"+filter.Query+"%")
-		argPos += 2
-	}
-	if filter.Author != "" {
-		conditions = append(conditions, fmt.Sprintf("au.name ILIKE $%d", argPos))
-		args = append(args, "%"+filter.Author+"%")
-		argPos++
-	}
+	conditions, args := articleConditions(filter)
 
 	whereClause := ""
 	if len(conditions) > 0 {
@@ -79,14 +374,23 @@ func (r *articleRepository) FindAll(ctx context.Context, filter model.ArticleQue
 		offset = 0
 	}
 
+	// The count query shares the filter arguments only.
+	countArgs := append([]any(nil), args...)
+
+	orderBy, args := articleOrder(filter, args)
+	argPos := len(args) + 1
+
 	args = append(args, limit, offset)
 	limitPos := argPos
 	offsetPos := argPos + 1
 
+	columns := articleColumnsFor(filter.Fields)
+
 	fullQuery := fmt.Sprintf(
-		"%s%s ORDER BY a.created_at DESC LIMIT $%d OFFSET $%d",
-		baseQuery,
+		"%s%s ORDER BY %s LIMIT $%d OFFSET $%d",
+		articleSelect(columns),
 		whereClause,
+		orderBy,
 		limitPos,
 		offsetPos,
 	)
@@ -107,22 +411,35 @@ func (r *articleRepository) FindAll(ctx context.Context, filter model.ArticleQue
 
 	var results []*model.Article
 	for rows.Next() {
-		var a model.Article
-		if err := rows.Scan(&a.ID, &a.AuthorID, &a.Author, &a.Title, &a.Body, &a.CreatedAt); err != nil {
+		a, err := scanArticle(rows, columns)
+		if err != nil {
 			log.Error(err)
 			return nil, 0, err
 		}
-		results = append(results, &a)
+		results = append(results, a)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, 0, err
 	}
 
-	countArgs := args[:len(args)-2]
+	// Timed on its own as well, since COUNT(*) over large filters is the usual slow spot.
+	observeCount := metrics.ObserveQuery("article", "FindAll.count")
 	var total int
 	err = r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
+	observeCount()
 	if err != nil {
 		log.Error(err)
 		return nil, 0, err
 	}
 
+	if len(filter.Fields) == 0 || slices.Contains(filter.Fields, "tags") {
+		if err := loadTags(ctx, r.db, results); err != nil {
+			log.Error(err)
+			return nil, 0, err
+		}
+	}
+
 	if shouldCache {
 		if err := r.cache.Set(ctx, cacheKey, model.CachedArticles{
 			Results: results,
@@ -135,32 +452,674 @@ func (r *articleRepository) FindAll(ctx context.Context, filter model.ArticleQue
 	return results, total, nil
 }
 
+// streamPageSize is the number of articles Stream reads per query.
+const streamPageSize = 500
+
+func (r *articleRepository) Stream(ctx context.Context, filter model.ArticleQuery, fn func(*model.Article) error) error {
+	log := logger.FromContext(ctx)
+
+	conditions, args := articleConditions(filter)
+
+	var last *model.Article
+	for {
+		// Each page continues after the last article of the previous one, so the position
+		// holds while articles are written and every query stays cheap on large tables.
+		pageConditions, pageArgs := conditions, args
+		if last != nil {
+			pageConditions = append(conditions[:len(conditions):len(conditions)],
+				fmt.Sprintf("(a.created_at, a.id) > ($%d, $%d)", len(args)+1, len(args)+2))
+			pageArgs = append(args[:len(args):len(args)], last.CreatedAt, last.ID)
+		}
+
+		whereClause := ""
+		if len(pageConditions) > 0 {
+			whereClause = " WHERE " + strings.Join(pageConditions, " AND ")
+		}
+		query := fmt.Sprintf("%s%s ORDER BY a.created_at ASC, a.id ASC LIMIT %d",
+			articleSelect(articleSelectColumns), whereClause, streamPageSize)
+
+		page, err := r.streamPage(ctx, query, pageArgs)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		for _, article := range page {
+			if err := fn(article); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < streamPageSize {
+			return nil
+		}
+		last = page[len(page)-1]
+	}
+}
+
+func (r *articleRepository) streamPage(ctx context.Context, query string, args []any) ([]*model.Article, error) {
+	defer metrics.ObserveQuery("article", "Stream")()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var page []*model.Article
+	for rows.Next() {
+		a, err := scanArticle(rows, articleSelectColumns)
+		if err != nil {
+			return nil, err
+		}
+		page = append(page, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadTags(ctx, r.db, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (r *articleRepository) StreamSitemap(ctx context.Context, offset, limit int, fn func(*model.Article) error) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("article", "StreamSitemap")()
+
+	query := `SELECT id, slug, updated_at FROM articles ORDER BY created_at ASC, id ASC OFFSET $1 LIMIT $2`
+	rows, err := r.db.QueryContext(ctx, query, offset, limit)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a model.Article
+		if err := rows.Scan(&a.ID, &a.Slug, &a.UpdatedAt); err != nil {
+			log.Error(err)
+			return err
+		}
+		if err := fn(&a); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *articleRepository) Count(ctx context.Context) (int, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("article", "Count")()
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM articles`).Scan(&count); err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Weights of the signals FindRelated ranks by. Text similarity is a ts_rank, mostly
+// well below 1, so it orders articles that share as many tags and the author.
+const (
+	relatedTagWeight    = 3
+	relatedAuthorWeight = 1
+	relatedTextWeight   = 2
+)
+
+// relatedColumns are read for related articles: every column but the body.
+var relatedColumns = slices.DeleteFunc(slices.Clone(articleSelectColumns), func(column string) bool {
+	return column == "a.body" || column == "a.body_html"
+})
+
+func relatedCacheKey(id uuid.UUID, limit int) string {
+	return fmt.Sprintf("%s:%s:limit=%d", model.RelatedKey, id, limit)
+}
+
+func (r *articleRepository) FindRelated(ctx context.Context, id uuid.UUID, limit int) ([]*model.Article, error) {
+	log := logger.FromContext(ctx)
+
+	cacheKey := relatedCacheKey(id, limit)
+
+	var cached []*model.Article
+	if err := r.cache.Get(ctx, cacheKey, &cached); err == nil {
+		return cached, nil
+	}
+
+	defer metrics.ObserveQuery("article", "FindRelated")()
+
+	// The title's lexemes, OR'ed into a query, find similar texts through the
+	// title || body full-text index. Candidates are in the language of the article,
+	// are not its translations and share a tag, the author or some text.
+	query := `
+	WITH target AS (
+		SELECT t.id, t.author_id, t.language, t.translation_id,
+			(SELECT to_tsquery('simple', string_agg(quote_literal(lexeme), ' | '))
+			FROM unnest(tsvector_to_array(to_tsvector(article_search_config(t.language), t.title))) AS lexeme) AS terms
+		FROM articles t
+		WHERE t.id = $1
+	),
+	related AS (
+		SELECT a.id,
+			$2 * (SELECT COUNT(*) FROM article_tags at
+				WHERE at.article_id = a.id AND at.tag_id IN (SELECT tag_id FROM article_tags WHERE article_id = target.id))
+			+ CASE WHEN a.author_id = target.author_id THEN $3 ELSE 0 END
+			+ $4 * COALESCE(ts_rank(` + articleSearchVector + `, target.terms), 0) AS score
+		FROM articles a, target
+		WHERE a.translation_id <> target.translation_id AND a.language = target.language AND (
+			a.author_id = target.author_id
+			OR EXISTS (
+				SELECT 1 FROM article_tags at
+				WHERE at.article_id = a.id AND at.tag_id IN (SELECT tag_id FROM article_tags WHERE article_id = target.id))
+			OR ` + articleSearchVector + ` @@ target.terms)
+	)` + articleSelect(relatedColumns) + `
+	JOIN related r ON r.id = a.id
+	ORDER BY r.score DESC, a.created_at DESC
+	LIMIT $5
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id, relatedTagWeight, relatedAuthorWeight, relatedTextWeight, limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*model.Article{}
+	for rows.Next() {
+		article, err := scanArticle(rows, relatedColumns)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		results = append(results, article)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := loadTags(ctx, r.db, results); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := r.cache.Set(ctx, cacheKey, results, config.RedisExpired()); err != nil {
+		log.Warn("failed to cache related articles")
+	}
+
+	return results, nil
+}
+
+func (r *articleRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Article, error) {
+	defer metrics.ObserveQuery("article", "FindByID")()
+
+	return r.findOne(ctx, articleSelect(articleSelectColumns)+" WHERE a.id = $1", id)
+}
+
+func (r *articleRepository) FindByIDs(ctx context.Context, ids []uuid.UUID, fields []string) ([]*model.Article, error) {
+	log := logger.FromContext(ctx)
+
+	if len(ids) == 0 {
+		return []*model.Article{}, nil
+	}
+
+	defer metrics.ObserveQuery("article", "FindByIDs")()
+
+	columns := articleColumnsFor(fields)
+	rows, err := r.db.QueryContext(ctx, articleSelect(columns)+" WHERE a.id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	byID := make(map[uuid.UUID]*model.Article, len(ids))
+	for rows.Next() {
+		a, err := scanArticle(rows, columns)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		byID[a.ID] = a
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	articles := make([]*model.Article, 0, len(byID))
+	for _, id := range ids {
+		if a, ok := byID[id]; ok {
+			articles = append(articles, a)
+		}
+	}
+
+	if len(fields) == 0 || slices.Contains(fields, "tags") {
+		if err := loadTags(ctx, r.db, articles); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+	}
+
+	return articles, nil
+}
+
+func (r *articleRepository) FindBySlug(ctx context.Context, slug string) (*model.Article, error) {
+	defer metrics.ObserveQuery("article", "FindBySlug")()
+
+	return r.findOne(ctx, articleSelect(articleSelectColumns)+" WHERE a.slug = $1", slug)
+}
+
+func (r *articleRepository) FindTranslations(ctx context.Context, translationID uuid.UUID) ([]*model.ArticleTranslation, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("article", "FindTranslations")()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, language, title, slug
+		FROM articles
+		WHERE translation_id = $1
+		ORDER BY language`, translationID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []*model.ArticleTranslation{}
+	for rows.Next() {
+		var t model.ArticleTranslation
+		if err := rows.Scan(&t.ID, &t.Language, &t.Title, &t.Slug); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		translations = append(translations, &t)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+func (r *articleRepository) findOne(ctx context.Context, query string, arg any) (*model.Article, error) {
+	log := logger.FromContext(ctx)
+
+	article, err := scanArticle(r.db.QueryRowContext(ctx, query, arg), articleSelectColumns)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := loadTags(ctx, r.db, []*model.Article{article}); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return article, nil
+}
+
+func (r *articleRepository) FindSlugRedirect(ctx context.Context, slug string) (string, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("article", "FindSlugRedirect")()
+
+	query := `
+		SELECT a.slug
+		FROM article_slug_history h
+		JOIN articles a ON a.id = h.article_id
+		WHERE h.slug = $1
+	`
+
+	var canonical string
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(&canonical)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		log.Error(err)
+		return "", err
+	}
+
+	return canonical, nil
+}
+
+func (r *articleRepository) FindSlugs(ctx context.Context, base string, excludeID uuid.UUID) ([]string, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("article", "FindSlugs")()
+
+	// base only contains [a-z0-9-], so it is safe to embed in the pattern.
+	query := `
+		SELECT slug FROM articles
+		WHERE (slug = $1 OR slug ~ ('^' || $1 || '-[0-9]+$')) AND id <> $2
+		UNION
+		SELECT slug FROM article_slug_history
+		WHERE (slug = $1 OR slug ~ ('^' || $1 || '-[0-9]+$')) AND article_id <> $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, base, excludeID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slugs []string
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		slugs = append(slugs, slug)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return slugs, nil
+}
+
 func (r *articleRepository) Create(ctx context.Context, article *model.Article) (*model.Article, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("article", "Create")()
+
 	article.ID = uuid.New()
+	defaultTranslation(article)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer tx.Rollback()
 
 	query := `
-		INSERT INTO articles (id, author_id, title, body, created_at)
-		VALUES ($1, $2, $3, $4, NOW())
-		RETURNING created_at
+		INSERT INTO articles (id, author_id, title, slug, body, body_html, excerpt, word_count, reading_time, category_id,
+			language, translation_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, COALESCE($13, NOW()), COALESCE($13, NOW()))
+		RETURNING created_at, updated_at
 	`
 
-	err := r.db.QueryRowContext(
+	// Imports keep the original date of an article; everything else is dated now.
+	createdAt := sql.NullTime{Time: article.CreatedAt, Valid: !article.CreatedAt.IsZero()}
+
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		article.ID,
 		article.AuthorID,
 		article.Title,
+		article.Slug,
 		article.Body,
-	).Scan(&article.CreatedAt)
+		article.BodyHTML,
+		article.Excerpt,
+		article.WordCount,
+		article.ReadingTime,
+		article.CategoryID,
+		article.Language,
+		article.TranslationID,
+		createdAt,
+	).Scan(&article.CreatedAt, &article.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := attachTags(ctx, tx, article.ID, article.Tags); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	r.invalidate(ctx, len(article.Tags) > 0)
+
+	return article, nil
+}
+
+// defaultTranslation writes article in the default language and, unless it translates
+// another article, starts its own set of translations.
+func defaultTranslation(article *model.Article) {
+	if article.Language == "" {
+		article.Language = config.DefaultArticleLanguage
+	}
+	if article.TranslationID == uuid.Nil {
+		article.TranslationID = article.ID
+	}
+}
+
+func (r *articleRepository) Update(ctx context.Context, article *model.Article) (*model.Article, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("article", "Update")()
+
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		log.Error(err)
 		return nil, err
 	}
+	defer tx.Rollback()
 
-	err = r.cache.Delete(ctx, fmt.Sprintf("%s:page=1:limit=%d", model.ArticleKey, model.CacheableLimit))
+	var previousSlug string
+	err = tx.QueryRowContext(ctx, `SELECT slug FROM articles WHERE id = $1 FOR UPDATE`, article.ID).Scan(&previousSlug)
 	if err != nil {
-		log.Warn("failed to delete cache articles")
+		log.Error(err)
+		return nil, err
 	}
 
+	query := `
+		UPDATE articles
+		SET title = $2, slug = $3, body = $4, body_html = $5, excerpt = $6, word_count = $7, reading_time = $8,
+			category_id = $9, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		article.ID,
+		article.Title,
+		article.Slug,
+		article.Body,
+		article.BodyHTML,
+		article.Excerpt,
+		article.WordCount,
+		article.ReadingTime,
+		article.CategoryID,
+	).Scan(&article.CreatedAt, &article.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if previousSlug != article.Slug {
+		// Keep the old slug resolvable and release the new one if it was historical.
+		history := `
+			INSERT INTO article_slug_history (slug, article_id, created_at)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT (slug) DO UPDATE SET article_id = EXCLUDED.article_id, created_at = EXCLUDED.created_at
+		`
+		if _, err := tx.ExecContext(ctx, history, previousSlug, article.ID); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM article_slug_history WHERE slug = $1`, article.Slug); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM article_tags WHERE article_id = $1`, article.ID); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := attachTags(ctx, tx, article.ID, article.Tags); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	r.invalidate(ctx, true)
+
 	return article, nil
 }
+
+func (r *articleRepository) BulkCreate(ctx context.Context, articles []*model.Article) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("article", "BulkCreate")()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("articles",
+		"id", "author_id", "title", "slug", "body", "body_html", "excerpt", "word_count", "reading_time",
+		"category_id", "language", "translation_id", "created_at", "updated_at",
+	))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	var slugs []string
+	for _, article := range articles {
+		if article.ID == uuid.Nil {
+			article.ID = uuid.New()
+		}
+		if article.CreatedAt.IsZero() {
+			article.CreatedAt = now
+		}
+		if article.UpdatedAt.IsZero() {
+			article.UpdatedAt = article.CreatedAt
+		}
+		defaultTranslation(article)
+
+		_, err := stmt.ExecContext(ctx,
+			article.ID, article.AuthorID, article.Title, article.Slug, article.Body, article.BodyHTML,
+			article.Excerpt, article.WordCount, article.ReadingTime, article.CategoryID,
+			article.Language, article.TranslationID, article.CreatedAt, article.UpdatedAt,
+		)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		slugs = append(slugs, article.Tags...)
+	}
+
+	// An Exec without arguments flushes the COPY buffer.
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if len(slugs) > 0 {
+		if err := copyArticleTags(ctx, tx, articles, slugs); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	r.invalidate(ctx, len(slugs) > 0)
+
+	return nil
+}
+
+// copyArticleTags upserts the tags used by articles and links them in one COPY.
+func copyArticleTags(ctx context.Context, tx *sql.Tx, articles []*model.Article, slugs []string) error {
+	tagIDs, err := upsertTags(ctx, tx, slugs)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("article_tags", "article_id", "tag_id"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, article := range articles {
+		for _, slug := range article.Tags {
+			if _, err := stmt.ExecContext(ctx, article.ID, tagIDs[slug]); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
+
+func (r *articleRepository) DeleteAll(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("article", "DeleteAll")()
+
+	// The cascade takes article_tags and article_slug_history along.
+	if _, err := r.db.ExecContext(ctx, `TRUNCATE articles CASCADE`); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	r.invalidate(ctx, true)
+
+	return nil
+}
+
+// invalidate drops cached listings after an article write. Any article may now rank
+// differently for any other, so every set of related articles goes as well.
+func (r *articleRepository) invalidate(ctx context.Context, tagsChanged bool) {
+	log := logger.FromContext(ctx)
+
+	deleteListingCache(ctx, r.cache)
+
+	if err := r.cache.DeleteByPrefix(ctx, model.RelatedKey+":"); err != nil {
+		log.Warn("failed to delete cache related articles")
+	}
+
+	if tagsChanged {
+		if err := r.cache.Delete(ctx, tagCountsCacheKey()); err != nil {
+			log.Warn("failed to delete cache tags")
+		}
+	}
+}
@@ -2,17 +2,26 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
 	"github.com/bagasss3/go-article/pkg/model"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+var articleColumns = []string{"id", "author_id", "name", "title", "slug", "body", "body_html", "excerpt", "word_count", "reading_time", "comment_count", "view_count", "reactions", "category_id", "language", "translation_id", "created_at", "updated_at"}
+
+func articleRow(id, authorID any, author, title, body string) []driver.Value {
+	return []driver.Value{id, authorID, author, title, "slug", body, "<p>" + body + "</p>", body, 1, 1, 0, 0, []byte("{}"), nil, "en", id, time.Now(), time.Now()}
+}
+
 func TestArticleRepository_Create(t *testing.T) {
 	kit := initializeRepoTestKit(t)
 	defer kit.closer()
@@ -23,23 +32,68 @@ func TestArticleRepository_Create(t *testing.T) {
 	article := &model.Article{
 		AuthorID: uuid.New(),
 		Title:    "Test Title",
+		Slug:     "test-title",
 		Body:     "Test Body",
 	}
 
 	t.Run("success", func(t *testing.T) {
+		feedKey := model.FeedKey + ":rss:author=:tag=:content=excerpt"
+		kit.cache.Set(ctx, feedKey, "cached", time.Minute)
+
+		kit.mock.ExpectBegin()
 		kit.mock.ExpectQuery("INSERT INTO articles").
-			WithArgs(sqlmock.AnyArg(), article.AuthorID, article.Title, article.Body).
-			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+			WithArgs(sqlmock.AnyArg(), article.AuthorID, article.Title, article.Slug, article.Body, article.BodyHTML, article.Excerpt, article.WordCount, article.ReadingTime, article.CategoryID, "en", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+		kit.mock.ExpectCommit()
 
 		result, err := repo.Create(ctx, article)
 		require.NoError(t, err)
 		require.NotNil(t, result)
+
+		var cached string
+		require.ErrorIs(t, kit.cache.Get(ctx, feedKey, &cached), cache.ErrCacheMiss)
+	})
+
+	t.Run("keeps given created at", func(t *testing.T) {
+		createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		imported := &model.Article{AuthorID: uuid.New(), Title: "Imported", Body: "Body", CreatedAt: createdAt}
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery(`COALESCE\(\$13, NOW\(\)\)`).
+			WithArgs(sqlmock.AnyArg(), imported.AuthorID, imported.Title, imported.Slug, imported.Body, imported.BodyHTML, imported.Excerpt, imported.WordCount, imported.ReadingTime, imported.CategoryID, "en", sqlmock.AnyArg(), sql.NullTime{Time: createdAt, Valid: true}).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(createdAt, createdAt))
+		kit.mock.ExpectCommit()
+
+		result, err := repo.Create(ctx, imported)
+		require.NoError(t, err)
+		require.Equal(t, createdAt, result.CreatedAt)
+		require.Equal(t, createdAt, result.UpdatedAt)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("translation keeps its language and translation id", func(t *testing.T) {
+		originalID := uuid.New()
+		translation := &model.Article{AuthorID: uuid.New(), Title: "Halo", Body: "Isi", Language: "id", TranslationID: originalID}
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery("INSERT INTO articles").
+			WithArgs(sqlmock.AnyArg(), translation.AuthorID, translation.Title, translation.Slug, translation.Body, translation.BodyHTML, translation.Excerpt, translation.WordCount, translation.ReadingTime, translation.CategoryID, "id", originalID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+		kit.mock.ExpectCommit()
+
+		result, err := repo.Create(ctx, translation)
+		require.NoError(t, err)
+		require.Equal(t, originalID, result.TranslationID)
+		require.NotEqual(t, originalID, result.ID)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
 	})
 
 	t.Run("insert error", func(t *testing.T) {
+		kit.mock.ExpectBegin()
 		kit.mock.ExpectQuery("INSERT INTO articles").
-			WithArgs(sqlmock.AnyArg(), article.AuthorID, article.Title, article.Body).
+			WithArgs(sqlmock.AnyArg(), article.AuthorID, article.Title, article.Slug, article.Body, article.BodyHTML, article.Excerpt, article.WordCount, article.ReadingTime, article.CategoryID, "en", sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnError(errors.New("db error"))
+		kit.mock.ExpectRollback()
 
 		_, err := repo.Create(ctx, article)
 		require.Error(t, err)
@@ -49,14 +103,66 @@ func TestArticleRepository_Create(t *testing.T) {
 		kit.mockCache.DelShouldError = true
 		defer func() { kit.mockCache.DelShouldError = false }()
 
+		kit.mock.ExpectBegin()
 		kit.mock.ExpectQuery("INSERT INTO articles").
-			WithArgs(sqlmock.AnyArg(), article.AuthorID, article.Title, article.Body).
-			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+			WithArgs(sqlmock.AnyArg(), article.AuthorID, article.Title, article.Slug, article.Body, article.BodyHTML, article.Excerpt, article.WordCount, article.ReadingTime, article.CategoryID, "en", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+		kit.mock.ExpectCommit()
 
 		result, err := repo.Create(ctx, article)
 		require.NoError(t, err)
 		require.NotNil(t, result)
 	})
+
+	t.Run("success with tags", func(t *testing.T) {
+		tagged := &model.Article{
+			AuthorID: uuid.New(),
+			Title:    "Tagged",
+			Body:     "Body",
+			Tags:     []string{"go", "database"},
+		}
+		tagID := uuid.New()
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery("INSERT INTO articles").
+			WithArgs(sqlmock.AnyArg(), tagged.AuthorID, tagged.Title, tagged.Slug, tagged.Body, tagged.BodyHTML, tagged.Excerpt, tagged.WordCount, tagged.ReadingTime, tagged.CategoryID, "en", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+		for _, slug := range tagged.Tags {
+			kit.mock.ExpectQuery("INSERT INTO tags").
+				WithArgs(sqlmock.AnyArg(), slug).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(tagID))
+			kit.mock.ExpectExec("INSERT INTO article_tags").
+				WithArgs(sqlmock.AnyArg(), tagID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		kit.mock.ExpectCommit()
+
+		result, err := repo.Create(ctx, tagged)
+		require.NoError(t, err)
+		require.Equal(t, []string{"go", "database"}, result.Tags)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("attach tag error", func(t *testing.T) {
+		tagged := &model.Article{
+			AuthorID: uuid.New(),
+			Title:    "Tagged",
+			Body:     "Body",
+			Tags:     []string{"go"},
+		}
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery("INSERT INTO articles").
+			WithArgs(sqlmock.AnyArg(), tagged.AuthorID, tagged.Title, tagged.Slug, tagged.Body, tagged.BodyHTML, tagged.Excerpt, tagged.WordCount, tagged.ReadingTime, tagged.CategoryID, "en", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+		kit.mock.ExpectQuery("INSERT INTO tags").
+			WillReturnError(errors.New("tag error"))
+		kit.mock.ExpectRollback()
+
+		_, err := repo.Create(ctx, tagged)
+		require.Error(t, err)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
 }
 
 func TestArticleRepository_FindAll(t *testing.T) {
@@ -67,8 +173,8 @@ func TestArticleRepository_FindAll(t *testing.T) {
 	ctx := context.TODO()
 
 	t.Run("success with result", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "author_id", "name", "title", "body", "created_at"}).
-			AddRow(uuid.New(), uuid.New(), "Author", "Title", "Body", time.Now())
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")...)
 
 		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
 			WillReturnRows(rows)
@@ -76,6 +182,9 @@ func TestArticleRepository_FindAll(t *testing.T) {
 		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
 		filter := model.ArticleQuery{Page: 1, Limit: 10}
 		res, total, err := repo.FindAll(ctx, filter)
 		require.NoError(t, err)
@@ -83,6 +192,26 @@ func TestArticleRepository_FindAll(t *testing.T) {
 		assert.Len(t, res, 1)
 	})
 
+	t.Run("bookmarks of a user with reaction counts", func(t *testing.T) {
+		row := articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")
+		row[12] = []byte(`{"like": 3, "love": 1}`)
+
+		kit.mock.ExpectQuery(`EXISTS \(\s+SELECT 1 FROM article_bookmarks b WHERE b.article_id = a.id AND b.user_id = \$1\)`).
+			WithArgs("user-1", 10, 0).
+			WillReturnRows(sqlmock.NewRows(articleColumns).AddRow(row...))
+		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+			WithArgs("user-1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
+		res, _, err := repo.FindAll(ctx, model.ArticleQuery{Page: 1, Limit: 10, BookmarkedBy: "user-1"})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, map[string]int{"like": 3, "love": 1}, res[0].Reactions)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
 	t.Run("query error", func(t *testing.T) {
 		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
 			WillReturnError(errors.New("query error"))
@@ -92,8 +221,8 @@ func TestArticleRepository_FindAll(t *testing.T) {
 	})
 
 	t.Run("scan error", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "author_id", "name", "title", "body", "created_at"}).
-			AddRow("bad-uuid", uuid.New(), "Author", "Title", "Body", time.Now())
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(articleRow("bad-uuid", uuid.New(), "Author", "Title", "Body")...)
 
 		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
 			WillReturnRows(rows)
@@ -102,9 +231,22 @@ func TestArticleRepository_FindAll(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("row iteration error", func(t *testing.T) {
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")...).
+			RowError(0, errors.New("connection reset"))
+
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
+			WillReturnRows(rows)
+
+		_, _, err := repo.FindAll(ctx, model.ArticleQuery{})
+		require.EqualError(t, err, "connection reset")
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
 	t.Run("count error", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "author_id", "name", "title", "body", "created_at"}).
-			AddRow(uuid.New(), uuid.New(), "Author", "Title", "Body", time.Now())
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")...)
 
 		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
 			WillReturnRows(rows)
@@ -116,28 +258,32 @@ func TestArticleRepository_FindAll(t *testing.T) {
 		require.Error(t, err)
 	})
 
-	t.Run("with title/body filter and author name", func(t *testing.T) {
+	t.Run("with title/body filter, language and author name", func(t *testing.T) {
 		titleBody := "%search%"
 		authorName := "%john%"
 
 		filter := model.ArticleQuery{
 			Query:  "search",
+			Lang:   "id",
 			Author: "john",
 			Page:   2,
 			Limit:  5,
 		}
 
-		rows := sqlmock.NewRows([]string{"id", "author_id", "name", "title", "body", "created_at"}).
-			AddRow(uuid.New(), uuid.New(), "John", "Search match", "Body", time.Now())
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(articleRow(uuid.New(), uuid.New(), "John", "Search match", "Body")...)
 
-		kit.mock.ExpectQuery("SELECT a.id, a.author_id.*FROM articles a.*JOIN authors au").
-			WithArgs(titleBody, titleBody, authorName, 5, 5).
+		kit.mock.ExpectQuery(`SELECT a.id, a.author_id.*FROM articles a.*JOIN authors au.*@@ plainto_tsquery\(article_search_config\(a.language\), \$3\)\) AND a.language = \$4`).
+			WithArgs(titleBody, titleBody, "search", "id", authorName, 5, 5).
 			WillReturnRows(rows)
 
 		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM articles a.*").
-			WithArgs(titleBody, titleBody, authorName).
+			WithArgs(titleBody, titleBody, "search", "id", authorName).
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
 		res, total, err := repo.FindAll(ctx, filter)
 		require.NoError(t, err)
 		require.Len(t, res, 1)
@@ -147,8 +293,8 @@ func TestArticleRepository_FindAll(t *testing.T) {
 	t.Run("default limit and page", func(t *testing.T) {
 		filter := model.ArticleQuery{}
 
-		rows := sqlmock.NewRows([]string{"id", "author_id", "name", "title", "body", "created_at"}).
-			AddRow(uuid.New(), uuid.New(), "John", "Title", "Body", time.Now())
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(articleRow(uuid.New(), uuid.New(), "John", "Title", "Body")...)
 
 		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
 			WithArgs(10, 0).
@@ -157,12 +303,132 @@ func TestArticleRepository_FindAll(t *testing.T) {
 		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
 		res, total, err := repo.FindAll(ctx, filter)
 		require.NoError(t, err)
 		assert.Equal(t, 1, total)
 		assert.Len(t, res, 1)
 	})
 
+	t.Run("with category filter", func(t *testing.T) {
+		filter := model.ArticleQuery{Category: "tech", Page: 1, Limit: 10}
+
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id.*WITH RECURSIVE tree").
+			WithArgs("tech", 10, 0).
+			WillReturnRows(sqlmock.NewRows(articleColumns).AddRow(articleRow(uuid.New(), uuid.New(), "John", "Title", "Body")...))
+
+		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+			WithArgs("tech").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
+		res, total, err := repo.FindAll(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("with tag filters", func(t *testing.T) {
+		id := uuid.New()
+		filter := model.ArticleQuery{
+			Tag:     "go",
+			TagsAny: []string{"db", "sql"},
+			TagsAll: []string{"go", "web"},
+			Page:    1,
+			Limit:   10,
+		}
+
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(articleRow(id, uuid.New(), "John", "Title", "Body")...)
+
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id.*t.slug = \\$1.*t.slug = ANY\\(\\$2\\).*t.slug = ANY\\(\\$3\\)\\) = \\$4").
+			WithArgs("go", sqlmock.AnyArg(), sqlmock.AnyArg(), 2, 10, 0).
+			WillReturnRows(rows)
+
+		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+			WithArgs("go", sqlmock.AnyArg(), sqlmock.AnyArg(), 2).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}).
+				AddRow(id, "go").
+				AddRow(id, "web"))
+
+		res, total, err := repo.FindAll(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []string{"go", "web"}, res[0].Tags)
+	})
+
+	t.Run("with author ids, date range and sort", func(t *testing.T) {
+		authorID := uuid.New().String()
+		filter := model.ArticleQuery{
+			AuthorIDs:   []string{authorID},
+			CreatedFrom: "2025-01-01T00:00",
+			CreatedTo:   "2025-02-01T00:00",
+			Sort:        "-title",
+			Page:        1,
+			Limit:       10,
+		}
+
+		kit.mock.ExpectQuery("a.author_id = ANY\\(\\$1\\) AND a.created_at >= \\$2::timestamp AND a.created_at <= \\$3::timestamp ORDER BY a.title DESC LIMIT \\$4 OFFSET \\$5").
+			WithArgs(sqlmock.AnyArg(), "2025-01-01T00:00", "2025-02-01T00:00", 10, 0).
+			WillReturnRows(sqlmock.NewRows(articleColumns).AddRow(articleRow(uuid.New(), authorID, "John", "Title", "Body")...))
+
+		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+			WithArgs(sqlmock.AnyArg(), "2025-01-01T00:00", "2025-02-01T00:00").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
+		res, total, err := repo.FindAll(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 1, total)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("relevance sort ranks the search query", func(t *testing.T) {
+		filter := model.ArticleQuery{Query: "go", Sort: "relevance", Page: 2, Limit: 10}
+
+		kit.mock.ExpectQuery("ORDER BY ts_rank\\(.*plainto_tsquery\\(article_search_config\\(a.language\\), \\$4\\)\\) DESC, a.created_at DESC LIMIT \\$5 OFFSET \\$6").
+			WithArgs("%go%", "%go%", "go", "go", 10, 10).
+			WillReturnRows(sqlmock.NewRows(articleColumns))
+
+		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+			WithArgs("%go%", "%go%", "go").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		res, total, err := repo.FindAll(ctx, filter)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+		assert.Equal(t, 0, total)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("load tags error", func(t *testing.T) {
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")...)
+
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
+			WillReturnRows(rows)
+
+		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnError(errors.New("tags failed"))
+
+		_, _, err := repo.FindAll(ctx, model.ArticleQuery{Query: "x"})
+		require.Error(t, err)
+	})
+
 	t.Run("cache hit returns early", func(t *testing.T) {
 		filter := model.ArticleQuery{
 			Page:  1,
@@ -185,12 +451,44 @@ func TestArticleRepository_FindAll(t *testing.T) {
 		assert.Equal(t, 1, total)
 	})
 
+	t.Run("selected fields", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "title", "created_at"}).
+			AddRow(uuid.New(), "Title", time.Now())
+
+		kit.mock.ExpectQuery(`SELECT a.id, a.title, a.created_at\s+FROM articles a`).
+			WithArgs(10, 0).
+			WillReturnRows(rows)
+
+		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		fields := []string{"title", "created_at"}
+		res, total, err := repo.FindAll(ctx, model.ArticleQuery{Page: 1, ArticleReadOptions: model.ArticleReadOptions{Fields: fields}})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, "Title", res[0].Title)
+		assert.Empty(t, res[0].Body)
+		assert.Nil(t, res[0].Tags)
+		assert.Equal(t, 1, total)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+
+		// The field set is part of the cache key and listing invalidation covers it.
+		key := "article:page=1:limit=10:fields=created_at,title"
+		var cached model.CachedArticles
+		require.NoError(t, kit.cache.Get(ctx, key, &cached))
+		require.NoError(t, kit.cache.Set(ctx, "article:page=1:limit=10", cached, time.Minute))
+
+		deleteListingCache(ctx, kit.cache)
+		assert.Error(t, kit.cache.Get(ctx, key, &cached))
+		assert.Error(t, kit.cache.Get(ctx, "article:page=1:limit=10", &cached))
+	})
+
 	t.Run("cache set error", func(t *testing.T) {
 		kit.mockCache.SetShouldError = true
 		defer func() { kit.mockCache.SetShouldError = false }()
 
-		rows := sqlmock.NewRows([]string{"id", "author_id", "name", "title", "body", "created_at"}).
-			AddRow(uuid.New(), uuid.New(), "Author", "Title", "Body", time.Now())
+		rows := sqlmock.NewRows(articleColumns).
+			AddRow(articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")...)
 
 		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
 			WillReturnRows(rows)
@@ -198,6 +496,9 @@ func TestArticleRepository_FindAll(t *testing.T) {
 		kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\)").
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
 		filter := model.ArticleQuery{Page: 1, Limit: 10}
 		res, total, err := repo.FindAll(ctx, filter)
 		require.NoError(t, err)
@@ -205,3 +506,496 @@ func TestArticleRepository_FindAll(t *testing.T) {
 		assert.Equal(t, 1, total)
 	})
 }
+
+func TestArticleRepository_Stream(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("pages after the last article", func(t *testing.T) {
+		first := sqlmock.NewRows(articleColumns)
+		lastID := uuid.New()
+		lastCreated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < streamPageSize-1; i++ {
+			first.AddRow(articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")...)
+		}
+		first.AddRow(lastID, uuid.New(), "Author", "Title", "slug", "Body", "<p>Body</p>", "Body", 1, 1, 0, 0, []byte("{}"), nil, "en", lastID, lastCreated, lastCreated)
+
+		kit.mock.ExpectQuery(`WHERE au.name ILIKE \$1 ORDER BY a.created_at ASC, a.id ASC LIMIT 500`).
+			WithArgs("%jane%").
+			WillReturnRows(first)
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+		kit.mock.ExpectQuery(`WHERE au.name ILIKE \$1 AND \(a.created_at, a.id\) > \(\$2, \$3\) ORDER BY`).
+			WithArgs("%jane%", lastCreated, lastID).
+			WillReturnRows(sqlmock.NewRows(articleColumns).
+				AddRow(articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")...))
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
+		count := 0
+		err := repo.Stream(ctx, model.ArticleQuery{Author: "jane"}, func(a *model.Article) error {
+			count++
+			require.NotNil(t, a.Tags)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, streamPageSize+1, count)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
+			WillReturnRows(sqlmock.NewRows(articleColumns))
+
+		err := repo.Stream(ctx, model.ArticleQuery{}, func(*model.Article) error {
+			t.Fatal("no article expected")
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("callback error stops", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
+			WillReturnRows(sqlmock.NewRows(articleColumns).
+				AddRow(articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")...).
+				AddRow(articleRow(uuid.New(), uuid.New(), "Author", "Title", "Body")...))
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
+		calls := 0
+		err := repo.Stream(ctx, model.ArticleQuery{}, func(*model.Article) error {
+			calls++
+			return errors.New("write failed")
+		})
+		require.EqualError(t, err, "write failed")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id").
+			WillReturnError(errors.New("db error"))
+
+		err := repo.Stream(ctx, model.ArticleQuery{}, func(*model.Article) error { return nil })
+		require.Error(t, err)
+	})
+}
+
+func TestArticleRepository_StreamSitemap(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		updated := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+		kit.mock.ExpectQuery("SELECT id, slug, updated_at FROM articles").
+			WithArgs(100, 50).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "updated_at"}).
+				AddRow(uuid.New(), "first", updated).
+				AddRow(uuid.New(), "second", updated))
+
+		var slugs []string
+		err := repo.StreamSitemap(ctx, 100, 50, func(a *model.Article) error {
+			slugs = append(slugs, a.Slug)
+			assert.Equal(t, updated, a.UpdatedAt)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, slugs)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT id, slug, updated_at FROM articles").
+			WillReturnError(errors.New("db error"))
+
+		require.Error(t, repo.StreamSitemap(ctx, 0, 50, func(*model.Article) error { return nil }))
+	})
+}
+
+func TestArticleRepository_Count(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM articles").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 42, count)
+
+	kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM articles").
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.Count(ctx)
+	require.Error(t, err)
+}
+
+func TestArticleRepository_FindBySlug(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("found with tags", func(t *testing.T) {
+		id := uuid.New()
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id.*WHERE a.slug = \\$1").
+			WithArgs("hello-world").
+			WillReturnRows(sqlmock.NewRows(articleColumns).AddRow(articleRow(id, uuid.New(), "John", "Hello World", "Body")...))
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}).AddRow(id, "go"))
+
+		res, err := repo.FindBySlug(ctx, "hello-world")
+		require.NoError(t, err)
+		require.Equal(t, id, res.ID)
+		require.Equal(t, []string{"go"}, res.Tags)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id.*WHERE a.slug = \\$1").
+			WithArgs("missing").
+			WillReturnRows(sqlmock.NewRows(articleColumns))
+
+		res, err := repo.FindBySlug(ctx, "missing")
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+
+	t.Run("redirect from history", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT a.slug\\s+FROM article_slug_history").
+			WithArgs("old-title").
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("new-title"))
+
+		res, err := repo.FindSlugRedirect(ctx, "old-title")
+		require.NoError(t, err)
+		require.Equal(t, "new-title", res)
+	})
+
+	t.Run("no redirect", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT a.slug\\s+FROM article_slug_history").
+			WithArgs("unknown").
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+
+		res, err := repo.FindSlugRedirect(ctx, "unknown")
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+}
+
+func TestArticleRepository_FindByIDs(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("keeps the order of ids", func(t *testing.T) {
+		first, second, missing := uuid.New(), uuid.New(), uuid.New()
+		kit.mock.ExpectQuery("SELECT a.id, a.author_id.*WHERE a.id = ANY\\(\\$1\\)").
+			WillReturnRows(sqlmock.NewRows(articleColumns).
+				AddRow(articleRow(second, uuid.New(), "John", "Second", "Body")...).
+				AddRow(articleRow(first, uuid.New(), "John", "First", "Body")...))
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}).AddRow(first, "go"))
+
+		res, err := repo.FindByIDs(ctx, []uuid.UUID{first, missing, second}, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, first, res[0].ID)
+		assert.Equal(t, []string{"go"}, res[0].Tags)
+		assert.Equal(t, second, res[1].ID)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		res, err := repo.FindByIDs(ctx, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		kit.mock.ExpectQuery("WHERE a.id = ANY").WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindByIDs(ctx, []uuid.UUID{uuid.New()}, nil)
+		assert.EqualError(t, err, "db error")
+		assert.Nil(t, res)
+	})
+}
+
+func TestArticleRepository_FindTranslations(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	translationID := uuid.New()
+
+	t.Run("every language", func(t *testing.T) {
+		indonesianID := uuid.New()
+		kit.mock.ExpectQuery(`SELECT id, language, title, slug\s+FROM articles\s+WHERE translation_id = \$1\s+ORDER BY language`).
+			WithArgs(translationID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "language", "title", "slug"}).
+				AddRow(translationID, "en", "Hello", "hello").
+				AddRow(indonesianID, "id", "Halo", "halo"))
+
+		res, err := repo.FindTranslations(ctx, translationID)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, model.ArticleTranslation{ID: indonesianID, Language: "id", Title: "Halo", Slug: "halo"}, *res[1])
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		kit.mock.ExpectQuery("WHERE translation_id = \\$1").WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindTranslations(ctx, translationID)
+		assert.EqualError(t, err, "db error")
+		assert.Nil(t, res)
+	})
+}
+
+func TestArticleRepository_FindSlugs(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	kit.mock.ExpectQuery("SELECT slug FROM articles.*UNION.*SELECT slug FROM article_slug_history").
+		WithArgs("hello", uuid.Nil).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("hello").AddRow("hello-2"))
+
+	res, err := repo.FindSlugs(ctx, "hello", uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"hello", "hello-2"}, res)
+
+	kit.mock.ExpectQuery("SELECT slug FROM articles").
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("hello").RowError(0, errors.New("connection reset")))
+
+	_, err = repo.FindSlugs(ctx, "hello", uuid.Nil)
+	require.EqualError(t, err, "connection reset")
+}
+
+func TestArticleRepository_FindRelated(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	id := uuid.New()
+
+	relatedRow := func(title string) []driver.Value {
+		row := articleRow(uuid.New(), uuid.New(), "Author", title, "Body")
+		return append(row[:5:5], row[7:]...)
+	}
+	relatedColumnNames := append(articleColumns[:5:5], articleColumns[7:]...)
+
+	t.Run("ranks, caches and drops the cache on writes", func(t *testing.T) {
+		kit.mock.ExpectQuery(`WITH target AS .*tsvector_to_array\(to_tsvector\(article_search_config\(t.language\), t.title\)\).*WHERE a.translation_id <> target.translation_id AND a.language = target.language AND .* JOIN related r ON r.id = a.id\s+ORDER BY r.score DESC, a.created_at DESC\s+LIMIT \$5`).
+			WithArgs(id, relatedTagWeight, relatedAuthorWeight, relatedTextWeight, 5).
+			WillReturnRows(sqlmock.NewRows(relatedColumnNames).AddRow(relatedRow("Closest")...).AddRow(relatedRow("Close")...))
+		kit.mock.ExpectQuery("SELECT at.article_id, t.slug").
+			WillReturnRows(sqlmock.NewRows([]string{"article_id", "slug"}))
+
+		res, err := repo.FindRelated(ctx, id, 5)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, "Closest", res[0].Title)
+		assert.Empty(t, res[0].Body)
+
+		// Served from the cache.
+		res, err = repo.FindRelated(ctx, id, 5)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+
+		article := &model.Article{ID: id, Title: "Title", Slug: "title", Body: "Body"}
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery("SELECT slug FROM articles WHERE id = \\$1 FOR UPDATE").
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("title"))
+		kit.mock.ExpectQuery("UPDATE articles").
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+		kit.mock.ExpectExec("DELETE FROM article_tags WHERE article_id = \\$1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		kit.mock.ExpectCommit()
+
+		_, err = repo.Update(ctx, article)
+		require.NoError(t, err)
+
+		var cached []*model.Article
+		require.ErrorIs(t, kit.cache.Get(ctx, relatedCacheKey(id, 5), &cached), cache.ErrCacheMiss)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		kit.mock.ExpectQuery("WITH target AS").WillReturnError(errors.New("db error"))
+
+		_, err := repo.FindRelated(ctx, uuid.New(), 5)
+		require.Error(t, err)
+	})
+}
+
+func TestArticleRepository_Update(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("slug change is kept in history", func(t *testing.T) {
+		article := &model.Article{ID: uuid.New(), Title: "New Title", Slug: "new-title", Body: "Body"}
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery("SELECT slug FROM articles WHERE id = \\$1 FOR UPDATE").
+			WithArgs(article.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("old-title"))
+		kit.mock.ExpectQuery("UPDATE articles").
+			WithArgs(article.ID, article.Title, article.Slug, article.Body, article.BodyHTML, article.Excerpt, article.WordCount, article.ReadingTime, article.CategoryID).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+		kit.mock.ExpectExec("INSERT INTO article_slug_history").
+			WithArgs("old-title", article.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		kit.mock.ExpectExec("DELETE FROM article_slug_history WHERE slug = \\$1").
+			WithArgs("new-title").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		kit.mock.ExpectExec("DELETE FROM article_tags WHERE article_id = \\$1").
+			WithArgs(article.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		kit.mock.ExpectCommit()
+
+		res, err := repo.Update(ctx, article)
+		require.NoError(t, err)
+		require.Equal(t, "new-title", res.Slug)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("same slug skips history", func(t *testing.T) {
+		article := &model.Article{ID: uuid.New(), Title: "Title", Slug: "title", Body: "Body"}
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery("SELECT slug FROM articles WHERE id = \\$1 FOR UPDATE").
+			WithArgs(article.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("title"))
+		kit.mock.ExpectQuery("UPDATE articles").
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+		kit.mock.ExpectExec("DELETE FROM article_tags WHERE article_id = \\$1").
+			WithArgs(article.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		kit.mock.ExpectCommit()
+
+		_, err := repo.Update(ctx, article)
+		require.NoError(t, err)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		article := &model.Article{ID: uuid.New(), Title: "Title", Slug: "title", Body: "Body"}
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery("SELECT slug FROM articles WHERE id = \\$1 FOR UPDATE").
+			WithArgs(article.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("title"))
+		kit.mock.ExpectQuery("UPDATE articles").
+			WillReturnError(errors.New("db error"))
+		kit.mock.ExpectRollback()
+
+		_, err := repo.Update(ctx, article)
+		require.Error(t, err)
+	})
+}
+
+func TestArticleRepository_BulkCreate(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	createdAt := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	newArticles := func() []*model.Article {
+		return []*model.Article{
+			{ID: uuid.New(), AuthorID: uuid.New(), Title: "First", Slug: "first", Body: "Body", CreatedAt: createdAt, Tags: []string{"go"}},
+			{ID: uuid.New(), AuthorID: uuid.New(), Title: "Second", Slug: "second", Body: "Body", CreatedAt: createdAt, Tags: []string{"go", "sql"}},
+		}
+	}
+
+	t.Run("success with tags", func(t *testing.T) {
+		articles := newArticles()
+		goID, sqlID := uuid.New(), uuid.New()
+		kit.cache.Set(ctx, articleListCachePrefix(), "cached", time.Minute)
+
+		kit.mock.ExpectBegin()
+		copyArticles := kit.mock.ExpectPrepare(`COPY "articles"`)
+		for _, a := range articles {
+			copyArticles.ExpectExec().
+				WithArgs(a.ID, a.AuthorID, a.Title, a.Slug, a.Body, a.BodyHTML, a.Excerpt, a.WordCount, a.ReadingTime, a.CategoryID, "en", a.ID, createdAt, createdAt).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		copyArticles.ExpectExec().WithoutArgs().WillReturnResult(sqlmock.NewResult(0, 0))
+		kit.mock.ExpectQuery("INSERT INTO tags").
+			WithArgs(sqlmock.AnyArg(), "go").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(goID))
+		kit.mock.ExpectQuery("INSERT INTO tags").
+			WithArgs(sqlmock.AnyArg(), "sql").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(sqlID))
+		copyTags := kit.mock.ExpectPrepare(`COPY "article_tags"`)
+		copyTags.ExpectExec().WithArgs(articles[0].ID, goID).WillReturnResult(sqlmock.NewResult(0, 1))
+		copyTags.ExpectExec().WithArgs(articles[1].ID, goID).WillReturnResult(sqlmock.NewResult(0, 1))
+		copyTags.ExpectExec().WithArgs(articles[1].ID, sqlID).WillReturnResult(sqlmock.NewResult(0, 1))
+		copyTags.ExpectExec().WithoutArgs().WillReturnResult(sqlmock.NewResult(0, 0))
+		kit.mock.ExpectCommit()
+
+		err := repo.BulkCreate(ctx, articles)
+		require.NoError(t, err)
+		require.Equal(t, createdAt, articles[0].UpdatedAt)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+
+		var cached string
+		require.ErrorIs(t, kit.cache.Get(ctx, articleListCachePrefix(), &cached), cache.ErrCacheMiss)
+	})
+
+	t.Run("copy error rolls back", func(t *testing.T) {
+		articles := newArticles()
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectPrepare(`COPY "articles"`).
+			ExpectExec().
+			WillReturnError(errors.New("copy failed"))
+		kit.mock.ExpectRollback()
+
+		err := repo.BulkCreate(ctx, articles)
+		require.EqualError(t, err, "copy failed")
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+}
+
+func TestArticleRepository_DeleteAll(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewArticleRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		kit.cache.Set(ctx, tagCountsCacheKey(), "cached", time.Minute)
+		kit.mock.ExpectExec("TRUNCATE articles CASCADE").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.NoError(t, repo.DeleteAll(ctx))
+
+		var cached string
+		require.ErrorIs(t, kit.cache.Get(ctx, tagCountsCacheKey(), &cached), cache.ErrCacheMiss)
+	})
+
+	t.Run("truncate error", func(t *testing.T) {
+		kit.mock.ExpectExec("TRUNCATE articles CASCADE").WillReturnError(errors.New("db error"))
+
+		require.Error(t, repo.DeleteAll(ctx))
+	})
+}
@@ -8,9 +8,11 @@ import (
 
 	"github.com/bagasss3/go-article/internal/config"
 	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
 	"github.com/bagasss3/go-article/pkg/model"
 	"github.com/google/uuid"
-	log "github.com/sirupsen/logrus"
+	"github.com/lib/pq"
 )
 
 type authorRepository struct {
@@ -26,6 +28,8 @@ func NewAuthorRepository(db *sql.DB, cache cache.Cache) model.AuthorRepository {
 }
 
 func (r *authorRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Author, error) {
+	log := logger.FromContext(ctx)
+
 	var author model.Author
 
 	key := fmt.Sprintf("%s:%s", model.AuthorKey, id.String())
@@ -33,6 +37,8 @@ func (r *authorRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.A
 		return &author, nil
 	}
 
+	defer metrics.ObserveQuery("author", "FindByID")()
+
 	query := `SELECT id, name FROM authors WHERE id = $1`
 	err := r.db.QueryRowContext(ctx, query, id).Scan(&author.ID, &author.Name)
 	if err != nil {
@@ -51,7 +57,93 @@ func (r *authorRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.A
 	return &author, nil
 }
 
+func (r *authorRepository) FindByName(ctx context.Context, name string) (*model.Author, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("author", "FindByName")()
+
+	// Names are not unique; the oldest row wins so repeated lookups agree.
+	var author model.Author
+	query := `SELECT id, name FROM authors WHERE name = $1 ORDER BY id LIMIT 1`
+	err := r.db.QueryRowContext(ctx, query, name).Scan(&author.ID, &author.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error(err)
+		return nil, err
+	}
+
+	return &author, nil
+}
+
+func (r *authorRepository) StreamSitemap(ctx context.Context, offset, limit int, fn func(*model.SitemapAuthor) error) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("author", "StreamSitemap")()
+
+	query := `
+		SELECT au.id, MAX(a.updated_at)
+		FROM authors au
+		LEFT JOIN articles a ON a.author_id = au.id
+		GROUP BY au.id
+		ORDER BY au.id
+		OFFSET $1 LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, offset, limit)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			updatedAt sql.NullTime
+		)
+		if err := rows.Scan(&id, &updatedAt); err != nil {
+			log.Error(err)
+			return err
+		}
+
+		author := &model.SitemapAuthor{ID: id.String()}
+		if updatedAt.Valid {
+			author.UpdatedAt = &updatedAt.Time
+		}
+		if err := fn(author); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *authorRepository) Count(ctx context.Context) (int, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("author", "Count")()
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM authors`).Scan(&count); err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *authorRepository) Create(ctx context.Context, author *model.Author) (*model.Author, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("author", "Create")()
+
 	author.ID = uuid.New()
 
 	query := `INSERT INTO authors (id, name) VALUES ($1, $2)`
@@ -61,5 +153,74 @@ func (r *authorRepository) Create(ctx context.Context, author *model.Author) (*m
 		return nil, err
 	}
 
+	deleteSitemapCache(ctx, r.cache)
+
 	return author, nil
 }
+
+func (r *authorRepository) BulkCreate(ctx context.Context, authors []*model.Author) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("author", "BulkCreate")()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("authors", "id", "name"))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer stmt.Close()
+
+	for _, author := range authors {
+		if author.ID == uuid.Nil {
+			author.ID = uuid.New()
+		}
+		if _, err := stmt.ExecContext(ctx, author.ID, author.Name); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	// An Exec without arguments flushes the COPY buffer.
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	deleteSitemapCache(ctx, r.cache)
+
+	return nil
+}
+
+func (r *authorRepository) DeleteAll(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("author", "DeleteAll")()
+
+	if _, err := r.db.ExecContext(ctx, `TRUNCATE authors CASCADE`); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := r.cache.DeleteByPrefix(ctx, model.AuthorKey+":"); err != nil {
+		log.Warn("failed to delete cache authors")
+	}
+	// The cascade removes every article as well.
+	deleteListingCache(ctx, r.cache)
+	if err := r.cache.Delete(ctx, tagCountsCacheKey()); err != nil {
+		log.Warn("failed to delete cache tags")
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/bagasss3/go-article/pkg/model"
@@ -97,6 +98,93 @@ func TestAuthorRepository_FindByID(t *testing.T) {
 	})
 }
 
+func TestAuthorRepository_FindByName(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewAuthorRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("found", func(t *testing.T) {
+		authorID := uuid.New()
+		kit.mock.ExpectQuery("SELECT id, name FROM authors WHERE name =").
+			WithArgs("Jane Doe").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(authorID, "Jane Doe"))
+
+		res, err := repo.FindByName(ctx, "Jane Doe")
+		require.NoError(t, err)
+		require.Equal(t, &model.Author{ID: authorID, Name: "Jane Doe"}, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT id, name FROM authors WHERE name =").
+			WithArgs("Nobody").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+		res, err := repo.FindByName(ctx, "Nobody")
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT id, name FROM authors WHERE name =").
+			WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindByName(ctx, "Jane Doe")
+		require.Error(t, err)
+		require.Nil(t, res)
+	})
+}
+
+func TestAuthorRepository_StreamSitemap(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewAuthorRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		updated := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+		withArticles, withoutArticles := uuid.New(), uuid.New()
+		kit.mock.ExpectQuery("SELECT au.id, MAX\\(a.updated_at\\)").
+			WithArgs(0, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "max"}).
+				AddRow(withArticles, updated).
+				AddRow(withoutArticles, nil))
+
+		var authors []*model.SitemapAuthor
+		err := repo.StreamSitemap(ctx, 0, 10, func(a *model.SitemapAuthor) error {
+			authors = append(authors, a)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*model.SitemapAuthor{
+			{ID: withArticles.String(), UpdatedAt: &updated},
+			{ID: withoutArticles.String()},
+		}, authors)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT au.id").WillReturnError(errors.New("db error"))
+
+		require.Error(t, repo.StreamSitemap(ctx, 0, 10, func(*model.SitemapAuthor) error { return nil }))
+	})
+}
+
+func TestAuthorRepository_Count(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewAuthorRepository(kit.db, kit.cache)
+
+	kit.mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM authors").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := repo.Count(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, 7, count)
+}
+
 func TestAuthorRepository_Create(t *testing.T) {
 	kit := initializeRepoTestKit(t)
 	defer kit.closer()
@@ -112,11 +200,17 @@ func TestAuthorRepository_Create(t *testing.T) {
 			WithArgs(sqlmock.AnyArg(), author.Name).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
+		sitemapKey := model.SitemapKey + ":index"
+		kit.cache.Set(ctx, sitemapKey, []byte("<urlset/>"), time.Minute)
+
 		res, err := repo.Create(ctx, author)
 		require.NoError(t, err)
 		require.NotNil(t, res)
 		require.Equal(t, author.Name, res.Name)
 		require.NotEqual(t, uuid.Nil, res.ID)
+
+		var cached []byte
+		require.Error(t, kit.cache.Get(ctx, sitemapKey, &cached))
 	})
 
 	t.Run("insert error", func(t *testing.T) {
@@ -129,3 +223,67 @@ func TestAuthorRepository_Create(t *testing.T) {
 		require.Nil(t, res)
 	})
 }
+
+func TestAuthorRepository_BulkCreate(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewAuthorRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		authors := []*model.Author{{ID: uuid.New(), Name: "John Doe"}, {Name: "Jane Doe"}}
+
+		kit.mock.ExpectBegin()
+		copyAuthors := kit.mock.ExpectPrepare(`COPY "authors"`)
+		copyAuthors.ExpectExec().WithArgs(authors[0].ID, "John Doe").WillReturnResult(sqlmock.NewResult(0, 1))
+		copyAuthors.ExpectExec().WithArgs(sqlmock.AnyArg(), "Jane Doe").WillReturnResult(sqlmock.NewResult(0, 1))
+		copyAuthors.ExpectExec().WithoutArgs().WillReturnResult(sqlmock.NewResult(0, 0))
+		kit.mock.ExpectCommit()
+
+		require.NoError(t, repo.BulkCreate(ctx, authors))
+		require.NotEqual(t, uuid.Nil, authors[1].ID)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("flush error rolls back", func(t *testing.T) {
+		authors := []*model.Author{{ID: uuid.New(), Name: "John Doe"}}
+
+		kit.mock.ExpectBegin()
+		copyAuthors := kit.mock.ExpectPrepare(`COPY "authors"`)
+		copyAuthors.ExpectExec().WithArgs(authors[0].ID, "John Doe").WillReturnResult(sqlmock.NewResult(0, 1))
+		copyAuthors.ExpectExec().WithoutArgs().WillReturnError(errors.New("duplicate key"))
+		kit.mock.ExpectRollback()
+
+		require.EqualError(t, repo.BulkCreate(ctx, authors), "duplicate key")
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuthorRepository_DeleteAll(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewAuthorRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("success drops cached authors and listings", func(t *testing.T) {
+		authorKey := model.AuthorKey + ":" + uuid.NewString()
+		kit.cache.Set(ctx, authorKey, model.Author{Name: "John Doe"}, time.Minute)
+		kit.cache.Set(ctx, articleListCachePrefix(), "cached", time.Minute)
+		kit.mock.ExpectExec("TRUNCATE authors CASCADE").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.NoError(t, repo.DeleteAll(ctx))
+
+		var author model.Author
+		require.Error(t, kit.cache.Get(ctx, authorKey, &author))
+		var cached string
+		require.Error(t, kit.cache.Get(ctx, articleListCachePrefix(), &cached))
+	})
+
+	t.Run("truncate error", func(t *testing.T) {
+		kit.mock.ExpectExec("TRUNCATE authors CASCADE").WillReturnError(errors.New("db error"))
+
+		require.Error(t, repo.DeleteAll(ctx))
+	})
+}
@@ -0,0 +1,511 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/lang/id"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/highlight/highlighter/html"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/google/uuid"
+)
+
+// bleveSearchIndex keeps the articles in an embedded Bleve index, which analyzes each
+// article as its language is written and ranks matches by BM25 rather than by
+// substring.
+type bleveSearchIndex struct {
+	path string
+
+	// mu guards index, which Reset replaces.
+	mu    sync.RWMutex
+	index bleve.Index
+}
+
+// bleveDocument is the indexed form of a model.SearchDocument.
+type bleveDocument struct {
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+	Language string `json:"language"`
+	// TitleSort and Author are lower case keywords, sorted by and matched by substring.
+	TitleSort string `json:"title_sort"`
+	Author    string `json:"author"`
+	AuthorID  string `json:"author_id"`
+	// AuthorFacet is "<author id>|<author name>", so the author facet carries names.
+	AuthorFacet string    `json:"author_facet"`
+	Categories  []string  `json:"categories"`
+	Tags        []string  `json:"tags"`
+	Month       string    `json:"month"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Type selects the document mapping, and so the analyzer, of the language of the
+// document.
+func (d bleveDocument) Type() string {
+	return d.Language
+}
+
+// bleveAnalyzers are the analyzers of the titles and bodies of the languages of
+// model.ArticleLanguages; other languages are analyzed by bleveDefaultAnalyzer. Bleve
+// has no Indonesian stemmer, so Indonesian only drops stop words.
+var bleveAnalyzers = map[string]string{
+	"en": en.AnalyzerName,
+	"id": "id",
+}
+
+const bleveDefaultAnalyzer = standard.Name
+
+// bleveAnalyzer returns the analyzer of language.
+func bleveAnalyzer(language string) string {
+	if analyzer, ok := bleveAnalyzers[language]; ok {
+		return analyzer
+	}
+	return bleveDefaultAnalyzer
+}
+
+// bleveOpenTimeout bounds the wait for the lock of an index held by another process.
+const bleveOpenTimeout = "1s"
+
+// bleveMonthTerms is the number of months counted before the newest are kept, since
+// Bleve keeps the largest counts.
+const bleveMonthTerms = 1200
+
+// bleveSortOrder maps the values of model.ArticleSorts to Bleve sort orders.
+var bleveSortOrder = map[string][]string{
+	"created_at":  {"created_at"},
+	"-created_at": {"-created_at"},
+	"title":       {"title_sort"},
+	"-title":      {"-title_sort"},
+	"updated_at":  {"updated_at"},
+	"-updated_at": {"-updated_at"},
+}
+
+// NewBleveSearchIndex opens the index at path, creating it when missing. An empty path
+// keeps the index in memory.
+func NewBleveSearchIndex(path string) (model.SearchIndex, error) {
+	index, err := openBleveIndex(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bleveSearchIndex{
+		path:  path,
+		index: index,
+	}, nil
+}
+
+func openBleveIndex(path string) (bleve.Index, error) {
+	if path == "" {
+		m, err := bleveMapping()
+		if err != nil {
+			return nil, err
+		}
+		return bleve.NewMemOnly(m)
+	}
+
+	index, err := bleve.OpenUsing(path, map[string]interface{}{"bolt_timeout": bleveOpenTimeout})
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		m, err := bleveMapping()
+		if err != nil {
+			return nil, err
+		}
+		return bleve.New(path, m)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open search index %s (is another process using it?): %w", path, err)
+	}
+	return index, nil
+}
+
+func bleveMapping() (mapping.IndexMapping, error) {
+	m := bleve.NewIndexMapping()
+	if err := m.AddCustomAnalyzer("lower_keyword", map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     single.Name,
+		"token_filters": []string{lowercase.Name},
+	}); err != nil {
+		return nil, err
+	}
+	if err := m.AddCustomAnalyzer("id", map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{lowercase.Name, id.StopName},
+	}); err != nil {
+		return nil, err
+	}
+
+	// Each language has a mapping of its own, which differ only in the analyzer of
+	// the title and body.
+	for language, analyzer := range bleveAnalyzers {
+		m.AddDocumentMapping(language, bleveDocumentMapping(analyzer))
+	}
+	m.DefaultMapping = bleveDocumentMapping(bleveDefaultAnalyzer)
+	m.DefaultAnalyzer = bleveDefaultAnalyzer
+	return m, nil
+}
+
+func bleveDocumentMapping(analyzer string) *mapping.DocumentMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = analyzer
+
+	keyword := bleve.NewKeywordFieldMapping()
+	keyword.Store = false
+	keyword.IncludeInAll = false
+	keyword.IncludeTermVectors = false
+
+	lower := bleve.NewKeywordFieldMapping()
+	lower.Analyzer = "lower_keyword"
+	lower.Store = false
+	lower.IncludeInAll = false
+	lower.IncludeTermVectors = false
+
+	date := bleve.NewDateTimeFieldMapping()
+	date.Store = false
+	date.IncludeInAll = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt("title", text)
+	doc.AddFieldMappingsAt("body", text)
+	doc.AddFieldMappingsAt("language", keyword)
+	doc.AddFieldMappingsAt("title_sort", lower)
+	doc.AddFieldMappingsAt("author", lower)
+	doc.AddFieldMappingsAt("author_id", keyword)
+	doc.AddFieldMappingsAt("author_facet", keyword)
+	doc.AddFieldMappingsAt("categories", keyword)
+	doc.AddFieldMappingsAt("tags", keyword)
+	doc.AddFieldMappingsAt("month", keyword)
+	doc.AddFieldMappingsAt("created_at", date)
+	doc.AddFieldMappingsAt("updated_at", date)
+	return doc
+}
+
+func (i *bleveSearchIndex) Index(ctx context.Context, docs ...*model.SearchDocument) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("search_index", "Index")()
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	batch := i.index.NewBatch()
+	for _, doc := range docs {
+		err := batch.Index(doc.ID.String(), bleveDocument{
+			Title:       doc.Title,
+			Body:        doc.Body,
+			Language:    doc.Language,
+			TitleSort:   doc.Title,
+			Author:      doc.Author,
+			AuthorID:    doc.AuthorID.String(),
+			AuthorFacet: doc.AuthorID.String() + "|" + doc.Author,
+			Categories:  doc.Categories,
+			Tags:        doc.Tags,
+			Month:       doc.CreatedAt.Format("2006-01"),
+			CreatedAt:   doc.CreatedAt,
+			UpdatedAt:   doc.UpdatedAt,
+		})
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if err := i.index.Batch(batch); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (i *bleveSearchIndex) Delete(ctx context.Context, ids ...uuid.UUID) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("search_index", "Delete")()
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	batch := i.index.NewBatch()
+	for _, id := range ids {
+		batch.Delete(id.String())
+	}
+
+	if err := i.index.Batch(batch); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (i *bleveSearchIndex) Search(ctx context.Context, q model.SearchIndexQuery) (*model.SearchResult, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("search_index", "Search")()
+
+	searchQuery, err := bleveQuery(q.Filter)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	req := bleve.NewSearchRequestOptions(searchQuery, max(q.Limit, 0), q.Offset, false)
+	order, ok := bleveSortOrder[q.Filter.Sort]
+	if !ok {
+		order = bleveSortOrder["-created_at"]
+	}
+	if q.Filter.Sort == "relevance" && q.Filter.Query != "" {
+		order = []string{"-_score", "-created_at"}
+	}
+	req.SortBy(order)
+
+	if q.Highlight && q.Filter.Query != "" {
+		req.Highlight = bleve.NewHighlightWithStyle(html.Name)
+		req.Highlight.AddField("title")
+		req.Highlight.AddField("body")
+	}
+	if q.FacetLimit > 0 {
+		req.AddFacet("authors", bleve.NewFacetRequest("author_facet", q.FacetLimit))
+		req.AddFacet("tags", bleve.NewFacetRequest("tags", q.FacetLimit))
+		req.AddFacet("months", bleve.NewFacetRequest("month", bleveMonthTerms))
+	}
+
+	i.mu.RLock()
+	res, err := i.index.SearchInContext(ctx, req)
+	i.mu.RUnlock()
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	result := &model.SearchResult{
+		Hits:  make([]*model.SearchHit, 0, len(res.Hits)),
+		Total: int(res.Total),
+	}
+	for _, match := range res.Hits {
+		id, err := uuid.Parse(match.ID)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		hit := &model.SearchHit{ID: id}
+		if len(match.Fragments) > 0 {
+			hit.Highlights = map[string][]string(match.Fragments)
+		}
+		result.Hits = append(result.Hits, hit)
+	}
+
+	if q.FacetLimit > 0 {
+		result.Facets = &model.Facets{
+			Authors: bleveFacet(res.Facets["authors"], true),
+			Tags:    bleveFacet(res.Facets["tags"], false),
+			Months:  bleveFacet(res.Facets["months"], false),
+		}
+		// Unlike the other facets, months are newest first.
+		slices.SortFunc(result.Facets.Months, func(a, b *model.FacetCount) int {
+			return strings.Compare(b.Value, a.Value)
+		})
+		result.Facets.Months = result.Facets.Months[:min(len(result.Facets.Months), q.FacetLimit)]
+	}
+
+	return result, nil
+}
+
+// bleveQuery turns the filters of an article listing into a query matching the same
+// articles, the text query aside, which matches words rather than substrings.
+func bleveQuery(filter model.ArticleQuery) (query.Query, error) {
+	if filter.BookmarkedBy != "" {
+		return nil, errors.New("the search index does not know bookmarks")
+	}
+
+	must := []query.Query{}
+	if filter.Query != "" {
+		// The text query is analyzed as each language is, and matched against the
+		// articles in that language.
+		if filter.Lang != "" {
+			must = append(must, bleveTextQuery(filter.Query, bleveAnalyzer(filter.Lang)))
+		} else {
+			languages := slices.Sorted(maps.Keys(bleveAnalyzers))
+			variants := make([]query.Query, 0, len(languages)+1)
+			for _, language := range languages {
+				variants = append(variants, bleve.NewConjunctionQuery(
+					termsQuery("language", []string{language}, false),
+					bleveTextQuery(filter.Query, bleveAnalyzers[language]),
+				))
+			}
+			variants = append(variants, query.NewBooleanQuery(
+				[]query.Query{bleveTextQuery(filter.Query, bleveDefaultAnalyzer)},
+				nil,
+				[]query.Query{termsQuery("language", languages, false)},
+			))
+			must = append(must, bleve.NewDisjunctionQuery(variants...))
+		}
+	}
+	if filter.Lang != "" {
+		must = append(must, termsQuery("language", []string{filter.Lang}, false))
+	}
+	if filter.Author != "" {
+		// A substring match like ILIKE in Postgres, with the name quoted so that it
+		// matches literally. The pattern runs over the term dictionary of the author
+		// field, which holds one term per author name.
+		author := bleve.NewRegexpQuery(".*" + regexp.QuoteMeta(strings.ToLower(filter.Author)) + ".*")
+		author.SetField("author")
+		must = append(must, author)
+	}
+	if len(filter.AuthorIDs) > 0 {
+		must = append(must, termsQuery("author_id", filter.AuthorIDs, false))
+	}
+	if filter.CreatedFrom != "" || filter.CreatedTo != "" {
+		var from, to time.Time
+		if filter.CreatedFrom != "" {
+			t, err := helper.ParseTime(filter.CreatedFrom)
+			if err != nil {
+				return nil, err
+			}
+			from = t
+		}
+		if filter.CreatedTo != "" {
+			t, err := helper.ParseTime(filter.CreatedTo)
+			if err != nil {
+				return nil, err
+			}
+			to = t
+		}
+		inclusive := true
+		dates := bleve.NewDateRangeInclusiveQuery(from, to, &inclusive, &inclusive)
+		dates.SetField("created_at")
+		must = append(must, dates)
+	}
+	if filter.Category != "" {
+		must = append(must, termsQuery("categories", []string{filter.Category}, false))
+	}
+	if filter.Tag != "" {
+		must = append(must, termsQuery("tags", []string{filter.Tag}, false))
+	}
+	if len(filter.TagsAny) > 0 {
+		must = append(must, termsQuery("tags", filter.TagsAny, false))
+	}
+	if len(filter.TagsAll) > 0 {
+		must = append(must, termsQuery("tags", filter.TagsAll, true))
+	}
+
+	if len(must) == 0 {
+		must = append(must, bleve.NewMatchAllQuery())
+	}
+	return query.NewBooleanQuery(must, nil, nil), nil
+}
+
+// bleveTextQuery matches text, analyzed by analyzer. Every word must appear in the
+// title or body; matches in the title count more, and matching each field also
+// locates the highlights.
+func bleveTextQuery(text, analyzer string) query.Query {
+	all := bleve.NewMatchQuery(text)
+	all.SetField("_all")
+	all.Analyzer = analyzer
+	all.SetOperator(query.MatchQueryOperatorAnd)
+
+	title := bleve.NewMatchQuery(text)
+	title.SetField("title")
+	title.Analyzer = analyzer
+	title.SetBoost(2)
+	body := bleve.NewMatchQuery(text)
+	body.SetField("body")
+	body.Analyzer = analyzer
+
+	return query.NewBooleanQuery([]query.Query{all}, []query.Query{title, body}, nil)
+}
+
+// termsQuery matches documents with any, or all, of terms in field.
+func termsQuery(field string, terms []string, all bool) query.Query {
+	queries := make([]query.Query, 0, len(terms))
+	for _, term := range terms {
+		q := bleve.NewTermQuery(term)
+		q.SetField(field)
+		queries = append(queries, q)
+	}
+	if all {
+		return bleve.NewConjunctionQuery(queries...)
+	}
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+// bleveFacet converts the terms of a facet, splitting "<value>|<label>" terms when
+// labeled. Bleve breaks count ties by term, so labeled counts are re-sorted to
+// break them by label as the Postgres index does.
+func bleveFacet(facet *search.FacetResult, labeled bool) []*model.FacetCount {
+	counts := []*model.FacetCount{}
+	if facet == nil {
+		return counts
+	}
+	for _, term := range facet.Terms.Terms() {
+		count := &model.FacetCount{Value: term.Term, Count: term.Count}
+		if labeled {
+			count.Value, count.Label, _ = strings.Cut(term.Term, "|")
+		}
+		counts = append(counts, count)
+	}
+	if labeled {
+		slices.SortStableFunc(counts, func(a, b *model.FacetCount) int {
+			if a.Count != b.Count {
+				return b.Count - a.Count
+			}
+			return strings.Compare(a.Label, b.Label)
+		})
+	}
+	return counts
+}
+
+func (i *bleveSearchIndex) Reset(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if err := i.index.Close(); err != nil {
+		log.Error(err)
+		return err
+	}
+	if i.path != "" {
+		if err := os.RemoveAll(i.path); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	index, err := openBleveIndex(i.path)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	i.index = index
+
+	return nil
+}
+
+func (i *bleveSearchIndex) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.index.Close()
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBleveSearchIndex_Languages(t *testing.T) {
+	ctx := context.TODO()
+
+	english := &model.SearchDocument{ID: uuid.New(), Language: "en", Title: "Reading books", Body: "Good books are worth it."}
+	indonesian := &model.SearchDocument{ID: uuid.New(), Language: "id", Title: "Membaca buku", Body: "Buku yang baik layak dibaca."}
+	french := &model.SearchDocument{ID: uuid.New(), Language: "fr", Title: "Les livres", Body: "Lire des livres."}
+
+	index, err := NewBleveSearchIndex("")
+	require.NoError(t, err)
+	defer index.Close()
+	require.NoError(t, index.Index(ctx, english, indonesian, french))
+
+	search := func(filter model.ArticleQuery) []uuid.UUID {
+		res, err := index.Search(ctx, model.SearchIndexQuery{Filter: filter, Limit: 10})
+		require.NoError(t, err)
+		ids := []uuid.UUID{}
+		for _, hit := range res.Hits {
+			ids = append(ids, hit.ID)
+		}
+		return ids
+	}
+
+	// English is stemmed, Indonesian drops its stop words and other languages match
+	// the words as written.
+	assert.Equal(t, []uuid.UUID{english.ID}, search(model.ArticleQuery{Query: "book"}))
+	assert.Equal(t, []uuid.UUID{indonesian.ID}, search(model.ArticleQuery{Query: "buku"}))
+	assert.Empty(t, search(model.ArticleQuery{Query: "yang", Lang: "id"}))
+	assert.Equal(t, []uuid.UUID{french.ID}, search(model.ArticleQuery{Query: "livres"}))
+
+	assert.Empty(t, search(model.ArticleQuery{Query: "buku", Lang: "en"}))
+	assert.Equal(t, []uuid.UUID{indonesian.ID}, search(model.ArticleQuery{Lang: "id"}))
+}
+
+func TestBleveSearchIndex(t *testing.T) {
+	ctx := context.TODO()
+
+	alice, bob := uuid.New(), uuid.New()
+	docs := []*model.SearchDocument{
+		{
+			ID: uuid.New(), AuthorID: alice, Author: "Alice Cooper", Language: "en", Title: "Running Postgres in production",
+			Body: "Postgres runs well once vacuuming is tuned.", Categories: []string{"backend", "databases"},
+			Tags: []string{"postgres", "ops"}, CreatedAt: time.Date(2025, 7, 3, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			ID: uuid.New(), AuthorID: bob, Author: "Bob Marley", Language: "en", Title: "Go generics",
+			Body: "Generic code that runs on postgres and elsewhere.", Categories: []string{"backend"},
+			Tags: []string{"go"}, CreatedAt: time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			ID: uuid.New(), AuthorID: alice, Author: "Alice Cooper", Language: "en", Title: "Gardening",
+			Body: "Tomatoes need sun.", Tags: []string{"garden"}, CreatedAt: time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	setup := func(t *testing.T) model.SearchIndex {
+		index, err := NewBleveSearchIndex("")
+		require.NoError(t, err)
+		t.Cleanup(func() { index.Close() })
+		require.NoError(t, index.Index(ctx, docs...))
+		return index
+	}
+
+	ids := func(res *model.SearchResult) []uuid.UUID {
+		var ids []uuid.UUID
+		for _, hit := range res.Hits {
+			ids = append(ids, hit.ID)
+		}
+		return ids
+	}
+
+	t.Run("stemmed words, newest first, with highlights and facets", func(t *testing.T) {
+		index := setup(t)
+
+		res, err := index.Search(ctx, model.SearchIndexQuery{
+			Filter: model.ArticleQuery{Query: "running postgres"}, Limit: 10, FacetLimit: 10, Highlight: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Total)
+		assert.Equal(t, []uuid.UUID{docs[1].ID, docs[0].ID}, ids(res))
+		assert.Contains(t, res.Hits[1].Highlights["title"][0], "<mark>Running</mark>")
+
+		assert.Equal(t, []*model.FacetCount{
+			{Value: alice.String(), Label: "Alice Cooper", Count: 1},
+			{Value: bob.String(), Label: "Bob Marley", Count: 1},
+		}, res.Facets.Authors)
+		assert.Len(t, res.Facets.Tags, 3)
+		assert.Equal(t, []*model.FacetCount{{Value: "2025-08", Count: 1}, {Value: "2025-07", Count: 1}}, res.Facets.Months)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		index := setup(t)
+
+		for name, tc := range map[string]struct {
+			filter   model.ArticleQuery
+			expected []uuid.UUID
+		}{
+			"author name":    {model.ArticleQuery{Author: "cooper"}, []uuid.UUID{docs[2].ID, docs[0].ID}},
+			"author pattern": {model.ArticleQuery{Author: "b*"}, nil},
+			"author regexp":  {model.ArticleQuery{Author: "alice.cooper"}, nil},
+			"author ids":     {model.ArticleQuery{AuthorIDs: []string{bob.String()}}, []uuid.UUID{docs[1].ID}},
+			"category":       {model.ArticleQuery{Category: "databases"}, []uuid.UUID{docs[0].ID}},
+			"tag":            {model.ArticleQuery{Tag: "go"}, []uuid.UUID{docs[1].ID}},
+			"any tag":        {model.ArticleQuery{TagsAny: []string{"go", "garden"}}, []uuid.UUID{docs[2].ID, docs[1].ID}},
+			"all tags":       {model.ArticleQuery{TagsAll: []string{"postgres", "ops"}}, []uuid.UUID{docs[0].ID}},
+			"creation date":  {model.ArticleQuery{CreatedFrom: "2025-08-01T10:00", CreatedTo: "2025-08-10T00:00"}, []uuid.UUID{docs[1].ID}},
+			"title sort":     {model.ArticleQuery{Sort: "title"}, []uuid.UUID{docs[2].ID, docs[1].ID, docs[0].ID}},
+		} {
+			res, err := index.Search(ctx, model.SearchIndexQuery{Filter: tc.filter, Limit: 10})
+			require.NoError(t, err, name)
+			assert.Equal(t, tc.expected, ids(res), name)
+		}
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		index := setup(t)
+
+		res, err := index.Search(ctx, model.SearchIndexQuery{Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 3, res.Total)
+		assert.Equal(t, []uuid.UUID{docs[1].ID}, ids(res))
+	})
+
+	t.Run("bookmarks are not indexed", func(t *testing.T) {
+		index := setup(t)
+
+		_, err := index.Search(ctx, model.SearchIndexQuery{Filter: model.ArticleQuery{BookmarkedBy: "1"}})
+		require.Error(t, err)
+	})
+
+	t.Run("delete and reset", func(t *testing.T) {
+		index := setup(t)
+
+		require.NoError(t, index.Delete(ctx, docs[0].ID))
+		res, err := index.Search(ctx, model.SearchIndexQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Total)
+
+		require.NoError(t, index.Reset(ctx))
+		res, err = index.Search(ctx, model.SearchIndexQuery{})
+		require.NoError(t, err)
+		assert.Zero(t, res.Total)
+	})
+
+	t.Run("on disk", func(t *testing.T) {
+		path := t.TempDir() + "/search.bleve"
+
+		index, err := NewBleveSearchIndex(path)
+		require.NoError(t, err)
+		require.NoError(t, index.Index(ctx, docs...))
+		require.NoError(t, index.Reset(ctx))
+		require.NoError(t, index.Index(ctx, docs[0]))
+		require.NoError(t, index.Close())
+
+		index, err = NewBleveSearchIndex(path)
+		require.NoError(t, err)
+		defer index.Close()
+		res, err := index.Search(ctx, model.SearchIndexQuery{Filter: model.ArticleQuery{Query: "vacuum"}, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{docs[0].ID}, ids(res))
+	})
+}
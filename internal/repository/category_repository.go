@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+)
+
+type categoryRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewCategoryRepository(db *sql.DB, cache cache.Cache) model.CategoryRepository {
+	return &categoryRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func categoryListCacheKey() string {
+	return fmt.Sprintf("%s:all", model.CategoryKey)
+}
+
+func (r *categoryRepository) FindAll(ctx context.Context) ([]*model.Category, error) {
+	log := logger.FromContext(ctx)
+
+	key := categoryListCacheKey()
+
+	var cached []*model.Category
+	if err := r.cache.Get(ctx, key, &cached); err == nil {
+		return cached, nil
+	}
+
+	defer metrics.ObserveQuery("category", "FindAll")()
+
+	query := `SELECT id, parent_id, name, slug, created_at FROM categories ORDER BY name ASC`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*model.Category
+	for rows.Next() {
+		var c model.Category
+		if err := rows.Scan(&c.ID, &c.ParentID, &c.Name, &c.Slug, &c.CreatedAt); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		results = append(results, &c)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := r.cache.Set(ctx, key, results, config.RedisExpired()); err != nil {
+		log.Warn("failed to cache categories")
+	}
+
+	return results, nil
+}
+
+func (r *categoryRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Category, error) {
+	defer metrics.ObserveQuery("category", "FindByID")()
+
+	query := `SELECT id, parent_id, name, slug, created_at FROM categories WHERE id = $1`
+	return r.findOne(ctx, query, id)
+}
+
+func (r *categoryRepository) FindBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	defer metrics.ObserveQuery("category", "FindBySlug")()
+
+	query := `SELECT id, parent_id, name, slug, created_at FROM categories WHERE slug = $1`
+	return r.findOne(ctx, query, slug)
+}
+
+func (r *categoryRepository) findOne(ctx context.Context, query string, arg any) (*model.Category, error) {
+	log := logger.FromContext(ctx)
+
+	var c model.Category
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(&c.ID, &c.ParentID, &c.Name, &c.Slug, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error(err)
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (r *categoryRepository) FindAncestors(ctx context.Context, id uuid.UUID) ([]*model.Category, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("category", "FindAncestors")()
+
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, name, slug, created_at, 0 AS depth
+			FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id, c.parent_id, c.name, c.slug, c.created_at, an.depth + 1
+			FROM categories c
+			JOIN ancestors an ON c.id = an.parent_id
+		)
+		SELECT id, parent_id, name, slug, created_at
+		FROM ancestors
+		ORDER BY depth DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*model.Category
+	for rows.Next() {
+		var c model.Category
+		if err := rows.Scan(&c.ID, &c.ParentID, &c.Name, &c.Slug, &c.CreatedAt); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		results = append(results, &c)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *categoryRepository) HasChildren(ctx context.Context, id uuid.UUID) (bool, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("category", "HasChildren")()
+
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id = $1)`
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		log.Error(err)
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *model.Category) (*model.Category, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("category", "Create")()
+
+	category.ID = uuid.New()
+
+	query := `
+		INSERT INTO categories (id, parent_id, name, slug, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING created_at
+	`
+	err := r.db.QueryRowContext(ctx, query, category.ID, category.ParentID, category.Name, category.Slug).
+		Scan(&category.CreatedAt)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	r.invalidate(ctx)
+
+	return category, nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, category *model.Category) (*model.Category, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("category", "Update")()
+
+	query := `
+		UPDATE categories SET parent_id = $2, name = $3, slug = $4
+		WHERE id = $1
+		RETURNING created_at
+	`
+	err := r.db.QueryRowContext(ctx, query, category.ID, category.ParentID, category.Name, category.Slug).
+		Scan(&category.CreatedAt)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	r.invalidate(ctx)
+
+	return category, nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("category", "Delete")()
+
+	query := `DELETE FROM categories WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	r.invalidate(ctx)
+
+	// Articles of the deleted category lose their category_id.
+	deleteListingCache(ctx, r.cache)
+
+	return nil
+}
+
+func (r *categoryRepository) invalidate(ctx context.Context) {
+	log := logger.FromContext(ctx)
+
+	if err := r.cache.Delete(ctx, categoryListCacheKey()); err != nil {
+		log.Warn("failed to delete cache categories")
+	}
+}
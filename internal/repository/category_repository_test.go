@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var categoryColumns = []string{"id", "parent_id", "name", "slug", "created_at"}
+
+func TestCategoryRepository_FindAll(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCategoryRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("query error", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT id, parent_id, name, slug, created_at FROM categories").
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.FindAll(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("row iteration error is not cached", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT id, parent_id, name, slug, created_at FROM categories").
+			WillReturnRows(sqlmock.NewRows(categoryColumns).
+				AddRow(uuid.New(), nil, "Tech", "tech", time.Now()).
+				RowError(0, errors.New("connection reset")))
+
+		_, err := repo.FindAll(ctx)
+		require.EqualError(t, err, "connection reset")
+
+		var cached []*model.Category
+		require.Error(t, kit.cache.Get(ctx, categoryListCacheKey(), &cached))
+	})
+
+	t.Run("found from db and cached", func(t *testing.T) {
+		rootID := uuid.New()
+		rows := sqlmock.NewRows(categoryColumns).
+			AddRow(rootID, nil, "Tech", "tech", time.Now()).
+			AddRow(uuid.New(), rootID, "Go", "go", time.Now())
+
+		kit.mock.ExpectQuery("SELECT id, parent_id, name, slug, created_at FROM categories").
+			WillReturnRows(rows)
+
+		res, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.Nil(t, res[0].ParentID)
+		require.Equal(t, rootID, *res[1].ParentID)
+
+		var cached []*model.Category
+		require.NoError(t, kit.cache.Get(ctx, categoryListCacheKey(), &cached))
+		require.Len(t, cached, 2)
+	})
+
+	t.Run("found from cache", func(t *testing.T) {
+		res, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+}
+
+func TestCategoryRepository_FindBySlug(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCategoryRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("found", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT id, parent_id, name, slug, created_at FROM categories WHERE slug =").
+			WithArgs("go").
+			WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(uuid.New(), nil, "Go", "go", time.Now()))
+
+		res, err := repo.FindBySlug(ctx, "go")
+		require.NoError(t, err)
+		require.Equal(t, "Go", res.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT id, parent_id, name, slug, created_at FROM categories WHERE slug =").
+			WithArgs("missing").
+			WillReturnRows(sqlmock.NewRows(categoryColumns))
+
+		res, err := repo.FindBySlug(ctx, "missing")
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT id, parent_id, name, slug, created_at FROM categories WHERE slug =").
+			WithArgs("go").
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.FindBySlug(ctx, "go")
+		require.Error(t, err)
+	})
+}
+
+func TestCategoryRepository_FindAncestors(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCategoryRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	rootID, childID := uuid.New(), uuid.New()
+
+	kit.mock.ExpectQuery("WITH RECURSIVE ancestors").
+		WithArgs(childID).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).
+			AddRow(rootID, nil, "Tech", "tech", time.Now()).
+			AddRow(childID, rootID, "Go", "go", time.Now()))
+
+	res, err := repo.FindAncestors(ctx, childID)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, "tech", res[0].Slug)
+	require.Equal(t, "go", res[1].Slug)
+
+	kit.mock.ExpectQuery("WITH RECURSIVE ancestors").
+		WithArgs(childID).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).
+			AddRow(rootID, nil, "Tech", "tech", time.Now()).
+			RowError(0, errors.New("connection reset")))
+
+	_, err = repo.FindAncestors(ctx, childID)
+	require.EqualError(t, err, "connection reset")
+}
+
+func TestCategoryRepository_Create(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCategoryRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("success invalidates cache", func(t *testing.T) {
+		require.NoError(t, kit.cache.Set(ctx, categoryListCacheKey(), []*model.Category{}, time.Minute))
+
+		category := &model.Category{Name: "Tech", Slug: "tech"}
+		kit.mock.ExpectQuery("INSERT INTO categories").
+			WithArgs(sqlmock.AnyArg(), category.ParentID, "Tech", "tech").
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+
+		res, err := repo.Create(ctx, category)
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, res.ID)
+
+		var cached []*model.Category
+		require.Error(t, kit.cache.Get(ctx, categoryListCacheKey(), &cached))
+	})
+
+	t.Run("insert error", func(t *testing.T) {
+		kit.mock.ExpectQuery("INSERT INTO categories").
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.Create(ctx, &model.Category{Name: "Tech", Slug: "tech"})
+		require.Error(t, err)
+	})
+}
+
+func TestCategoryRepository_Update(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCategoryRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	parentID := uuid.New()
+	category := &model.Category{ID: uuid.New(), ParentID: &parentID, Name: "Go", Slug: "go"}
+
+	kit.mock.ExpectQuery("UPDATE categories SET parent_id").
+		WithArgs(category.ID, category.ParentID, "Go", "go").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+
+	res, err := repo.Update(ctx, category)
+	require.NoError(t, err)
+	require.Equal(t, parentID, *res.ParentID)
+}
+
+func TestCategoryRepository_Delete(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCategoryRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	id := uuid.New()
+
+	t.Run("has children", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		res, err := repo.HasChildren(ctx, id)
+		require.NoError(t, err)
+		require.True(t, res)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		kit.mock.ExpectExec("DELETE FROM categories WHERE id =").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.Delete(ctx, id))
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		kit.mock.ExpectExec("DELETE FROM categories WHERE id =").
+			WithArgs(id).
+			WillReturnError(errors.New("db error"))
+
+		require.Error(t, repo.Delete(ctx, id))
+	})
+}
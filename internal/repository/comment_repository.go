@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const commentColumns = `id, article_id, parent_id, user_id, body, status, depth, created_at, updated_at`
+
+type commentRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewCommentRepository(db *sql.DB, cache cache.Cache) model.CommentRepository {
+	return &commentRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func scanComment(row rowScanner) (*model.Comment, error) {
+	var c model.Comment
+	err := row.Scan(&c.ID, &c.ArticleID, &c.ParentID, &c.UserID, &c.Body, &c.Status, &c.Depth, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *commentRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Comment, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("comment", "FindByID")()
+
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE id = $1`
+	comment, err := scanComment(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error(err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (r *commentRepository) FindThreads(ctx context.Context, articleID uuid.UUID, after *model.CommentCursor, limit int) ([]*model.Comment, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("comment", "FindThreads")()
+
+	query := `
+		SELECT ` + commentColumns + `
+		FROM comments
+		WHERE article_id = $1 AND parent_id IS NULL AND status = $2
+	`
+	args := []any{articleID, model.CommentStatusApproved, limit}
+	if after != nil {
+		query += ` AND (created_at, id) > ($4, $5)`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += ` ORDER BY created_at ASC, id ASC LIMIT $3`
+
+	comments, err := r.query(ctx, query, args...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *commentRepository) FindReplies(ctx context.Context, rootIDs []uuid.UUID) ([]*model.Comment, error) {
+	log := logger.FromContext(ctx)
+
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	defer metrics.ObserveQuery("comment", "FindReplies")()
+
+	ids := make([]string, len(rootIDs))
+	for i, id := range rootIDs {
+		ids[i] = id.String()
+	}
+
+	// The walk only descends through approved comments, so a hidden reply hides its
+	// whole subthread.
+	query := `
+		WITH RECURSIVE thread AS (
+			SELECT ` + commentColumns + `
+			FROM comments
+			WHERE parent_id = ANY($1) AND status = $2
+			UNION ALL
+			SELECT c.id, c.article_id, c.parent_id, c.user_id, c.body, c.status, c.depth, c.created_at, c.updated_at
+			FROM comments c
+			JOIN thread ON c.parent_id = thread.id
+			WHERE c.status = $2
+		)
+		SELECT ` + commentColumns + ` FROM thread
+		ORDER BY created_at ASC, id ASC
+	`
+
+	comments, err := r.query(ctx, query, pq.Array(ids), model.CommentStatusApproved)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *commentRepository) query(ctx context.Context, query string, args ...any) ([]*model.Comment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*model.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *model.Comment) (*model.Comment, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("comment", "Create")()
+
+	comment.ID = uuid.New()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO comments (id, article_id, parent_id, user_id, body, status, depth, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, query,
+		comment.ID, comment.ArticleID, comment.ParentID, comment.UserID, comment.Body, comment.Status, comment.Depth,
+	).Scan(&comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := r.commit(ctx, tx, comment.ArticleID); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, comment *model.Comment) (*model.Comment, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("comment", "Update")()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE comments
+		SET body = $2, status = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, query, comment.ID, comment.Body, comment.Status).
+		Scan(&comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := r.commit(ctx, tx, comment.ArticleID); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (r *commentRepository) Delete(ctx context.Context, comment *model.Comment) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("comment", "Delete")()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	// The cascade takes the replies along.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM comments WHERE id = $1`, comment.ID); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := r.commit(ctx, tx, comment.ArticleID); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// commit recounts the approved comments of the article within tx before committing
+// it, then drops the cached listings that show the count.
+func (r *commentRepository) commit(ctx context.Context, tx *sql.Tx, articleID uuid.UUID) error {
+	query := `
+		UPDATE articles
+		SET comment_count = (SELECT COUNT(*) FROM comments WHERE article_id = $1 AND status = $2)
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, articleID, model.CommentStatusApproved); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	deleteListingCache(ctx, r.cache)
+
+	return nil
+}
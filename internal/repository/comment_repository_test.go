@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var commentRowColumns = []string{"id", "article_id", "parent_id", "user_id", "body", "status", "depth", "created_at", "updated_at"}
+
+func commentRow(id, articleID, parentID any, depth int) []driver.Value {
+	return []driver.Value{id, articleID, parentID, "user-1", "Body", model.CommentStatusApproved, depth, time.Now(), time.Now()}
+}
+
+func TestCommentRepository_FindByID(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCommentRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("found", func(t *testing.T) {
+		id, parentID := uuid.New(), uuid.New()
+		kit.mock.ExpectQuery("SELECT id, article_id, parent_id").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows(commentRowColumns).AddRow(commentRow(id, uuid.New(), parentID, 1)...))
+
+		comment, err := repo.FindByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, id, comment.ID)
+		assert.Equal(t, parentID, *comment.ParentID)
+		assert.Equal(t, 1, comment.Depth)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT id, article_id, parent_id").
+			WillReturnRows(sqlmock.NewRows(commentRowColumns))
+
+		comment, err := repo.FindByID(ctx, uuid.New())
+		require.NoError(t, err)
+		assert.Nil(t, comment)
+	})
+}
+
+func TestCommentRepository_FindThreads(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCommentRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	articleID := uuid.New()
+
+	t.Run("first page", func(t *testing.T) {
+		kit.mock.ExpectQuery(`WHERE article_id = \$1 AND parent_id IS NULL AND status = \$2\s+ORDER BY created_at ASC, id ASC LIMIT \$3`).
+			WithArgs(articleID, model.CommentStatusApproved, 11).
+			WillReturnRows(sqlmock.NewRows(commentRowColumns).AddRow(commentRow(uuid.New(), articleID, nil, 0)...))
+
+		comments, err := repo.FindThreads(ctx, articleID, nil, 11)
+		require.NoError(t, err)
+		require.Len(t, comments, 1)
+		assert.Nil(t, comments[0].ParentID)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("after cursor", func(t *testing.T) {
+		cursor := &model.CommentCursor{CreatedAt: time.Now(), ID: uuid.New()}
+		kit.mock.ExpectQuery(`AND \(created_at, id\) > \(\$4, \$5\) ORDER BY`).
+			WithArgs(articleID, model.CommentStatusApproved, 11, cursor.CreatedAt, cursor.ID).
+			WillReturnRows(sqlmock.NewRows(commentRowColumns))
+
+		comments, err := repo.FindThreads(ctx, articleID, cursor, 11)
+		require.NoError(t, err)
+		assert.Empty(t, comments)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+}
+
+func TestCommentRepository_FindReplies(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCommentRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("walks approved replies", func(t *testing.T) {
+		rootID := uuid.New()
+		kit.mock.ExpectQuery("WITH RECURSIVE thread").
+			WithArgs(pq.Array([]string{rootID.String()}), model.CommentStatusApproved).
+			WillReturnRows(sqlmock.NewRows(commentRowColumns).AddRow(commentRow(uuid.New(), uuid.New(), rootID, 1)...))
+
+		replies, err := repo.FindReplies(ctx, []uuid.UUID{rootID})
+		require.NoError(t, err)
+		require.Len(t, replies, 1)
+		assert.Equal(t, rootID, *replies[0].ParentID)
+	})
+
+	t.Run("no roots", func(t *testing.T) {
+		replies, err := repo.FindReplies(ctx, nil)
+		require.NoError(t, err)
+		assert.Nil(t, replies)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+}
+
+func TestCommentRepository_Create(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCommentRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	comment := &model.Comment{ArticleID: uuid.New(), UserID: "user-1", Body: "Nice", Status: model.CommentStatusApproved}
+
+	t.Run("success recounts and drops listings", func(t *testing.T) {
+		listingKey := firstPageCacheKey(nil)
+		kit.cache.Set(ctx, listingKey, "cached", time.Minute)
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery("INSERT INTO comments").
+			WithArgs(sqlmock.AnyArg(), comment.ArticleID, comment.ParentID, "user-1", "Nice", model.CommentStatusApproved, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+		kit.mock.ExpectExec(`UPDATE articles\s+SET comment_count`).
+			WithArgs(comment.ArticleID, model.CommentStatusApproved).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		kit.mock.ExpectCommit()
+
+		result, err := repo.Create(ctx, comment)
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, result.ID)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+
+		var cached string
+		require.ErrorIs(t, kit.cache.Get(ctx, listingKey, &cached), cache.ErrCacheMiss)
+	})
+
+	t.Run("insert error", func(t *testing.T) {
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectQuery("INSERT INTO comments").WillReturnError(errors.New("db error"))
+		kit.mock.ExpectRollback()
+
+		_, err := repo.Create(ctx, comment)
+		require.Error(t, err)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+}
+
+func TestCommentRepository_Update(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCommentRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	comment := &model.Comment{ID: uuid.New(), ArticleID: uuid.New(), Body: "Edited", Status: model.CommentStatusSpam}
+
+	kit.mock.ExpectBegin()
+	kit.mock.ExpectQuery("UPDATE comments").
+		WithArgs(comment.ID, "Edited", model.CommentStatusSpam).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+	kit.mock.ExpectExec(`UPDATE articles\s+SET comment_count`).
+		WithArgs(comment.ArticleID, model.CommentStatusApproved).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	kit.mock.ExpectCommit()
+
+	_, err := repo.Update(ctx, comment)
+	require.NoError(t, err)
+	require.NoError(t, kit.mock.ExpectationsWereMet())
+}
+
+func TestCommentRepository_Delete(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewCommentRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	comment := &model.Comment{ID: uuid.New(), ArticleID: uuid.New()}
+
+	t.Run("success", func(t *testing.T) {
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectExec("DELETE FROM comments WHERE id").
+			WithArgs(comment.ID).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		kit.mock.ExpectExec(`UPDATE articles\s+SET comment_count`).
+			WithArgs(comment.ArticleID, model.CommentStatusApproved).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		kit.mock.ExpectCommit()
+
+		require.NoError(t, repo.Delete(ctx, comment))
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("recount error rolls back", func(t *testing.T) {
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectExec("DELETE FROM comments WHERE id").
+			WithArgs(comment.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		kit.mock.ExpectExec(`UPDATE articles\s+SET comment_count`).
+			WillReturnError(errors.New("db error"))
+		kit.mock.ExpectRollback()
+
+		require.Error(t, repo.Delete(ctx, comment))
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+}
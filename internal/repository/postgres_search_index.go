@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+)
+
+// postgresSearchIndex searches the article tables directly. Their indexes follow every
+// write, so there is nothing to index, delete or reset.
+type postgresSearchIndex struct {
+	db *sql.DB
+}
+
+func NewPostgresSearchIndex(db *sql.DB) model.SearchIndex {
+	return &postgresSearchIndex{
+		db: db,
+	}
+}
+
+func (i *postgresSearchIndex) Index(ctx context.Context, docs ...*model.SearchDocument) error {
+	return nil
+}
+
+func (i *postgresSearchIndex) Delete(ctx context.Context, ids ...uuid.UUID) error {
+	return nil
+}
+
+func (i *postgresSearchIndex) Reset(ctx context.Context) error {
+	return nil
+}
+
+func (i *postgresSearchIndex) Close() error {
+	return nil
+}
+
+// headlineOptions make ts_headline mark the matches the way the local index does.
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+
+func (i *postgresSearchIndex) Search(ctx context.Context, query model.SearchIndexQuery) (*model.SearchResult, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("search", "Search")()
+
+	conditions, args := articleConditions(query.Filter)
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	result := &model.SearchResult{Hits: []*model.SearchHit{}}
+
+	countQuery := `
+		SELECT COUNT(*)
+		FROM articles a
+		JOIN authors au ON a.author_id = au.id
+	` + whereClause
+	if err := i.db.QueryRowContext(ctx, countQuery, args...).Scan(&result.Total); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if query.FacetLimit > 0 {
+		facets, err := i.facets(ctx, whereClause, args, query.FacetLimit)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result.Facets = facets
+	}
+
+	if query.Limit <= 0 || result.Total == 0 {
+		return result, nil
+	}
+
+	orderBy, hitArgs := articleOrder(query.Filter, args[:len(args):len(args)])
+	columns := "a.id, '', ''"
+	if query.Highlight && query.Filter.Query != "" {
+		pos := len(hitArgs) + 1
+		columns = fmt.Sprintf(
+			`a.id,
+			ts_headline(article_search_config(a.language), a.title, plainto_tsquery(article_search_config(a.language), $%d), 'HighlightAll=true'),
+			ts_headline(article_search_config(a.language), a.body, plainto_tsquery(article_search_config(a.language), $%d), '%s')`,
+			pos, pos, headlineOptions,
+		)
+		hitArgs = append(hitArgs, query.Filter.Query)
+	}
+	hitArgs = append(hitArgs, query.Limit, query.Offset)
+
+	hitQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM articles a
+		JOIN authors au ON a.author_id = au.id%s
+		ORDER BY %s LIMIT $%d OFFSET $%d`,
+		columns, whereClause, orderBy, len(hitArgs)-1, len(hitArgs))
+
+	rows, err := i.db.QueryContext(ctx, hitQuery, hitArgs...)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			hit         model.SearchHit
+			title, body string
+		)
+		if err := rows.Scan(&hit.ID, &title, &body); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		hit.Highlights = highlights(map[string]string{"title": title, "body": body})
+		result.Hits = append(result.Hits, &hit)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// highlights keeps the headlines that mark a match; ts_headline falls back to the start
+// of the text otherwise.
+func highlights(headlines map[string]string) map[string][]string {
+	var marked map[string][]string
+	for field, headline := range headlines {
+		if !strings.Contains(headline, "<mark>") {
+			continue
+		}
+		if marked == nil {
+			marked = make(map[string][]string)
+		}
+		marked[field] = strings.Split(headline, " ... ")
+	}
+	return marked
+}
+
+// facetQueries count the articles matching a filter per facet value. Each is given
+// the FROM clause and WHERE clause of the filter and the position of its limit.
+var facetQueries = map[string]string{
+	"authors": `
+		SELECT au.id::text, au.name, COUNT(*)
+		%s%s
+		GROUP BY au.id, au.name
+		ORDER BY COUNT(*) DESC, au.name
+		LIMIT $%d`,
+	"tags": `
+		SELECT t.slug, t.name, COUNT(*)
+		%s
+		JOIN article_tags ft ON ft.article_id = a.id
+		JOIN tags t ON t.id = ft.tag_id%s
+		GROUP BY t.slug, t.name
+		ORDER BY COUNT(*) DESC, t.slug
+		LIMIT $%d`,
+	"months": `
+		SELECT to_char(date_trunc('month', a.created_at), 'YYYY-MM') AS month, '', COUNT(*)
+		%s%s
+		GROUP BY month
+		ORDER BY month DESC
+		LIMIT $%d`,
+}
+
+// facets counts the matches of the conditions whereClause with args per author, tag and
+// month.
+func (i *postgresSearchIndex) facets(ctx context.Context, whereClause string, args []any, limit int) (*model.Facets, error) {
+	defer metrics.ObserveQuery("search", "Facets")()
+
+	from := `FROM articles a
+		JOIN authors au ON a.author_id = au.id`
+	args = append(args[:len(args):len(args)], limit)
+
+	// The facets are independent aggregates over the same matches, so they run at once.
+	facets := make(map[string][]*model.FacetCount, len(facetQueries))
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	for name, query := range facetQueries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			counts, err := i.facet(ctx, fmt.Sprintf(query, from, whereClause, len(args)), args)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			facets[name] = counts
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return &model.Facets{
+		Authors: facets["authors"],
+		Tags:    facets["tags"],
+		Months:  facets["months"],
+	}, nil
+}
+
+// facet runs one of facetQueries.
+func (i *postgresSearchIndex) facet(ctx context.Context, query string, args []any) ([]*model.FacetCount, error) {
+	rows, err := i.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []*model.FacetCount{}
+	for rows.Next() {
+		var c model.FacetCount
+		if err := rows.Scan(&c.Value, &c.Label, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresSearchIndex_Search(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	index := NewPostgresSearchIndex(kit.db)
+	ctx := context.TODO()
+	filter := model.ArticleQuery{Query: "go", Tag: "databases"}
+	facetColumns := []string{"value", "label", "count"}
+
+	t.Run("hits with highlights and facets", func(t *testing.T) {
+		// The facets are queried concurrently.
+		kit.mock.MatchExpectationsInOrder(false)
+		defer kit.mock.MatchExpectationsInOrder(true)
+
+		articleID, otherID, authorID := uuid.New(), uuid.New(), uuid.NewString()
+		kit.mock.ExpectQuery(`SELECT COUNT\(\*\)\s+FROM articles a\s+JOIN authors au ON a.author_id = au.id\s+WHERE \(a.title ILIKE \$1 OR a.body ILIKE \$2 OR .*\$3\)\) AND EXISTS`).
+			WithArgs("%go%", "%go%", "go", "databases").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+		kit.mock.ExpectQuery(`SELECT au.id::text, au.name, COUNT\(\*\)\s+FROM articles a\s+JOIN authors au ON a.author_id = au.id WHERE \(a.title ILIKE \$1 OR a.body ILIKE \$2 OR .*\$3\)\) AND EXISTS .*GROUP BY au.id, au.name .*LIMIT \$5`).
+			WithArgs("%go%", "%go%", "go", "databases", 10).
+			WillReturnRows(sqlmock.NewRows(facetColumns).AddRow(authorID, "Gopher", 3))
+		kit.mock.ExpectQuery(`SELECT t.slug, t.name, COUNT\(\*\)\s+FROM articles a\s+JOIN authors au ON a.author_id = au.id\s+JOIN article_tags ft ON ft.article_id = a.id\s+JOIN tags t ON t.id = ft.tag_id WHERE .*GROUP BY t.slug, t.name`).
+			WithArgs("%go%", "%go%", "go", "databases", 10).
+			WillReturnRows(sqlmock.NewRows(facetColumns).AddRow("databases", "Databases", 3).AddRow("go", "Go", 2))
+		kit.mock.ExpectQuery(`SELECT to_char\(date_trunc\('month', a.created_at\), 'YYYY-MM'\) AS month.*GROUP BY month\s+ORDER BY month DESC`).
+			WithArgs("%go%", "%go%", "go", "databases", 10).
+			WillReturnRows(sqlmock.NewRows(facetColumns).AddRow("2025-08", "", 2).AddRow("2025-07", "", 1))
+		kit.mock.ExpectQuery(`SELECT a.id,\s+ts_headline\(article_search_config\(a.language\), a.title, plainto_tsquery\(article_search_config\(a.language\), \$5\), 'HighlightAll=true'\),\s+ts_headline\(article_search_config\(a.language\), a.body, plainto_tsquery\(article_search_config\(a.language\), \$5\), 'StartSel=<mark>.*ORDER BY a.created_at DESC LIMIT \$6 OFFSET \$7`).
+			WithArgs("%go%", "%go%", "go", "databases", "go", 2, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "body"}).
+				AddRow(articleID, "<mark>Go</mark> and Postgres", "Using <mark>go</mark> ... with <mark>go</mark>").
+				AddRow(otherID, "Databases", "Tables and rows"))
+
+		res, err := index.Search(ctx, model.SearchIndexQuery{Filter: filter, Offset: 10, Limit: 2, FacetLimit: 10, Highlight: true})
+		require.NoError(t, err)
+		assert.Equal(t, 12, res.Total)
+		require.Len(t, res.Hits, 2)
+		assert.Equal(t, articleID, res.Hits[0].ID)
+		assert.Equal(t, []string{"<mark>Go</mark> and Postgres"}, res.Hits[0].Highlights["title"])
+		assert.Equal(t, []string{"Using <mark>go</mark>", "with <mark>go</mark>"}, res.Hits[0].Highlights["body"])
+		assert.Nil(t, res.Hits[1].Highlights)
+		require.Len(t, res.Facets.Authors, 1)
+		assert.Equal(t, model.FacetCount{Value: authorID, Label: "Gopher", Count: 3}, *res.Facets.Authors[0])
+		require.Len(t, res.Facets.Tags, 2)
+		assert.Equal(t, "go", res.Facets.Tags[1].Value)
+		require.Len(t, res.Facets.Months, 2)
+		assert.Equal(t, "2025-08", res.Facets.Months[0].Value)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("count only", func(t *testing.T) {
+		kit.mock.ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithArgs("%go%", "%go%", "go", "databases").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+		res, err := index.Search(ctx, model.SearchIndexQuery{Filter: filter})
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.Total)
+		assert.Empty(t, res.Hits)
+		assert.Nil(t, res.Facets)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("relevance without highlights", func(t *testing.T) {
+		kit.mock.ExpectQuery(`SELECT COUNT\(\*\)`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		kit.mock.ExpectQuery(`SELECT a.id, '', ''\s+FROM articles a.*ORDER BY ts_rank\(.*plainto_tsquery\(article_search_config\(a.language\), \$5\)\) DESC, a.created_at DESC LIMIT \$6 OFFSET \$7`).
+			WithArgs("%go%", "%go%", "go", "databases", "go", 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "body"}).AddRow(uuid.New(), "", ""))
+
+		relevance := filter
+		relevance.Sort = "relevance"
+		res, err := index.Search(ctx, model.SearchIndexQuery{Filter: relevance, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, res.Hits, 1)
+		assert.Nil(t, res.Hits[0].Highlights)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("facet error", func(t *testing.T) {
+		kit.mock.MatchExpectationsInOrder(false)
+		defer kit.mock.MatchExpectationsInOrder(true)
+
+		kit.mock.ExpectQuery(`SELECT COUNT\(\*\)`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		kit.mock.ExpectQuery("GROUP BY au.id").WillReturnError(errors.New("db error"))
+		kit.mock.ExpectQuery("GROUP BY t.slug").WillReturnRows(sqlmock.NewRows(facetColumns))
+		kit.mock.ExpectQuery("GROUP BY month").WillReturnRows(sqlmock.NewRows(facetColumns))
+
+		_, err := index.Search(ctx, model.SearchIndexQuery{Filter: filter, Limit: 10, FacetLimit: 10})
+		require.Error(t, err)
+	})
+}
+
+func TestPostgresSearchIndex_Writes(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	index := NewPostgresSearchIndex(kit.db)
+	ctx := context.TODO()
+
+	// The article tables are the index, so writes touch nothing.
+	require.NoError(t, index.Index(ctx, &model.SearchDocument{ID: uuid.New()}))
+	require.NoError(t, index.Delete(ctx, uuid.New()))
+	require.NoError(t, index.Reset(ctx))
+	require.NoError(t, index.Close())
+	require.NoError(t, kit.mock.ExpectationsWereMet())
+}
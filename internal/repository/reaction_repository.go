@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type reactionRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewReactionRepository(db *sql.DB, cache cache.Cache) model.ReactionRepository {
+	return &reactionRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (r *reactionRepository) Add(ctx context.Context, articleID uuid.UUID, userID, reaction string) (bool, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("reaction", "Add")()
+
+	query := `
+		INSERT INTO article_reactions (article_id, user_id, type, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (article_id, user_id, type) DO NOTHING
+	`
+	res, err := r.db.ExecContext(ctx, query, articleID, userID, reaction)
+	if err != nil {
+		log.Error(err)
+		return false, err
+	}
+
+	return affected(res)
+}
+
+func (r *reactionRepository) Remove(ctx context.Context, articleID uuid.UUID, userID, reaction string) (bool, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("reaction", "Remove")()
+
+	query := `DELETE FROM article_reactions WHERE article_id = $1 AND user_id = $2 AND type = $3`
+	res, err := r.db.ExecContext(ctx, query, articleID, userID, reaction)
+	if err != nil {
+		log.Error(err)
+		return false, err
+	}
+
+	return affected(res)
+}
+
+func (r *reactionRepository) ApplyCounts(ctx context.Context, deltas map[uuid.UUID]map[string]int64) error {
+	log := logger.FromContext(ctx)
+
+	var (
+		articleIDs []string
+		types      []string
+		counts     []int64
+	)
+	for articleID, byType := range deltas {
+		for reaction, delta := range byType {
+			if delta == 0 {
+				continue
+			}
+			articleIDs = append(articleIDs, articleID.String())
+			types = append(types, reaction)
+			counts = append(counts, delta)
+		}
+	}
+	if len(articleIDs) == 0 {
+		return nil
+	}
+
+	defer metrics.ObserveQuery("reaction", "ApplyCounts")()
+
+	// The join skips articles deleted since the reactions were counted. Existing counts
+	// take the delta as is, so unreactions lower them; only new counts are clamped at
+	// zero. A count inserted concurrently is clamped by the conflict clause.
+	query := `
+		WITH d AS (
+			SELECT d.article_id, d.type, d.delta
+			FROM unnest($1::text[], $2::text[], $3::bigint[]) AS d(article_id, type, delta)
+			JOIN articles a ON a.id = d.article_id
+		), updated AS (
+			UPDATE article_reaction_counts rc
+			SET count = GREATEST(rc.count + d.delta, 0)
+			FROM d
+			WHERE rc.article_id = d.article_id AND rc.type = d.type
+			RETURNING rc.article_id, rc.type
+		)
+		INSERT INTO article_reaction_counts (article_id, type, count)
+		SELECT d.article_id, d.type, GREATEST(d.delta, 0)
+		FROM d
+		WHERE NOT EXISTS (SELECT 1 FROM updated u WHERE u.article_id = d.article_id AND u.type = d.type)
+		ON CONFLICT (article_id, type) DO UPDATE
+		SET count = GREATEST(article_reaction_counts.count + EXCLUDED.count, 0)
+	`
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(articleIDs), pq.Array(types), pq.Array(counts)); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	deleteReactionCache(ctx, r.cache)
+
+	return nil
+}
+
+// deleteReactionCache drops the cached articles that carry reaction counts: the first
+// page of the listing and the related articles. Feeds, sitemaps and facets leave the
+// counts out and are kept.
+func deleteReactionCache(ctx context.Context, c cache.Cache) {
+	log := logger.FromContext(ctx)
+
+	if err := c.DeleteByPrefix(ctx, articleListCachePrefix()); err != nil {
+		log.Warn("failed to delete cache articles")
+	}
+	if err := c.DeleteByPrefix(ctx, model.RelatedKey+":"); err != nil {
+		log.Warn("failed to delete cache related articles")
+	}
+}
+
+func (r *reactionRepository) AddBookmark(ctx context.Context, articleID uuid.UUID, userID string) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("reaction", "AddBookmark")()
+
+	query := `
+		INSERT INTO article_bookmarks (user_id, article_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id, article_id) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query, userID, articleID); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *reactionRepository) RemoveBookmark(ctx context.Context, articleID uuid.UUID, userID string) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("reaction", "RemoveBookmark")()
+
+	query := `DELETE FROM article_bookmarks WHERE user_id = $1 AND article_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, userID, articleID); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func affected(res sql.Result) (bool, error) {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReactionRepository_Add(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewReactionRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	articleID := uuid.New()
+
+	t.Run("new reaction", func(t *testing.T) {
+		kit.mock.ExpectExec(`INSERT INTO article_reactions .* ON CONFLICT \(article_id, user_id, type\) DO NOTHING`).
+			WithArgs(articleID, "user-1", model.ReactionLike).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		added, err := repo.Add(ctx, articleID, "user-1", model.ReactionLike)
+		require.NoError(t, err)
+		assert.True(t, added)
+	})
+
+	t.Run("repeated reaction", func(t *testing.T) {
+		kit.mock.ExpectExec("INSERT INTO article_reactions").
+			WithArgs(articleID, "user-1", model.ReactionLike).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		added, err := repo.Add(ctx, articleID, "user-1", model.ReactionLike)
+		require.NoError(t, err)
+		assert.False(t, added)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		kit.mock.ExpectExec("INSERT INTO article_reactions").WillReturnError(errors.New("db error"))
+
+		_, err := repo.Add(ctx, articleID, "user-1", model.ReactionLike)
+		require.Error(t, err)
+	})
+}
+
+func TestReactionRepository_Remove(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewReactionRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	articleID := uuid.New()
+
+	kit.mock.ExpectExec("DELETE FROM article_reactions").
+		WithArgs(articleID, "user-1", model.ReactionLove).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	removed, err := repo.Remove(ctx, articleID, "user-1", model.ReactionLove)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	require.NoError(t, kit.mock.ExpectationsWereMet())
+}
+
+func TestReactionRepository_ApplyCounts(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewReactionRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	articleID := uuid.New()
+
+	t.Run("upserts deltas and drops cached reaction counts", func(t *testing.T) {
+		listingKey := firstPageCacheKey(nil)
+		relatedKey := relatedCacheKey(uuid.New(), 5)
+		feedKey := model.FeedKey + ":rss"
+		for _, key := range []string{listingKey, relatedKey, feedKey} {
+			kit.cache.Set(ctx, key, "cached", time.Minute)
+		}
+
+		kit.mock.ExpectExec(`FROM unnest\(\$1::text\[\], \$2::text\[\], \$3::bigint\[\]\).*INSERT INTO article_reaction_counts`).
+			WithArgs(pq.Array([]string{articleID.String()}), pq.Array([]string{model.ReactionLike}), pq.Array([]int64{3})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.ApplyCounts(ctx, map[uuid.UUID]map[string]int64{
+			articleID: {model.ReactionLike: 3, model.ReactionLove: 0},
+		})
+		require.NoError(t, err)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+
+		var cached string
+		require.ErrorIs(t, kit.cache.Get(ctx, listingKey, &cached), cache.ErrCacheMiss)
+		require.ErrorIs(t, kit.cache.Get(ctx, relatedKey, &cached), cache.ErrCacheMiss)
+		require.NoError(t, kit.cache.Get(ctx, feedKey, &cached))
+	})
+
+	t.Run("negative delta lowers existing counts", func(t *testing.T) {
+		// Existing counts take the raw delta; only the insert of a new count is clamped.
+		kit.mock.ExpectExec(`UPDATE article_reaction_counts rc\s+SET count = GREATEST\(rc.count \+ d.delta, 0\).*`+
+			`INSERT INTO article_reaction_counts \(article_id, type, count\)\s+SELECT d.article_id, d.type, GREATEST\(d.delta, 0\)\s+FROM d\s+WHERE NOT EXISTS`).
+			WithArgs(pq.Array([]string{articleID.String()}), pq.Array([]string{model.ReactionLike}), pq.Array([]int64{-2})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.ApplyCounts(ctx, map[uuid.UUID]map[string]int64{articleID: {model.ReactionLike: -2}})
+		require.NoError(t, err)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to apply", func(t *testing.T) {
+		err := repo.ApplyCounts(ctx, map[uuid.UUID]map[string]int64{articleID: {model.ReactionLike: 0}})
+		require.NoError(t, err)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		kit.mock.ExpectExec("INSERT INTO article_reaction_counts").WillReturnError(errors.New("db error"))
+
+		err := repo.ApplyCounts(ctx, map[uuid.UUID]map[string]int64{articleID: {model.ReactionLike: -1}})
+		require.Error(t, err)
+	})
+}
+
+func TestReactionRepository_Bookmarks(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewReactionRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	articleID := uuid.New()
+
+	kit.mock.ExpectExec(`INSERT INTO article_bookmarks .* ON CONFLICT \(user_id, article_id\) DO NOTHING`).
+		WithArgs("user-1", articleID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	kit.mock.ExpectExec("DELETE FROM article_bookmarks").
+		WithArgs("user-1", articleID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.AddBookmark(ctx, articleID, "user-1"))
+	require.NoError(t, repo.RemoveBookmark(ctx, articleID, "user-1"))
+	require.NoError(t, kit.mock.ExpectationsWereMet())
+}
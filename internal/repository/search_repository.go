@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/lib/pq"
+)
+
+type searchRepository struct {
+	db *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) model.SearchRepository {
+	return &searchRepository{
+		db: db,
+	}
+}
+
+// likeEscaper escapes the wildcards of LIKE, whose default escape character is the
+// backslash.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *searchRepository) Suggest(ctx context.Context, prefix string, limit int) (*model.Suggestions, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("search", "Suggest")()
+
+	// Both patterns are served by the trigram indexes on lower(title) and lower(name).
+	start := likeEscaper.Replace(prefix) + "%"
+	word := "% " + start
+
+	suggestions := &model.Suggestions{
+		Articles: []*model.ArticleSuggestion{},
+		Authors:  []*model.AuthorSuggestion{},
+	}
+
+	query := `
+		SELECT a.id, a.title, a.slug
+		FROM articles a
+		WHERE lower(a.title) LIKE $1 OR lower(a.title) LIKE $2
+		ORDER BY lower(a.title) LIKE $1 DESC, similarity(lower(a.title), $3) DESC, a.title
+		LIMIT $4
+	`
+	rows, err := r.db.QueryContext(ctx, query, start, word, prefix, limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s model.ArticleSuggestion
+		if err := rows.Scan(&s.ID, &s.Title, &s.Slug); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		suggestions.Articles = append(suggestions.Articles, &s)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	query = `
+		SELECT au.id, au.name
+		FROM authors au
+		WHERE lower(au.name) LIKE $1 OR lower(au.name) LIKE $2
+		ORDER BY lower(au.name) LIKE $1 DESC, similarity(lower(au.name), $3) DESC, au.name
+		LIMIT $4
+	`
+	authorRows, err := r.db.QueryContext(ctx, query, start, word, prefix, limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer authorRows.Close()
+
+	for authorRows.Next() {
+		var s model.AuthorSuggestion
+		if err := authorRows.Scan(&s.ID, &s.Name); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		suggestions.Authors = append(suggestions.Authors, &s)
+	}
+	if err := authorRows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+func (r *searchRepository) SimilarWords(ctx context.Context, words []string) ([]string, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("search", "SimilarWords")()
+
+	// % keeps the words above the pg_trgm similarity threshold; among those the closest
+	// wins, then the one found in most articles.
+	query := `
+		SELECT COALESCE((
+			SELECT w.word FROM search_words w
+			WHERE w.word % q.word
+			ORDER BY w.word <-> q.word, w.ndoc DESC
+			LIMIT 1), '')
+		FROM unnest($1::text[]) WITH ORDINALITY AS q(word, pos)
+		ORDER BY q.pos
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(words))
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	similar := make([]string, 0, len(words))
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		similar = append(similar, word)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return similar, nil
+}
+
+func (r *searchRepository) RefreshWords(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("search", "RefreshWords")()
+
+	if _, err := r.db.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY search_words`); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepository_Suggest(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewSearchRepository(kit.db)
+	ctx := context.TODO()
+
+	t.Run("titles and names by word prefix", func(t *testing.T) {
+		articleID, authorID := uuid.New(), uuid.New()
+		kit.mock.ExpectQuery(`SELECT a.id, a.title, a.slug\s+FROM articles a\s+WHERE lower\(a.title\) LIKE \$1 OR lower\(a.title\) LIKE \$2`).
+			WithArgs("go%", "% go%", "go", 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "slug"}).AddRow(articleID, "Go Generics", "go-generics"))
+		kit.mock.ExpectQuery(`SELECT au.id, au.name\s+FROM authors au\s+WHERE lower\(au.name\) LIKE \$1 OR lower\(au.name\) LIKE \$2`).
+			WithArgs("go%", "% go%", "go", 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(authorID, "Gopher"))
+
+		res, err := repo.Suggest(ctx, "go", 5)
+		require.NoError(t, err)
+		require.Len(t, res.Articles, 1)
+		assert.Equal(t, "go-generics", res.Articles[0].Slug)
+		require.Len(t, res.Authors, 1)
+		assert.Equal(t, authorID, res.Authors[0].ID)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("wildcards are literal", func(t *testing.T) {
+		kit.mock.ExpectQuery("FROM articles a").
+			WithArgs(`50\%\_off%`, `% 50\%\_off%`, "50%_off", 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "slug"}))
+		kit.mock.ExpectQuery("FROM authors au").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+		res, err := repo.Suggest(ctx, "50%_off", 5)
+		require.NoError(t, err)
+		assert.Empty(t, res.Articles)
+		assert.Empty(t, res.Authors)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		kit.mock.ExpectQuery("FROM articles a").WillReturnError(errors.New("db error"))
+
+		_, err := repo.Suggest(ctx, "go", 5)
+		require.Error(t, err)
+	})
+}
+
+func TestSearchRepository_SimilarWords(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewSearchRepository(kit.db)
+	ctx := context.TODO()
+
+	kit.mock.ExpectQuery(`FROM search_words w\s+WHERE w.word % q.word\s+ORDER BY w.word <-> q.word, w.ndoc DESC .* FROM unnest\(\$1::text\[\]\) WITH ORDINALITY`).
+		WithArgs(pq.Array([]string{"postgress", "xyzzy"})).
+		WillReturnRows(sqlmock.NewRows([]string{"word"}).AddRow("postgres").AddRow(""))
+
+	res, err := repo.SimilarWords(ctx, []string{"postgress", "xyzzy"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"postgres", ""}, res)
+	require.NoError(t, kit.mock.ExpectationsWereMet())
+}
+
+func TestSearchRepository_RefreshWords(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewSearchRepository(kit.db)
+	ctx := context.TODO()
+
+	kit.mock.ExpectExec(`REFRESH MATERIALIZED VIEW CONCURRENTLY search_words`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, repo.RefreshWords(ctx))
+
+	kit.mock.ExpectExec(`REFRESH MATERIALIZED VIEW`).WillReturnError(errors.New("db error"))
+	require.Error(t, repo.RefreshWords(ctx))
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type tagRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewTagRepository(db *sql.DB, cache cache.Cache) model.TagRepository {
+	return &tagRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func tagCountsCacheKey() string {
+	return fmt.Sprintf("%s:counts", model.TagKey)
+}
+
+func (r *tagRepository) FindAll(ctx context.Context) ([]*model.Tag, error) {
+	log := logger.FromContext(ctx)
+
+	key := tagCountsCacheKey()
+
+	var cached []*model.Tag
+	if err := r.cache.Get(ctx, key, &cached); err == nil {
+		return cached, nil
+	}
+
+	defer metrics.ObserveQuery("tag", "FindAll")()
+
+	query := `
+		SELECT t.id, t.name, t.slug, COUNT(at.article_id)
+		FROM tags t
+		LEFT JOIN article_tags at ON at.tag_id = t.id
+		GROUP BY t.id, t.name, t.slug
+		ORDER BY COUNT(at.article_id) DESC, t.slug ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*model.Tag
+	for rows.Next() {
+		var t model.Tag
+		if err := rows.Scan(&t.ID, &t.Name, &t.Slug, &t.ArticleCount); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		results = append(results, &t)
+	}
+
+	if err := r.cache.Set(ctx, key, results, config.RedisExpired()); err != nil {
+		log.Warn("failed to cache tags")
+	}
+
+	return results, nil
+}
+
+// upsertTag creates a tag named after its slug, or returns the ID of the existing one.
+const upsertTag = `
+	INSERT INTO tags (id, name, slug)
+	VALUES ($1, $2, $2)
+	ON CONFLICT (slug) DO UPDATE SET slug = EXCLUDED.slug
+	RETURNING id
+`
+
+// attachTags upserts the given tag slugs and links them to the article inside tx.
+func attachTags(ctx context.Context, tx *sql.Tx, articleID uuid.UUID, slugs []string) error {
+	linkTag := `
+		INSERT INTO article_tags (article_id, tag_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	for _, slug := range slugs {
+		var tagID uuid.UUID
+		if err := tx.QueryRowContext(ctx, upsertTag, uuid.New(), slug).Scan(&tagID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, linkTag, articleID, tagID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertTags upserts the given tag slugs inside tx and returns their IDs by slug.
+func upsertTags(ctx context.Context, tx *sql.Tx, slugs []string) (map[string]uuid.UUID, error) {
+	ids := make(map[string]uuid.UUID, len(slugs))
+	for _, slug := range slugs {
+		if _, ok := ids[slug]; ok {
+			continue
+		}
+
+		var tagID uuid.UUID
+		if err := tx.QueryRowContext(ctx, upsertTag, uuid.New(), slug).Scan(&tagID); err != nil {
+			return nil, err
+		}
+		ids[slug] = tagID
+	}
+
+	return ids, nil
+}
+
+// loadTags fills the Tags field of every article with a single query.
+func loadTags(ctx context.Context, db *sql.DB, articles []*model.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(articles))
+	byID := make(map[uuid.UUID]*model.Article, len(articles))
+	for _, a := range articles {
+		ids = append(ids, a.ID.String())
+		byID[a.ID] = a
+		a.Tags = []string{}
+	}
+
+	query := `
+		SELECT at.article_id, t.slug
+		FROM article_tags at
+		JOIN tags t ON t.id = at.tag_id
+		WHERE at.article_id = ANY($1)
+		ORDER BY t.slug ASC
+	`
+
+	rows, err := db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			articleID uuid.UUID
+			slug      string
+		)
+		if err := rows.Scan(&articleID, &slug); err != nil {
+			return err
+		}
+		if a, ok := byID[articleID]; ok {
+			a.Tags = append(a.Tags, slug)
+		}
+	}
+
+	return rows.Err()
+}
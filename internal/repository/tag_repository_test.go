@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagRepository_FindAll(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewTagRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+
+	t.Run("query error", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT t.id, t.name, t.slug, COUNT").
+			WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAll(ctx)
+		require.Error(t, err)
+		require.Nil(t, res)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "slug", "count"}).
+			AddRow("bad-uuid", "go", "go", 1)
+
+		kit.mock.ExpectQuery("SELECT t.id, t.name, t.slug, COUNT").
+			WillReturnRows(rows)
+
+		_, err := repo.FindAll(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("found from db and cached", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "slug", "count"}).
+			AddRow(uuid.New(), "go", "go", 3).
+			AddRow(uuid.New(), "sql", "sql", 1)
+
+		kit.mock.ExpectQuery("SELECT t.id, t.name, t.slug, COUNT").
+			WillReturnRows(rows)
+
+		res, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.Equal(t, 3, res[0].ArticleCount)
+
+		var cached []*model.Tag
+		require.NoError(t, kit.cache.Get(ctx, tagCountsCacheKey(), &cached))
+		require.Len(t, cached, 2)
+	})
+
+	t.Run("found from cache", func(t *testing.T) {
+		res, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/metrics"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type viewRepository struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+func NewViewRepository(db *sql.DB, cache cache.Cache) model.ViewRepository {
+	return &viewRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (r *viewRepository) SaveDaily(ctx context.Context, views []model.DailyViews) error {
+	log := logger.FromContext(ctx)
+
+	if len(views) == 0 {
+		return nil
+	}
+
+	articleIDs := make([]string, len(views))
+	days := make([]string, len(views))
+	counts := make([]int64, len(views))
+	for i, v := range views {
+		articleIDs[i] = v.ArticleID.String()
+		days[i] = v.Day.Format(time.DateOnly)
+		counts[i] = v.Views
+	}
+
+	defer metrics.ObserveQuery("view", "SaveDaily")()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	// Counts of a day only grow, so the larger one wins; a visitor key that expired
+	// before it was read cannot wipe out a stored count. The join skips articles
+	// deleted since they were viewed.
+	query := `
+		INSERT INTO article_views (article_id, day, views)
+		SELECT d.article_id, d.day, d.views
+		FROM unnest($1::text[], $2::date[], $3::bigint[]) AS d(article_id, day, views)
+		JOIN articles a ON a.id = d.article_id
+		ON CONFLICT (article_id, day) DO UPDATE
+		SET views = GREATEST(article_views.views, EXCLUDED.views)
+	`
+	if _, err := tx.ExecContext(ctx, query, pq.Array(articleIDs), pq.Array(days), pq.Array(counts)); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	query = `
+		UPDATE articles a
+		SET view_count = (SELECT COALESCE(SUM(v.views), 0) FROM article_views v WHERE v.article_id = a.id)
+		WHERE a.id = ANY($1::text[])
+	`
+	if _, err := tx.ExecContext(ctx, query, pq.Array(articleIDs)); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	deleteListingCache(ctx, r.cache)
+
+	return nil
+}
+
+func (r *viewRepository) Trending(ctx context.Context, since time.Time, halfLife time.Duration, limit int) ([]uuid.UUID, error) {
+	log := logger.FromContext(ctx)
+
+	defer metrics.ObserveQuery("view", "Trending")()
+
+	// A day's views are aged from the start of the day.
+	query := `
+		SELECT v.article_id
+		FROM article_views v
+		WHERE v.day >= $1::date
+		GROUP BY v.article_id
+		ORDER BY SUM(v.views * POWER(0.5, EXTRACT(EPOCH FROM ($2::timestamp - v.day::timestamp)) / $3)) DESC, v.article_id
+		LIMIT $4
+	`
+	rows, err := r.db.QueryContext(ctx, query, since.Format(time.DateOnly), time.Now().UTC(), halfLife.Seconds(), limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewRepository_SaveDaily(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewViewRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	articleID := uuid.New()
+	day := time.Date(2025, 8, 19, 0, 0, 0, 0, time.UTC)
+
+	t.Run("keeps the larger count and refreshes totals", func(t *testing.T) {
+		listingKey := firstPageCacheKey(nil)
+		kit.cache.Set(ctx, listingKey, "cached", time.Minute)
+
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectExec(`INSERT INTO article_views .* FROM unnest\(\$1::text\[\], \$2::date\[\], \$3::bigint\[\]\) .* GREATEST\(article_views.views, EXCLUDED.views\)`).
+			WithArgs(pq.Array([]string{articleID.String()}), pq.Array([]string{"2025-08-19"}), pq.Array([]int64{42})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		kit.mock.ExpectExec(`UPDATE articles a\s+SET view_count`).
+			WithArgs(pq.Array([]string{articleID.String()})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		kit.mock.ExpectCommit()
+
+		err := repo.SaveDaily(ctx, []model.DailyViews{{ArticleID: articleID, Day: day, Views: 42}})
+		require.NoError(t, err)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+
+		var cached string
+		require.ErrorIs(t, kit.cache.Get(ctx, listingKey, &cached), cache.ErrCacheMiss)
+	})
+
+	t.Run("nothing to save", func(t *testing.T) {
+		require.NoError(t, repo.SaveDaily(ctx, nil))
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("error rolls back", func(t *testing.T) {
+		kit.mock.ExpectBegin()
+		kit.mock.ExpectExec("INSERT INTO article_views").WillReturnError(errors.New("db error"))
+		kit.mock.ExpectRollback()
+
+		err := repo.SaveDaily(ctx, []model.DailyViews{{ArticleID: articleID, Day: day, Views: 1}})
+		require.Error(t, err)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+}
+
+func TestViewRepository_Trending(t *testing.T) {
+	kit := initializeRepoTestKit(t)
+	defer kit.closer()
+
+	repo := NewViewRepository(kit.db, kit.cache)
+	ctx := context.TODO()
+	since := time.Date(2025, 8, 12, 9, 30, 0, 0, time.UTC)
+
+	t.Run("ordered by decayed score", func(t *testing.T) {
+		first, second := uuid.New(), uuid.New()
+		kit.mock.ExpectQuery(`SELECT v.article_id\s+FROM article_views v\s+WHERE v.day >= \$1::date .* ORDER BY SUM\(v.views \* POWER\(0.5, .*\)\) DESC`).
+			WithArgs("2025-08-12", sqlmock.AnyArg(), float64(86400), 10).
+			WillReturnRows(sqlmock.NewRows([]string{"article_id"}).AddRow(first).AddRow(second))
+
+		ids, err := repo.Trending(ctx, since, 24*time.Hour, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{first, second}, ids)
+		require.NoError(t, kit.mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		kit.mock.ExpectQuery("SELECT v.article_id").WillReturnError(errors.New("db error"))
+
+		_, err := repo.Trending(ctx, since, time.Hour, 10)
+		require.Error(t, err)
+	})
+}
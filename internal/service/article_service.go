@@ -2,9 +2,17 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/bagasss3/go-article/internal/config"
 	"github.com/bagasss3/go-article/internal/errors"
 	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
 	"github.com/bagasss3/go-article/pkg/model"
 	"github.com/sirupsen/logrus"
 
@@ -12,23 +20,65 @@ import (
 )
 
 type articleService struct {
-	articleRepository model.ArticleRepository
-	authorRepository  model.AuthorRepository
+	articleRepository  model.ArticleRepository
+	authorRepository   model.AuthorRepository
+	categoryRepository model.CategoryRepository
+	searchIndex        model.SearchIndex
+	events             model.ArticleEventHandler
 }
 
-func NewArticleService(articleRepository model.ArticleRepository, authorRepository model.AuthorRepository) model.ArticleMethodService {
+// NewArticleService returns the article service. Text searches go through searchIndex,
+// and events, when not nil, is told of every article written.
+func NewArticleService(articleRepository model.ArticleRepository, authorRepository model.AuthorRepository, categoryRepository model.CategoryRepository, searchIndex model.SearchIndex, events model.ArticleEventHandler) model.ArticleMethodService {
 	return &articleService{
-		articleRepository: articleRepository,
-		authorRepository:  authorRepository,
+		articleRepository:  articleRepository,
+		authorRepository:   authorRepository,
+		categoryRepository: categoryRepository,
+		searchIndex:        searchIndex,
+		events:             events,
 	}
 }
 
 func (s *articleService) FindAll(ctx context.Context, filter model.ArticleQuery) ([]*model.Article, int, error) {
-	log := logrus.WithFields(logrus.Fields{
+	ctx, span := tracing.Start(ctx, "articleService.FindAll")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
 		"filter": filter,
 	})
 
-	articles, total, err := s.articleRepository.FindAll(ctx, filter)
+	if err := validateFormat(filter.Format); err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	if err := validateView(filter.View); err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	fields, err := articleFields(filter.Fields, filter.View)
+	if err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+	filter.Fields = fields
+
+	if err := normalizeArticleFilter(&filter); err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	var (
+		articles []*model.Article
+		total    int
+	)
+	// The search index does not know bookmarks, so searches within them stay in SQL.
+	if filter.Query != "" && filter.BookmarkedBy == "" {
+		articles, total, err = s.search(ctx, filter)
+	} else {
+		articles, total, err = s.articleRepository.FindAll(ctx, filter)
+	}
 	if err != nil {
 		log.Error(err)
 		return nil, 0, err
@@ -38,11 +88,74 @@ func (s *articleService) FindAll(ctx context.Context, filter model.ArticleQuery)
 		return []*model.Article{}, 0, nil
 	}
 
+	if !hasField(filter.Fields, "body") {
+		return articles, total, nil
+	}
+
+	if err := formatArticles(filter.Format, articles...); err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
 	return articles, total, nil
 }
 
+// search finds the articles of a text query in the search index and reads them from the
+// database in the order of the hits.
+func (s *articleService) search(ctx context.Context, filter model.ArticleQuery) ([]*model.Article, int, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = config.DefaultArticleLimit
+	}
+	offset := 0
+	if filter.Page > 0 {
+		offset = (filter.Page - 1) * limit
+	}
+
+	result, err := s.searchIndex.Search(ctx, model.SearchIndexQuery{
+		Filter:    filter,
+		Offset:    offset,
+		Limit:     limit,
+		Highlight: hasField(filter.Fields, "highlights"),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(result.Hits) == 0 {
+		return nil, result.Total, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(result.Hits))
+	highlights := make(map[uuid.UUID]map[string][]string, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ID)
+		highlights[hit.ID] = hit.Highlights
+	}
+
+	articles, err := s.articleRepository.FindByIDs(ctx, ids, filter.Fields)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, article := range articles {
+		article.Highlights = highlights[article.ID]
+	}
+
+	return articles, result.Total, nil
+}
+
+// saved tells the event handler, if any, that an article was created or updated.
+func (s *articleService) saved(ctx context.Context, id uuid.UUID) {
+	if s.events == nil {
+		return
+	}
+	s.events.HandleArticleEvent(ctx, model.ArticleEvent{Type: model.ArticleSaved, ArticleID: id})
+}
+
 func (s *articleService) Create(ctx context.Context, req *model.CreateArticleRequest) (*model.Article, error) {
-	log := logrus.WithFields(logrus.Fields{
+	ctx, span := tracing.Start(ctx, "articleService.Create")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
 		"req": helper.ToJSON(req),
 	})
 
@@ -53,6 +166,13 @@ func (s *articleService) Create(ctx context.Context, req *model.CreateArticleReq
 		return nil, err
 	}
 
+	tags := normalizeTags(req.Tags)
+	if len(tags) > config.MaxArticleTags() {
+		err := errors.New(errors.ErrInvalidData, fmt.Sprintf("an article can have at most %d tags", config.MaxArticleTags()))
+		log.Error(err)
+		return nil, err
+	}
+
 	author, err := s.authorRepository.FindByID(ctx, authorID)
 	if err != nil {
 		log.Error(err)
@@ -65,18 +185,755 @@ func (s *articleService) Create(ctx context.Context, req *model.CreateArticleReq
 		return nil, err
 	}
 
+	article, err := s.newArticle(ctx, authorID, req, tags)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	result, err := s.articleRepository.Create(ctx, article)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	s.saved(ctx, result.ID)
+
+	// The stored HTML rendering is internal; the response carries the Markdown body.
+	if err := formatArticles(model.ArticleFormatMarkdown, result); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// newArticle builds an article of an existing author, resolving its language, the
+// article it translates, its category and slug and rendering its body.
+func (s *articleService) newArticle(ctx context.Context, authorID uuid.UUID, req *model.CreateArticleRequest, tags []string) (*model.Article, error) {
+	language, err := normalizeLanguage(req.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	translationID, err := s.resolveTranslation(ctx, req.TranslationOf, language)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryID, err := s.resolveCategory(ctx, req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := s.uniqueSlug(ctx, req.Title, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyHTML, err := helper.RenderMarkdown(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	article := &model.Article{
-		ID:       uuid.New(),
-		AuthorID: authorID,
-		Title:    req.Title,
-		Body:     req.Body,
+		ID:         uuid.New(),
+		AuthorID:   authorID,
+		Title:      req.Title,
+		Slug:       slug,
+		Body:       req.Body,
+		BodyHTML:   bodyHTML,
+		CategoryID: categoryID,
+		Language:   language,
+		// The repository starts a translation of its own when this is uuid.Nil.
+		TranslationID: translationID,
+		Tags:          tags,
+		Reactions:     map[string]int{},
+	}
+	summarize(article, req.Summary)
+
+	return article, nil
+}
+
+func (s *articleService) Import(ctx context.Context, rows model.ArticleImportReader) (*model.ArticleImportResult, error) {
+	ctx, span := tracing.Start(ctx, "articleService.Import")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	result := &model.ArticleImportResult{Errors: []model.ArticleImportError{}}
+	authors := make(map[string]uuid.UUID)
+	for {
+		row, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if rowErr, ok := err.(*model.ArticleImportError); ok {
+			result.Failed++
+			result.Errors = append(result.Errors, *rowErr)
+			continue
+		}
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		err = s.importRow(ctx, row, authors)
+		// Invalid rows are reported and skipped; anything else, such as the database
+		// going away, would fail every remaining row as well.
+		if custErr, ok := err.(*errors.CustomError); ok {
+			result.Failed++
+			result.Errors = append(result.Errors, model.ArticleImportError{
+				Row:     row.Row,
+				Source:  row.Source,
+				Message: custErr.MessageDeveloper,
+			})
+			continue
+		}
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"row":    row.Row,
+				"source": row.Source,
+			}).Error(err)
+			return nil, err
+		}
+		result.Imported++
+	}
+
+	log.WithFields(logrus.Fields{
+		"imported": result.Imported,
+		"failed":   result.Failed,
+	}).Info("articles imported")
+
+	return result, nil
+}
+
+// importRow creates the article of one import row. authors caches the ids of the
+// authors already matched or created by name.
+func (s *articleService) importRow(ctx context.Context, row *model.ArticleImportRow, authors map[string]uuid.UUID) error {
+	name := strings.TrimSpace(row.Author)
+	title := strings.TrimSpace(row.Title)
+	switch {
+	case len(name) < 3 || len(name) > 100:
+		return errors.New(errors.ErrInvalidData, "author must be between 3 and 100 characters")
+	case len(title) < 3 || len(title) > 255:
+		return errors.New(errors.ErrInvalidData, "title must be between 3 and 255 characters")
+	case strings.TrimSpace(row.Body) == "":
+		return errors.New(errors.ErrInvalidData, "body is required")
+	case len(row.Summary) > 500:
+		return errors.New(errors.ErrInvalidData, "summary must be at most 500 characters")
+	}
+
+	tags := normalizeTags(row.Tags)
+	if len(tags) > config.MaxArticleTags() {
+		return errors.New(errors.ErrInvalidData, fmt.Sprintf("an article can have at most %d tags", config.MaxArticleTags()))
+	}
+
+	authorID, ok := authors[name]
+	if !ok {
+		author, err := s.authorRepository.FindByName(ctx, name)
+		if err != nil {
+			return err
+		}
+		if author == nil {
+			author, err = s.authorRepository.Create(ctx, &model.Author{Name: name})
+			if err != nil {
+				return err
+			}
+		}
+		authorID = author.ID
+		authors[name] = authorID
+	}
+
+	article, err := s.newArticle(ctx, authorID, &model.CreateArticleRequest{
+		Title:      title,
+		Body:       row.Body,
+		Summary:    row.Summary,
+		CategoryID: strings.TrimSpace(row.CategoryID),
+		Language:   row.Language,
+	}, tags)
+	if err != nil {
+		return err
+	}
+	if row.CreatedAt != nil {
+		article.CreatedAt = *row.CreatedAt
 	}
 
 	result, err := s.articleRepository.Create(ctx, article)
+	if err != nil {
+		return err
+	}
+
+	s.saved(ctx, result.ID)
+
+	return nil
+}
+
+func (s *articleService) Export(ctx context.Context, filter model.ArticleQuery, w model.ArticleExportWriter) error {
+	ctx, span := tracing.Start(ctx, "articleService.Export")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"filter": filter,
+	})
+
+	if err := normalizeArticleFilter(&filter); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := s.articleRepository.Stream(ctx, filter, w.Write); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *articleService) FindByID(ctx context.Context, id string, opts model.ArticleReadOptions) (*model.Article, error) {
+	ctx, span := tracing.Start(ctx, "articleService.FindByID")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"article_id": id,
+	})
+
+	if err := validateFormat(opts.Format); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	fields, err := validateFields(opts.Fields, model.ArticleFields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	article, err := s.findByID(ctx, id)
 	if err != nil {
 		log.Error(err)
 		return nil, err
 	}
 
+	article, err = s.translate(ctx, article, opts.Languages, fields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := formatArticles(opts.Format, article); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return article, nil
+}
+
+// translate returns the translation of article in the first of languages it is written
+// in, or article itself when none is, listing the other languages it is available in
+// when fields include translations.
+func (s *articleService) translate(ctx context.Context, article *model.Article, languages, fields []string) (*model.Article, error) {
+	if len(languages) == 0 && !hasField(fields, "translations") {
+		return article, nil
+	}
+
+	translations, err := s.articleRepository.FindTranslations(ctx, article.TranslationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, language := range languages {
+		if language == article.Language {
+			break
+		}
+		i := slices.IndexFunc(translations, func(t *model.ArticleTranslation) bool {
+			return t.Language == language
+		})
+		if i < 0 {
+			continue
+		}
+		translated, err := s.articleRepository.FindByID(ctx, translations[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		if translated != nil {
+			article = translated
+			break
+		}
+	}
+
+	article.Translations = slices.DeleteFunc(translations, func(t *model.ArticleTranslation) bool {
+		return t.ID == article.ID
+	})
+
+	return article, nil
+}
+
+// findByID returns the stored article without applying a representation.
+func (s *articleService) findByID(ctx context.Context, id string) (*model.Article, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New(errors.ErrInvalidData, "invalid article ID format")
+	}
+
+	article, err := s.articleRepository.FindByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if article == nil {
+		return nil, errors.New(errors.ErrRecordNotFound, "article not found")
+	}
+
+	return article, nil
+}
+
+func (s *articleService) FindRelated(ctx context.Context, id string, query model.RelatedQuery) ([]*model.Article, error) {
+	ctx, span := tracing.Start(ctx, "articleService.FindRelated")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"article_id": id,
+		"query":      query,
+	})
+
+	fields, err := validateFields(query.Fields, model.ArticleFields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if slices.Contains(fields, "body") || slices.Contains(fields, "format") {
+		err := errors.New(errors.ErrInvalidData, "related articles are listed without their body")
+		log.Error(err)
+		return nil, err
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = config.DefaultRelatedLimit
+	}
+	query.Limit = min(query.Limit, config.MaxRelatedLimit)
+
+	article, err := s.findByID(ctx, id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	related, err := s.articleRepository.FindRelated(ctx, article.ID, query.Limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return related, nil
+}
+
+func (s *articleService) FindBySlug(ctx context.Context, slug string, opts model.ArticleReadOptions) (*model.Article, string, error) {
+	ctx, span := tracing.Start(ctx, "articleService.FindBySlug")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"slug": slug,
+	})
+
+	if err := validateFormat(opts.Format); err != nil {
+		log.Error(err)
+		return nil, "", err
+	}
+
+	fields, err := validateFields(opts.Fields, model.ArticleFields)
+	if err != nil {
+		log.Error(err)
+		return nil, "", err
+	}
+
+	article, err := s.articleRepository.FindBySlug(ctx, slug)
+	if err != nil {
+		log.Error(err)
+		return nil, "", err
+	}
+
+	if article != nil {
+		// A translation is served at the slug that was asked for, which stays canonical.
+		canonical := article.Slug
+		article, err = s.translate(ctx, article, opts.Languages, fields)
+		if err != nil {
+			log.Error(err)
+			return nil, "", err
+		}
+		if err := formatArticles(opts.Format, article); err != nil {
+			log.Error(err)
+			return nil, "", err
+		}
+		return article, canonical, nil
+	}
+
+	canonical, err := s.articleRepository.FindSlugRedirect(ctx, slug)
+	if err != nil {
+		log.Error(err)
+		return nil, "", err
+	}
+
+	if canonical == "" {
+		err := errors.New(errors.ErrRecordNotFound, "article not found")
+		log.Error(err)
+		return nil, "", err
+	}
+
+	return nil, canonical, nil
+}
+
+func (s *articleService) Update(ctx context.Context, id string, req *model.UpdateArticleRequest) (*model.Article, error) {
+	ctx, span := tracing.Start(ctx, "articleService.Update")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"article_id": id,
+		"req":        helper.ToJSON(req),
+	})
+
+	tags := normalizeTags(req.Tags)
+	if len(tags) > config.MaxArticleTags() {
+		err := errors.New(errors.ErrInvalidData, fmt.Sprintf("an article can have at most %d tags", config.MaxArticleTags()))
+		log.Error(err)
+		return nil, err
+	}
+
+	article, err := s.findByID(ctx, id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	categoryID, err := s.resolveCategory(ctx, req.CategoryID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// Only a title that slugifies differently moves the article to a new slug.
+	if helper.Slugify(req.Title) != helper.Slugify(article.Title) {
+		slug, err := s.uniqueSlug(ctx, req.Title, article.ID)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		article.Slug = slug
+	}
+
+	bodyHTML, err := helper.RenderMarkdown(req.Body)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	article.Title = req.Title
+	article.Body = req.Body
+	article.BodyHTML = bodyHTML
+	article.CategoryID = categoryID
+	article.Tags = tags
+	summarize(article, req.Summary)
+
+	result, err := s.articleRepository.Update(ctx, article)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	s.saved(ctx, result.ID)
+
+	if err := formatArticles(model.ArticleFormatMarkdown, result); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if result.Tags == nil {
+		result.Tags = []string{}
+	}
+
 	return result, nil
 }
+
+// resolveCategory parses an optional category id and checks that the category exists.
+func (s *articleService) resolveCategory(ctx context.Context, id string) (*uuid.UUID, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	categoryID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New(errors.ErrInvalidData, "invalid category id format")
+	}
+
+	category, err := s.categoryRepository.FindByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, errors.New(errors.ErrRecordNotFound, "category not found")
+	}
+
+	return &categoryID, nil
+}
+
+// resolveTranslation returns the translation ID shared with the article of id, which a
+// new article in language translates, or uuid.Nil when id is empty.
+func (s *articleService) resolveTranslation(ctx context.Context, id, language string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, nil
+	}
+
+	articleID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, errors.New(errors.ErrInvalidData, "invalid translation_of format")
+	}
+
+	original, err := s.articleRepository.FindByID(ctx, articleID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if original == nil {
+		return uuid.Nil, errors.New(errors.ErrRecordNotFound, "translated article not found")
+	}
+
+	translations, err := s.articleRepository.FindTranslations(ctx, original.TranslationID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	for _, translation := range translations {
+		if translation.Language == language {
+			return uuid.Nil, errors.New(errors.ErrDuplicate, fmt.Sprintf("the article is already translated to %s", language))
+		}
+	}
+
+	return original.TranslationID, nil
+}
+
+// uniqueSlug derives a slug from title, suffixing it with a number when another
+// article uses or used the same slug.
+func (s *articleService) uniqueSlug(ctx context.Context, title string, articleID uuid.UUID) (string, error) {
+	base := helper.Slugify(title)
+	if base == "" {
+		base = model.ArticleKey
+	}
+
+	taken, err := s.articleRepository.FindSlugs(ctx, base, articleID)
+	if err != nil {
+		return "", err
+	}
+
+	return helper.UniqueSlug(base, taken), nil
+}
+
+func validateFormat(format string) error {
+	switch format {
+	case "", model.ArticleFormatMarkdown, model.ArticleFormatHTML, model.ArticleFormatText:
+		return nil
+	}
+	return errors.New(errors.ErrInvalidData, fmt.Sprintf("format must be one of %s, %s or %s",
+		model.ArticleFormatMarkdown, model.ArticleFormatHTML, model.ArticleFormatText))
+}
+
+func validateView(view string) error {
+	switch view {
+	case "", model.ArticleViewFull, model.ArticleViewSummary:
+		return nil
+	}
+	return errors.New(errors.ErrInvalidData, fmt.Sprintf("view must be %s or %s",
+		model.ArticleViewFull, model.ArticleViewSummary))
+}
+
+// normalizeArticleFilter validates a list query like normalizeListFilter and brings
+// its category and tags into slug form.
+func normalizeArticleFilter(filter *model.ArticleQuery) error {
+	if err := normalizeListFilter(filter); err != nil {
+		return err
+	}
+
+	if filter.Lang != "" {
+		language, err := normalizeLanguage(filter.Lang)
+		if err != nil {
+			return err
+		}
+		filter.Lang = language
+	}
+
+	filter.Category = helper.Slugify(filter.Category)
+	filter.Tag = helper.Slugify(filter.Tag)
+	filter.TagsAny = normalizeTags(filter.TagsAny)
+	filter.TagsAll = normalizeTags(filter.TagsAll)
+
+	return nil
+}
+
+// normalizeLanguage checks that language is one of model.ArticleLanguages, defaulting
+// to config.DefaultArticleLanguage when empty.
+func normalizeLanguage(language string) (string, error) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" {
+		return config.DefaultArticleLanguage, nil
+	}
+	if !slices.Contains(model.ArticleLanguages, language) {
+		return "", errors.New(errors.ErrInvalidData, fmt.Sprintf("language must be one of %s", strings.Join(model.ArticleLanguages, ", ")))
+	}
+	return language, nil
+}
+
+// normalizeListFilter validates the sort, creation date range and author ids of a list
+// query and brings the author ids into canonical form.
+func normalizeListFilter(filter *model.ArticleQuery) error {
+	if filter.Sort != "" && !slices.Contains(model.ArticleSorts, filter.Sort) {
+		return errors.New(errors.ErrInvalidData, fmt.Sprintf("sort must be one of %s", strings.Join(model.ArticleSorts, ", ")))
+	}
+
+	var from, to time.Time
+	if filter.CreatedFrom != "" {
+		t, err := helper.ParseTime(filter.CreatedFrom)
+		if err != nil {
+			return errors.New(errors.ErrInvalidData, "created_from must be formatted as 2006-01-02T15:04")
+		}
+		from = t
+	}
+	if filter.CreatedTo != "" {
+		t, err := helper.ParseTime(filter.CreatedTo)
+		if err != nil {
+			return errors.New(errors.ErrInvalidData, "created_to must be formatted as 2006-01-02T15:04")
+		}
+		to = t
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return errors.New(errors.ErrInvalidData, "created_from must not be after created_to")
+	}
+
+	var authorIDs []string
+	for _, value := range filter.AuthorIDs {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			id, err := uuid.Parse(part)
+			if err != nil {
+				return errors.New(errors.ErrInvalidData, "invalid author id format")
+			}
+			if !slices.Contains(authorIDs, id.String()) {
+				authorIDs = append(authorIDs, id.String())
+			}
+		}
+	}
+	filter.AuthorIDs = authorIDs
+
+	return nil
+}
+
+// validateFields normalizes raw field names and checks them against allowed.
+func validateFields(raw []string, allowed []string) ([]string, error) {
+	fields := helper.ParseFields(raw)
+	for _, field := range fields {
+		if !slices.Contains(allowed, field) {
+			return nil, errors.New(errors.ErrInvalidData, fmt.Sprintf("unknown field %q, allowed fields are %s",
+				field, strings.Join(allowed, ", ")))
+		}
+	}
+	return fields, nil
+}
+
+// articleFields resolves the fields to read for a list; the summary view drops the body
+// from the requested (or the full) field set.
+func articleFields(raw []string, view string) ([]string, error) {
+	fields, err := validateFields(raw, model.ArticleFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if view != model.ArticleViewSummary {
+		return fields, nil
+	}
+
+	if len(fields) == 0 {
+		fields = model.ArticleFields
+	}
+
+	summary := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field != "body" && field != "format" {
+			summary = append(summary, field)
+		}
+	}
+	if len(summary) == 0 {
+		summary = append(summary, "id")
+	}
+	return summary, nil
+}
+
+// hasField reports whether field is part of fields, where no fields means all of them.
+func hasField(fields []string, field string) bool {
+	return len(fields) == 0 || slices.Contains(fields, field)
+}
+
+// summarize derives the excerpt, word count and reading time from the rendered body.
+// A non-empty summary written by the author replaces the generated excerpt.
+func summarize(article *model.Article, summary string) {
+	text := helper.PlainText(article.BodyHTML)
+	article.WordCount = helper.WordCount(text)
+	article.ReadingTime = helper.ReadingTime(article.WordCount, config.WordsPerMinute())
+	article.Excerpt = helper.Excerpt(text, config.ExcerptLength())
+	if summary = strings.TrimSpace(summary); summary != "" {
+		article.Excerpt = summary
+	}
+}
+
+// formatArticles replaces Body with the requested representation; Markdown is the default.
+func formatArticles(format string, articles ...*model.Article) error {
+	if format == "" {
+		format = model.ArticleFormatMarkdown
+	}
+
+	for _, a := range articles {
+		if format != model.ArticleFormatMarkdown && a.BodyHTML == "" {
+			// Articles stored before HTML rendering existed.
+			rendered, err := helper.RenderMarkdown(a.Body)
+			if err != nil {
+				return err
+			}
+			a.BodyHTML = rendered
+		}
+
+		switch format {
+		case model.ArticleFormatHTML:
+			a.Body = a.BodyHTML
+		case model.ArticleFormatText:
+			a.Body = helper.PlainText(a.BodyHTML)
+		}
+		a.BodyHTML = ""
+		a.Format = format
+	}
+
+	return nil
+}
+
+// normalizeTags turns raw tag input (repeated or comma separated) into unique lowercase slugs.
+func normalizeTags(raw []string) []string {
+	var (
+		tags []string
+		seen = make(map[string]bool)
+	)
+	for _, value := range raw {
+		for _, part := range strings.Split(value, ",") {
+			slug := helper.Slugify(part)
+			if slug == "" || seen[slug] {
+				continue
+			}
+			seen[slug] = true
+			tags = append(tags, slug)
+		}
+	}
+	return tags
+}
@@ -3,9 +3,13 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/bagasss3/go-article/internal/config"
 	customErrors "github.com/bagasss3/go-article/internal/errors"
 	"github.com/bagasss3/go-article/internal/mocks"
 	"github.com/bagasss3/go-article/pkg/model"
@@ -16,7 +20,7 @@ import (
 )
 
 func TestNewArticleService(t *testing.T) {
-	s := NewArticleService(nil, nil)
+	s := NewArticleService(nil, nil, nil, nil, nil)
 	require.NotNil(t, s)
 }
 
@@ -27,10 +31,12 @@ func TestArticleService_Create(t *testing.T) {
 	ctx := context.TODO()
 	mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
 	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+	mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
 
 	articleService := &articleService{
-		authorRepository:  mockAuthorRepo,
-		articleRepository: mockArticleRepo,
+		authorRepository:   mockAuthorRepo,
+		articleRepository:  mockArticleRepo,
+		categoryRepository: mockCategoryRepo,
 	}
 
 	t.Run("invalid author id format", func(t *testing.T) {
@@ -91,6 +97,10 @@ func TestArticleService_Create(t *testing.T) {
 			FindByID(gomock.Any(), authorID).
 			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
 
+		mockArticleRepo.EXPECT().
+			FindSlugs(gomock.Any(), "some-title", uuid.Nil).
+			Return(nil, nil)
+
 		mockArticleRepo.EXPECT().
 			Create(gomock.Any(), gomock.Any()).
 			Return(nil, errors.New("insert error"))
@@ -107,6 +117,111 @@ func TestArticleService_Create(t *testing.T) {
 		assert.Nil(t, res)
 	})
 
+	t.Run("category not found", func(t *testing.T) {
+		authorID, categoryID := uuid.New(), uuid.New()
+
+		mockAuthorRepo.EXPECT().
+			FindByID(gomock.Any(), authorID).
+			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
+
+		mockCategoryRepo.EXPECT().
+			FindByID(gomock.Any(), categoryID).
+			Return(nil, nil)
+
+		req := &model.CreateArticleRequest{
+			AuthorID:   authorID.String(),
+			CategoryID: categoryID.String(),
+			Title:      "Some Title",
+			Body:       "Some Body",
+		}
+
+		res, err := articleService.Create(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
+		assert.Nil(t, res)
+	})
+
+	t.Run("success with category", func(t *testing.T) {
+		authorID, categoryID := uuid.New(), uuid.New()
+
+		mockAuthorRepo.EXPECT().
+			FindByID(gomock.Any(), authorID).
+			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
+
+		mockCategoryRepo.EXPECT().
+			FindByID(gomock.Any(), categoryID).
+			Return(&model.Category{ID: categoryID, Name: "Go", Slug: "go"}, nil)
+
+		mockArticleRepo.EXPECT().
+			FindSlugs(gomock.Any(), "some-title", uuid.Nil).
+			Return(nil, nil)
+
+		mockArticleRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				return a, nil
+			})
+
+		req := &model.CreateArticleRequest{
+			AuthorID:   authorID.String(),
+			CategoryID: categoryID.String(),
+			Title:      "Some Title",
+			Body:       "Some Body",
+		}
+
+		res, err := articleService.Create(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, categoryID, *res.CategoryID)
+	})
+
+	t.Run("too many tags", func(t *testing.T) {
+		tags := make([]string, 0, 11)
+		for i := 0; i < 11; i++ {
+			tags = append(tags, fmt.Sprintf("tag-%d", i))
+		}
+
+		req := &model.CreateArticleRequest{
+			AuthorID: uuid.New().String(),
+			Title:    "Some Title",
+			Body:     "Some Body",
+			Tags:     tags,
+		}
+
+		res, err := articleService.Create(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+		assert.Nil(t, res)
+	})
+
+	t.Run("success with normalized tags", func(t *testing.T) {
+		authorID := uuid.New()
+
+		mockAuthorRepo.EXPECT().
+			FindByID(gomock.Any(), authorID).
+			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
+
+		mockArticleRepo.EXPECT().
+			FindSlugs(gomock.Any(), "some-title", uuid.Nil).
+			Return(nil, nil)
+
+		mockArticleRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				return a, nil
+			})
+
+		req := &model.CreateArticleRequest{
+			AuthorID: authorID.String(),
+			Title:    "Some Title",
+			Body:     "Some Body",
+			Tags:     []string{"Go Lang", "go-lang", "Databases,SQL"},
+		}
+
+		res, err := articleService.Create(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"go-lang", "databases", "sql"}, res.Tags)
+	})
+
 	t.Run("success", func(t *testing.T) {
 		authorID := uuid.New()
 
@@ -114,6 +229,10 @@ func TestArticleService_Create(t *testing.T) {
 			FindByID(gomock.Any(), authorID).
 			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
 
+		mockArticleRepo.EXPECT().
+			FindSlugs(gomock.Any(), "some-title", uuid.Nil).
+			Return(nil, nil)
+
 		mockArticleRepo.EXPECT().
 			Create(gomock.Any(), gomock.Any()).
 			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
@@ -143,10 +262,12 @@ func TestArticleService_FindAll(t *testing.T) {
 	ctx := context.TODO()
 	mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
 	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+	mockSearchIndex := mocks.NewMockSearchIndex(ctrl)
 
 	articleService := &articleService{
 		authorRepository:  mockAuthorRepo,
 		articleRepository: mockArticleRepo,
+		searchIndex:       mockSearchIndex,
 	}
 
 	t.Run("success", func(t *testing.T) {
@@ -166,7 +287,6 @@ func TestArticleService_FindAll(t *testing.T) {
 			Return(expected, 1, nil)
 
 		res, total, err := articleService.FindAll(ctx, model.ArticleQuery{
-			Query: "keyword",
 			Page:  1,
 			Limit: 10,
 		})
@@ -176,6 +296,86 @@ func TestArticleService_FindAll(t *testing.T) {
 		assert.Equal(t, expected, res)
 	})
 
+	t.Run("search goes through the index", func(t *testing.T) {
+		first, second, gone := uuid.New(), uuid.New(), uuid.New()
+		mockSearchIndex.EXPECT().
+			Search(gomock.Any(), model.SearchIndexQuery{
+				Filter: model.ArticleQuery{Query: "keyword", Page: 3, Limit: 5, Sort: "relevance",
+					ArticleReadOptions: model.ArticleReadOptions{Fields: []string{"title", "highlights"}}},
+				Offset:    10,
+				Limit:     5,
+				Highlight: true,
+			}).
+			Return(&model.SearchResult{Total: 13, Hits: []*model.SearchHit{
+				{ID: second, Highlights: map[string][]string{"title": {"<mark>Keyword</mark>"}}},
+				{ID: gone},
+				{ID: first},
+			}}, nil)
+		mockArticleRepo.EXPECT().
+			FindByIDs(gomock.Any(), []uuid.UUID{second, gone, first}, []string{"title", "highlights"}).
+			Return([]*model.Article{{ID: second, Title: "Keyword"}, {ID: first, Title: "Other"}}, nil)
+
+		res, total, err := articleService.FindAll(ctx, model.ArticleQuery{
+			Query: "keyword", Page: 3, Limit: 5, Sort: "relevance",
+			ArticleReadOptions: model.ArticleReadOptions{Fields: []string{"title,highlights"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 13, total)
+		require.Len(t, res, 2)
+		assert.Equal(t, []string{"<mark>Keyword</mark>"}, res[0].Highlights["title"])
+		assert.Nil(t, res[1].Highlights)
+	})
+
+	t.Run("search without hits", func(t *testing.T) {
+		mockSearchIndex.EXPECT().
+			Search(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query model.SearchIndexQuery) (*model.SearchResult, error) {
+				assert.Equal(t, config.DefaultArticleLimit, query.Limit)
+				assert.True(t, query.Highlight)
+				return &model.SearchResult{Hits: []*model.SearchHit{}}, nil
+			})
+
+		res, total, err := articleService.FindAll(ctx, model.ArticleQuery{Query: "keyword"})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+		assert.Zero(t, total)
+	})
+
+	t.Run("search index error", func(t *testing.T) {
+		mockSearchIndex.EXPECT().Search(gomock.Any(), gomock.Any()).Return(nil, errors.New("index error"))
+
+		_, _, err := articleService.FindAll(ctx, model.ArticleQuery{Query: "keyword"})
+		assert.Error(t, err)
+	})
+
+	t.Run("searching bookmarks stays in the database", func(t *testing.T) {
+		mockArticleRepo.EXPECT().
+			FindAll(gomock.Any(), model.ArticleQuery{Query: "keyword", BookmarkedBy: "user-1"}).
+			Return(nil, 0, nil)
+
+		_, _, err := articleService.FindAll(ctx, model.ArticleQuery{Query: "keyword", BookmarkedBy: "user-1"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("normalizes tag filters", func(t *testing.T) {
+		mockArticleRepo.EXPECT().
+			FindAll(gomock.Any(), model.ArticleQuery{
+				Tag:     "go-lang",
+				TagsAny: []string{"sql", "nosql"},
+				TagsAll: []string{"web"},
+				Page:    1,
+			}).
+			Return(nil, 0, nil)
+
+		_, _, err := articleService.FindAll(ctx, model.ArticleQuery{
+			Tag:     "Go Lang",
+			TagsAny: []string{"SQL,NoSQL"},
+			TagsAll: []string{"Web", "web"},
+			Page:    1,
+		})
+		assert.NoError(t, err)
+	})
+
 	t.Run("empty articles", func(t *testing.T) {
 		expected := []*model.Article{}
 		mockArticleRepo.EXPECT().
@@ -183,7 +383,6 @@ func TestArticleService_FindAll(t *testing.T) {
 			Return(nil, 0, nil)
 
 		res, total, err := articleService.FindAll(ctx, model.ArticleQuery{
-			Query: "keyword",
 			Page:  1,
 			Limit: 10,
 		})
@@ -199,7 +398,6 @@ func TestArticleService_FindAll(t *testing.T) {
 			Return(nil, 0, errors.New("repo error"))
 
 		res, total, err := articleService.FindAll(ctx, model.ArticleQuery{
-			Query: "keyword",
 			Page:  1,
 			Limit: 10,
 		})
@@ -209,3 +407,751 @@ func TestArticleService_FindAll(t *testing.T) {
 		assert.Equal(t, 0, total)
 	})
 }
+
+func TestArticleService_Slugs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+
+	articleService := &articleService{
+		authorRepository:  mockAuthorRepo,
+		articleRepository: mockArticleRepo,
+	}
+
+	t.Run("create adds suffix on collision", func(t *testing.T) {
+		authorID := uuid.New()
+
+		mockAuthorRepo.EXPECT().
+			FindByID(gomock.Any(), authorID).
+			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
+
+		mockArticleRepo.EXPECT().
+			FindSlugs(gomock.Any(), "cafe-creme", uuid.Nil).
+			Return([]string{"cafe-creme", "cafe-creme-2"}, nil)
+
+		mockArticleRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				return a, nil
+			})
+
+		res, err := articleService.Create(ctx, &model.CreateArticleRequest{
+			AuthorID: authorID.String(),
+			Title:    "Café Crème",
+			Body:     "Body",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "cafe-creme-3", res.Slug)
+	})
+
+	t.Run("find by current slug", func(t *testing.T) {
+		expected := &model.Article{ID: uuid.New(), Slug: "hello"}
+		mockArticleRepo.EXPECT().FindBySlug(gomock.Any(), "hello").Return(expected, nil)
+		mockArticleRepo.EXPECT().FindTranslations(gomock.Any(), expected.TranslationID).Return(nil, nil)
+
+		res, canonical, err := articleService.FindBySlug(ctx, "hello", model.ArticleReadOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, res)
+		assert.Equal(t, "hello", canonical)
+	})
+
+	t.Run("find by historical slug", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindBySlug(gomock.Any(), "old").Return(nil, nil)
+		mockArticleRepo.EXPECT().FindSlugRedirect(gomock.Any(), "old").Return("new", nil)
+
+		res, canonical, err := articleService.FindBySlug(ctx, "old", model.ArticleReadOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, res)
+		assert.Equal(t, "new", canonical)
+	})
+
+	t.Run("unknown slug", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindBySlug(gomock.Any(), "nope").Return(nil, nil)
+		mockArticleRepo.EXPECT().FindSlugRedirect(gomock.Any(), "nope").Return("", nil)
+
+		_, _, err := articleService.FindBySlug(ctx, "nope", model.ArticleReadOptions{})
+		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
+	})
+}
+
+func TestArticleService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+
+	articleService := &articleService{articleRepository: mockArticleRepo}
+	id := uuid.New()
+
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := articleService.Update(ctx, "bad", &model.UpdateArticleRequest{Title: "Title", Body: "Body"})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), id).Return(nil, nil)
+
+		_, err := articleService.Update(ctx, id.String(), &model.UpdateArticleRequest{Title: "Title", Body: "Body"})
+		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
+	})
+
+	t.Run("title change moves slug", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), id).
+			Return(&model.Article{ID: id, Title: "Old Title", Slug: "old-title"}, nil)
+		mockArticleRepo.EXPECT().FindSlugs(gomock.Any(), "new-title", id).Return(nil, nil)
+		mockArticleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				return a, nil
+			})
+
+		res, err := articleService.Update(ctx, id.String(), &model.UpdateArticleRequest{Title: "New Title", Body: "Body", Tags: []string{"Go"}})
+		require.NoError(t, err)
+		assert.Equal(t, "new-title", res.Slug)
+		assert.Equal(t, []string{"go"}, res.Tags)
+	})
+
+	t.Run("cosmetic title change keeps slug", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), id).
+			Return(&model.Article{ID: id, Title: "Old Title", Slug: "old-title-2"}, nil)
+		mockArticleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				return a, nil
+			})
+
+		res, err := articleService.Update(ctx, id.String(), &model.UpdateArticleRequest{Title: "Old title!", Body: "Body"})
+		require.NoError(t, err)
+		assert.Equal(t, "old-title-2", res.Slug)
+		assert.Equal(t, []string{}, res.Tags)
+	})
+
+	t.Run("publishes saved event", func(t *testing.T) {
+		mockEvents := mocks.NewMockArticleEventHandler(ctrl)
+		articleService.events = mockEvents
+		defer func() { articleService.events = nil }()
+
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), id).
+			Return(&model.Article{ID: id, Title: "Title", Slug: "title"}, nil)
+		mockArticleRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				return a, nil
+			})
+		mockEvents.EXPECT().HandleArticleEvent(gomock.Any(), model.ArticleEvent{Type: model.ArticleSaved, ArticleID: id})
+
+		_, err := articleService.Update(ctx, id.String(), &model.UpdateArticleRequest{Title: "Title", Body: "New body"})
+		require.NoError(t, err)
+	})
+}
+
+func TestArticleService_FindRelated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+
+	articleService := &articleService{articleRepository: mockArticleRepo}
+	id := uuid.New()
+
+	t.Run("default limit", func(t *testing.T) {
+		related := []*model.Article{{ID: uuid.New(), Title: "Related"}}
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), id).Return(&model.Article{ID: id}, nil)
+		mockArticleRepo.EXPECT().FindRelated(gomock.Any(), id, config.DefaultRelatedLimit).Return(related, nil)
+
+		res, err := articleService.FindRelated(ctx, id.String(), model.RelatedQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, related, res)
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), id).Return(&model.Article{ID: id}, nil)
+		mockArticleRepo.EXPECT().FindRelated(gomock.Any(), id, config.MaxRelatedLimit).Return([]*model.Article{}, nil)
+
+		_, err := articleService.FindRelated(ctx, id.String(), model.RelatedQuery{Limit: 500})
+		require.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), id).Return(nil, nil)
+
+		_, err := articleService.FindRelated(ctx, id.String(), model.RelatedQuery{})
+		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := articleService.FindRelated(ctx, "bad", model.RelatedQuery{})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+
+		_, err = articleService.FindRelated(ctx, id.String(), model.RelatedQuery{Fields: []string{"title,body"}})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+}
+
+func TestArticleService_Format(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+
+	articleService := &articleService{
+		authorRepository:  mockAuthorRepo,
+		articleRepository: mockArticleRepo,
+	}
+
+	stored := func() []*model.Article {
+		return []*model.Article{{
+			ID:       uuid.New(),
+			Body:     "# Title\n\nSome **bold** text",
+			BodyHTML: "<h1>Title</h1>\n<p>Some <strong>bold</strong> text</p>\n",
+		}}
+	}
+
+	t.Run("create stores sanitized html", func(t *testing.T) {
+		authorID := uuid.New()
+
+		mockAuthorRepo.EXPECT().
+			FindByID(gomock.Any(), authorID).
+			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
+
+		mockArticleRepo.EXPECT().
+			FindSlugs(gomock.Any(), gomock.Any(), uuid.Nil).
+			Return(nil, nil)
+
+		var stored string
+		mockArticleRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				stored = a.BodyHTML
+				return a, nil
+			})
+
+		res, err := articleService.Create(ctx, &model.CreateArticleRequest{
+			AuthorID: authorID.String(),
+			Title:    "Markdown",
+			Body:     "| a |\n|---|\n| 1 |\n\n<script>alert(1)</script>\n\n[x](javascript:alert(1))",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, stored, "<table>")
+		assert.NotContains(t, stored, "<script>")
+		assert.NotContains(t, stored, "javascript:")
+		// The rendering stays internal.
+		assert.Empty(t, res.BodyHTML)
+		assert.Equal(t, model.ArticleFormatMarkdown, res.Format)
+	})
+
+	t.Run("markdown by default", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(stored(), 1, nil)
+
+		res, _, err := articleService.FindAll(ctx, model.ArticleQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, "# Title\n\nSome **bold** text", res[0].Body)
+		assert.Empty(t, res[0].BodyHTML)
+		assert.Equal(t, model.ArticleFormatMarkdown, res[0].Format)
+	})
+
+	t.Run("html", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(stored(), 1, nil)
+
+		res, _, err := articleService.FindAll(ctx, model.ArticleQuery{ArticleReadOptions: model.ArticleReadOptions{Format: "html"}})
+		require.NoError(t, err)
+		assert.Equal(t, "<h1>Title</h1>\n<p>Some <strong>bold</strong> text</p>\n", res[0].Body)
+	})
+
+	t.Run("text renders legacy rows", func(t *testing.T) {
+		legacy := stored()
+		legacy[0].BodyHTML = ""
+		mockArticleRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(legacy, 1, nil)
+
+		res, _, err := articleService.FindAll(ctx, model.ArticleQuery{ArticleReadOptions: model.ArticleReadOptions{Format: "text"}})
+		require.NoError(t, err)
+		assert.Equal(t, "Title Some bold text", res[0].Body)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, _, err := articleService.FindAll(ctx, model.ArticleQuery{ArticleReadOptions: model.ArticleReadOptions{Format: "pdf"}})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+}
+
+func TestArticleService_Summary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+
+	articleService := &articleService{
+		authorRepository:  mockAuthorRepo,
+		articleRepository: mockArticleRepo,
+	}
+
+	create := func(req *model.CreateArticleRequest) (*model.Article, error) {
+		authorID := uuid.New()
+		req.AuthorID = authorID.String()
+
+		mockAuthorRepo.EXPECT().
+			FindByID(gomock.Any(), authorID).
+			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
+		mockArticleRepo.EXPECT().
+			FindSlugs(gomock.Any(), gomock.Any(), uuid.Nil).
+			Return(nil, nil)
+		mockArticleRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				return a, nil
+			})
+
+		return articleService.Create(ctx, req)
+	}
+
+	t.Run("derived from body", func(t *testing.T) {
+		body := "# Heading\n\n" + strings.Repeat("A fairly ordinary sentence with words. ", 60)
+
+		res, err := create(&model.CreateArticleRequest{Title: "Long read", Body: body})
+		require.NoError(t, err)
+		assert.Equal(t, 361, res.WordCount)
+		assert.Equal(t, 2, res.ReadingTime)
+		assert.True(t, strings.HasPrefix(res.Excerpt, "Heading A fairly ordinary sentence"))
+		assert.True(t, strings.HasSuffix(res.Excerpt, "."))
+		assert.LessOrEqual(t, len([]rune(res.Excerpt)), 200)
+	})
+
+	t.Run("explicit summary", func(t *testing.T) {
+		res, err := create(&model.CreateArticleRequest{Title: "Short", Body: "Body text", Summary: " Written by hand. "})
+		require.NoError(t, err)
+		assert.Equal(t, "Written by hand.", res.Excerpt)
+		assert.Equal(t, 2, res.WordCount)
+		assert.Equal(t, 1, res.ReadingTime)
+	})
+
+	t.Run("summary view keeps stored excerpt", func(t *testing.T) {
+		filter := model.ArticleQuery{View: model.ArticleViewSummary}
+		expected := filter
+		expected.Fields = []string{"id", "author_id", "author", "title", "slug", "excerpt",
+			"word_count", "reading_time", "comment_count", "view_count", "reactions", "category_id", "tags", "created_at", "updated_at", "highlights",
+			"language", "translation_id", "translations"}
+		mockArticleRepo.EXPECT().
+			FindAll(gomock.Any(), expected).
+			Return([]*model.Article{{ID: uuid.New(), Excerpt: "Stored."}}, 1, nil)
+
+		res, _, err := articleService.FindAll(ctx, filter)
+		require.NoError(t, err)
+		assert.Empty(t, res[0].Body)
+		assert.Empty(t, res[0].Format)
+		assert.Equal(t, "Stored.", res[0].Excerpt)
+	})
+
+	t.Run("invalid view", func(t *testing.T) {
+		_, _, err := articleService.FindAll(ctx, model.ArticleQuery{View: "compact"})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+}
+
+func TestArticleService_Fields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+
+	articleService := &articleService{articleRepository: mockArticleRepo}
+
+	t.Run("normalized and passed to the repository", func(t *testing.T) {
+		mockArticleRepo.EXPECT().
+			FindAll(gomock.Any(), model.ArticleQuery{ArticleReadOptions: model.ArticleReadOptions{Fields: []string{"id", "title", "created_at"}}}).
+			Return([]*model.Article{{ID: uuid.New(), Title: "Title"}}, 1, nil)
+
+		res, _, err := articleService.FindAll(ctx, model.ArticleQuery{ArticleReadOptions: model.ArticleReadOptions{
+			Fields: []string{"id, Title", "created_at", "id"},
+		}})
+		require.NoError(t, err)
+		assert.Empty(t, res[0].Format)
+	})
+
+	t.Run("summary view drops body", func(t *testing.T) {
+		mockArticleRepo.EXPECT().
+			FindAll(gomock.Any(), model.ArticleQuery{View: model.ArticleViewSummary, ArticleReadOptions: model.ArticleReadOptions{Fields: []string{"title"}}}).
+			Return([]*model.Article{{ID: uuid.New(), Title: "Title"}}, 1, nil)
+
+		_, _, err := articleService.FindAll(ctx, model.ArticleQuery{View: model.ArticleViewSummary, ArticleReadOptions: model.ArticleReadOptions{
+			Fields: []string{"title,body"},
+		}})
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, _, err := articleService.FindAll(ctx, model.ArticleQuery{ArticleReadOptions: model.ArticleReadOptions{Fields: []string{"body_html"}}})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+
+		_, err = articleService.FindByID(ctx, uuid.New().String(), model.ArticleReadOptions{Fields: []string{"password"}})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+}
+
+func TestArticleService_ListFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+
+	articleService := &articleService{articleRepository: mockArticleRepo}
+
+	t.Run("author ids are normalized", func(t *testing.T) {
+		a, b := uuid.New(), uuid.New()
+		mockArticleRepo.EXPECT().
+			FindAll(gomock.Any(), model.ArticleQuery{
+				AuthorIDs:   []string{a.String(), b.String()},
+				CreatedFrom: "2025-01-01T00:00",
+				Sort:        "title",
+			}).
+			Return(nil, 0, nil)
+
+		res, _, err := articleService.FindAll(ctx, model.ArticleQuery{
+			AuthorIDs:   []string{strings.ToUpper(a.String()) + ", " + b.String(), a.String()},
+			CreatedFrom: "2025-01-01T00:00",
+			Sort:        "title",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	invalid := map[string]model.ArticleQuery{
+		"unknown sort":        {Sort: "author; DROP TABLE articles"},
+		"bad created_from":    {CreatedFrom: "2025-01-01"},
+		"bad created_to":      {CreatedTo: "yesterday"},
+		"inverted range":      {CreatedFrom: "2025-02-01T00:00", CreatedTo: "2025-01-01T00:00"},
+		"malformed author id": {AuthorIDs: []string{"not-a-uuid"}},
+		"unknown language":    {Lang: "fr"},
+	}
+	for name, filter := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := articleService.FindAll(ctx, filter)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+		})
+	}
+}
+
+func TestArticleService_Languages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+
+	articleService := &articleService{
+		authorRepository:  mockAuthorRepo,
+		articleRepository: mockArticleRepo,
+	}
+
+	authorID := uuid.New()
+	translationID := uuid.New()
+	english := &model.Article{ID: translationID, TranslationID: translationID, Language: "en", Title: "Hello", Slug: "hello"}
+	indonesian := &model.Article{ID: uuid.New(), TranslationID: translationID, Language: "id", Title: "Halo", Slug: "halo"}
+	translations := func() []*model.ArticleTranslation {
+		return []*model.ArticleTranslation{
+			{ID: english.ID, Language: "en", Title: english.Title, Slug: english.Slug},
+			{ID: indonesian.ID, Language: "id", Title: indonesian.Title, Slug: indonesian.Slug},
+		}
+	}
+
+	t.Run("create translation", func(t *testing.T) {
+		original := &model.Article{ID: translationID, TranslationID: translationID, Language: "en"}
+		mockAuthorRepo.EXPECT().
+			FindByID(gomock.Any(), authorID).
+			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), translationID).Return(original, nil)
+		mockArticleRepo.EXPECT().
+			FindTranslations(gomock.Any(), translationID).
+			Return([]*model.ArticleTranslation{{ID: translationID, Language: "en"}}, nil)
+		mockArticleRepo.EXPECT().FindSlugs(gomock.Any(), "halo", uuid.Nil).Return(nil, nil)
+		mockArticleRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				return a, nil
+			})
+
+		res, err := articleService.Create(ctx, &model.CreateArticleRequest{
+			AuthorID:      authorID.String(),
+			Title:         "Halo",
+			Body:          "Isi artikel",
+			Language:      " ID ",
+			TranslationOf: translationID.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "id", res.Language)
+		assert.Equal(t, translationID, res.TranslationID)
+	})
+
+	t.Run("language already translated", func(t *testing.T) {
+		original := &model.Article{ID: translationID, TranslationID: translationID, Language: "en"}
+		mockAuthorRepo.EXPECT().
+			FindByID(gomock.Any(), authorID).
+			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), translationID).Return(original, nil)
+		mockArticleRepo.EXPECT().FindTranslations(gomock.Any(), translationID).Return(translations(), nil)
+
+		_, err := articleService.Create(ctx, &model.CreateArticleRequest{
+			AuthorID:      authorID.String(),
+			Title:         "Halo",
+			Body:          "Isi artikel",
+			Language:      "id",
+			TranslationOf: translationID.String(),
+		})
+		assert.Contains(t, err.Error(), customErrors.ErrDuplicate.Error())
+	})
+
+	t.Run("unknown language", func(t *testing.T) {
+		mockAuthorRepo.EXPECT().
+			FindByID(gomock.Any(), authorID).
+			Return(&model.Author{ID: authorID, Name: "Test"}, nil)
+
+		_, err := articleService.Create(ctx, &model.CreateArticleRequest{
+			AuthorID: authorID.String(),
+			Title:    "Bonjour",
+			Body:     "Corps",
+			Language: "fr",
+		})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("find by id negotiates a translation", func(t *testing.T) {
+		stored := *english
+		served := *indonesian
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), english.ID).Return(&stored, nil)
+		mockArticleRepo.EXPECT().FindTranslations(gomock.Any(), translationID).Return(translations(), nil)
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), indonesian.ID).Return(&served, nil)
+
+		res, err := articleService.FindByID(ctx, english.ID.String(), model.ArticleReadOptions{Languages: []string{"fr", "id", "en"}})
+		require.NoError(t, err)
+		assert.Equal(t, "Halo", res.Title)
+		require.Len(t, res.Translations, 1)
+		assert.Equal(t, "en", res.Translations[0].Language)
+	})
+
+	t.Run("find by id keeps a preferred language", func(t *testing.T) {
+		stored := *english
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), english.ID).Return(&stored, nil)
+		mockArticleRepo.EXPECT().FindTranslations(gomock.Any(), translationID).Return(translations(), nil)
+
+		res, err := articleService.FindByID(ctx, english.ID.String(), model.ArticleReadOptions{Languages: []string{"en", "id"}})
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", res.Title)
+		require.Len(t, res.Translations, 1)
+		assert.Equal(t, "id", res.Translations[0].Language)
+	})
+
+	t.Run("find by id without translations field", func(t *testing.T) {
+		stored := *english
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), english.ID).Return(&stored, nil)
+
+		res, err := articleService.FindByID(ctx, english.ID.String(), model.ArticleReadOptions{Fields: []string{"id", "title"}})
+		require.NoError(t, err)
+		assert.Nil(t, res.Translations)
+	})
+
+	t.Run("find by slug keeps the requested slug canonical", func(t *testing.T) {
+		stored := *english
+		served := *indonesian
+		mockArticleRepo.EXPECT().FindBySlug(gomock.Any(), "hello").Return(&stored, nil)
+		mockArticleRepo.EXPECT().FindTranslations(gomock.Any(), translationID).Return(translations(), nil)
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), indonesian.ID).Return(&served, nil)
+
+		res, canonical, err := articleService.FindBySlug(ctx, "hello", model.ArticleReadOptions{Languages: []string{"id"}})
+		require.NoError(t, err)
+		assert.Equal(t, "halo", res.Slug)
+		assert.Equal(t, "hello", canonical)
+	})
+
+	t.Run("language filter is normalized", func(t *testing.T) {
+		mockArticleRepo.EXPECT().FindAll(gomock.Any(), model.ArticleQuery{Lang: "id"}).Return(nil, 0, nil)
+
+		_, _, err := articleService.FindAll(ctx, model.ArticleQuery{Lang: " ID"})
+		require.NoError(t, err)
+	})
+}
+
+// importRows replays rows and per-row errors in order.
+type importRows struct {
+	items []any
+}
+
+func (r *importRows) Next() (*model.ArticleImportRow, error) {
+	if len(r.items) == 0 {
+		return nil, io.EOF
+	}
+	item := r.items[0]
+	r.items = r.items[1:]
+	if err, ok := item.(error); ok {
+		return nil, err
+	}
+	return item.(*model.ArticleImportRow), nil
+}
+
+type exportRecorder struct {
+	articles []*model.Article
+	closed   bool
+}
+
+func (w *exportRecorder) Write(article *model.Article) error {
+	w.articles = append(w.articles, article)
+	return nil
+}
+
+func (w *exportRecorder) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestArticleService_Import(t *testing.T) {
+	ctx := context.TODO()
+
+	setup := func(t *testing.T) (*articleService, *mocks.MockArticleRepository, *mocks.MockAuthorRepository) {
+		ctrl := gomock.NewController(t)
+		mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+		mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
+		return &articleService{
+			articleRepository:  mockArticleRepo,
+			authorRepository:   mockAuthorRepo,
+			categoryRepository: mocks.NewMockCategoryRepository(ctrl),
+		}, mockArticleRepo, mockAuthorRepo
+	}
+
+	t.Run("matches and creates authors once", func(t *testing.T) {
+		service, mockArticleRepo, mockAuthorRepo := setup(t)
+
+		existing := &model.Author{ID: uuid.New(), Name: "Jane Doe"}
+		created := &model.Author{ID: uuid.New(), Name: "John Roe"}
+		mockAuthorRepo.EXPECT().FindByName(gomock.Any(), "Jane Doe").Return(existing, nil)
+		mockAuthorRepo.EXPECT().FindByName(gomock.Any(), "John Roe").Return(nil, nil)
+		mockAuthorRepo.EXPECT().
+			Create(gomock.Any(), &model.Author{Name: "John Roe"}).
+			Return(created, nil)
+		mockArticleRepo.EXPECT().FindSlugs(gomock.Any(), gomock.Any(), uuid.Nil).Return(nil, nil).Times(3)
+
+		createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		var stored []*model.Article
+		mockArticleRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *model.Article) (*model.Article, error) {
+				stored = append(stored, a)
+				return a, nil
+			}).
+			Times(3)
+
+		res, err := service.Import(ctx, &importRows{items: []any{
+			&model.ArticleImportRow{Row: 1, Author: "Jane Doe", Title: "First", Body: "One", Tags: []string{"Go"}, CreatedAt: &createdAt},
+			&model.ArticleImportRow{Row: 2, Author: " John Roe ", Title: "Second", Body: "Two"},
+			&model.ArticleImportRow{Row: 3, Author: "Jane Doe", Title: "Third", Body: "Three"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, &model.ArticleImportResult{Imported: 3, Errors: []model.ArticleImportError{}}, res)
+
+		require.Len(t, stored, 3)
+		assert.Equal(t, existing.ID, stored[0].AuthorID)
+		assert.Equal(t, []string{"go"}, stored[0].Tags)
+		assert.Equal(t, createdAt, stored[0].CreatedAt)
+		assert.Equal(t, "first", stored[0].Slug)
+		assert.NotEmpty(t, stored[0].BodyHTML)
+		assert.Equal(t, created.ID, stored[1].AuthorID)
+		assert.True(t, stored[1].CreatedAt.IsZero())
+		assert.Equal(t, existing.ID, stored[2].AuthorID)
+	})
+
+	t.Run("invalid rows are reported and skipped", func(t *testing.T) {
+		service, mockArticleRepo, mockAuthorRepo := setup(t)
+
+		mockAuthorRepo.EXPECT().FindByName(gomock.Any(), "Jane Doe").Return(&model.Author{ID: uuid.New(), Name: "Jane Doe"}, nil)
+		mockArticleRepo.EXPECT().FindSlugs(gomock.Any(), gomock.Any(), uuid.Nil).Return(nil, nil)
+		mockArticleRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&model.Article{}, nil)
+
+		res, err := service.Import(ctx, &importRows{items: []any{
+			&model.ArticleImportRow{Row: 1, Author: "Jane Doe", Title: "T", Body: "Short title"},
+			&model.ArticleImportError{Row: 2, Message: "invalid JSON: unexpected end of JSON input"},
+			&model.ArticleImportRow{Row: 3, Source: "posts/c.md", Author: "Jane Doe", Title: "No body"},
+			&model.ArticleImportRow{Row: 4, Author: "Jane Doe", Title: "Bad category", Body: "Body", CategoryID: "nope"},
+			&model.ArticleImportRow{Row: 5, Author: "Jane Doe", Title: "Valid", Body: "Body"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Imported)
+		assert.Equal(t, 4, res.Failed)
+		assert.Equal(t, []model.ArticleImportError{
+			{Row: 1, Message: "title must be between 3 and 255 characters"},
+			{Row: 2, Message: "invalid JSON: unexpected end of JSON input"},
+			{Row: 3, Source: "posts/c.md", Message: "body is required"},
+			{Row: 4, Message: "invalid category id format"},
+		}, res.Errors)
+	})
+
+	t.Run("reader error ends the import", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		res, err := service.Import(ctx, &importRows{items: []any{errors.New("connection reset")}})
+		assert.EqualError(t, err, "connection reset")
+		assert.Nil(t, res)
+	})
+
+	t.Run("repository error ends the import", func(t *testing.T) {
+		service, _, mockAuthorRepo := setup(t)
+
+		mockAuthorRepo.EXPECT().FindByName(gomock.Any(), "Jane Doe").Return(nil, errors.New("db failure"))
+
+		res, err := service.Import(ctx, &importRows{items: []any{
+			&model.ArticleImportRow{Row: 1, Author: "Jane Doe", Title: "First", Body: "One"},
+		}})
+		assert.EqualError(t, err, "db failure")
+		assert.Nil(t, res)
+	})
+}
+
+func TestArticleService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.TODO()
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+	articleService := &articleService{articleRepository: mockArticleRepo}
+
+	t.Run("streams normalized filter", func(t *testing.T) {
+		articles := []*model.Article{{Title: "First"}, {Title: "Second"}}
+		mockArticleRepo.EXPECT().
+			Stream(gomock.Any(), model.ArticleQuery{Tag: "go-lang", TagsAny: []string{"go"}}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.ArticleQuery, fn func(*model.Article) error) error {
+				for _, a := range articles {
+					if err := fn(a); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		w := &exportRecorder{}
+		err := articleService.Export(ctx, model.ArticleQuery{Tag: "Go Lang", TagsAny: []string{"GO"}}, w)
+		require.NoError(t, err)
+		assert.Equal(t, articles, w.articles)
+		assert.True(t, w.closed)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		w := &exportRecorder{}
+		err := articleService.Export(ctx, model.ArticleQuery{CreatedFrom: "yesterday"}, w)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+		assert.False(t, w.closed)
+	})
+
+	t.Run("stream error", func(t *testing.T) {
+		mockArticleRepo.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db failure"))
+
+		w := &exportRecorder{}
+		err := articleService.Export(ctx, model.ArticleQuery{}, w)
+		assert.EqualError(t, err, "db failure")
+		assert.False(t, w.closed)
+	})
+}
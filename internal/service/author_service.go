@@ -5,6 +5,8 @@ import (
 
 	"github.com/bagasss3/go-article/internal/errors"
 	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
 	"github.com/sirupsen/logrus"
 
 	"github.com/bagasss3/go-article/pkg/model"
@@ -21,11 +23,19 @@ func NewAuthorService(authorRepository model.AuthorRepository) model.AuthorMetho
 	}
 }
 
-func (s *authorService) FindByID(ctx context.Context, id string) (*model.Author, error) {
-	log := logrus.WithFields(logrus.Fields{
+func (s *authorService) FindByID(ctx context.Context, id string, opts model.AuthorReadOptions) (*model.Author, error) {
+	ctx, span := tracing.Start(ctx, "authorService.FindByID")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
 		"author_id": id,
 	})
 
+	if _, err := validateFields(opts.Fields, model.AuthorFields); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
 	uid, err := uuid.Parse(id)
 	if err != nil {
 		err := errors.New(errors.ErrInvalidData, "invalid author ID format")
@@ -49,7 +59,10 @@ func (s *authorService) FindByID(ctx context.Context, id string) (*model.Author,
 }
 
 func (s *authorService) Create(ctx context.Context, req *model.CreateAuthorRequest) (*model.Author, error) {
-	log := logrus.WithFields(logrus.Fields{
+	ctx, span := tracing.Start(ctx, "authorService.Create")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
 		"req": helper.ToJSON(req),
 	})
 
@@ -29,7 +29,7 @@ func TestAuthorService_FindByID(t *testing.T) {
 	service := &authorService{authorRepository: mockRepo}
 
 	t.Run("invalid uuid", func(t *testing.T) {
-		res, err := service.FindByID(ctx, "not-a-uuid")
+		res, err := service.FindByID(ctx, "not-a-uuid", model.AuthorReadOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
 		assert.Nil(t, res)
@@ -42,7 +42,7 @@ func TestAuthorService_FindByID(t *testing.T) {
 			FindByID(gomock.Any(), uid).
 			Return(nil, nil)
 
-		res, err := service.FindByID(ctx, uid.String())
+		res, err := service.FindByID(ctx, uid.String(), model.AuthorReadOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
 		assert.Nil(t, res)
@@ -55,7 +55,7 @@ func TestAuthorService_FindByID(t *testing.T) {
 			FindByID(gomock.Any(), uid).
 			Return(nil, errors.New("db failure"))
 
-		res, err := service.FindByID(ctx, uid.String())
+		res, err := service.FindByID(ctx, uid.String(), model.AuthorReadOptions{})
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -68,10 +68,17 @@ func TestAuthorService_FindByID(t *testing.T) {
 			FindByID(gomock.Any(), uid).
 			Return(expected, nil)
 
-		res, err := service.FindByID(ctx, uid.String())
+		res, err := service.FindByID(ctx, uid.String(), model.AuthorReadOptions{})
 		assert.NoError(t, err)
 		assert.Equal(t, expected, res)
 	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		res, err := service.FindByID(ctx, uuid.New().String(), model.AuthorReadOptions{Fields: []string{"name,email"}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+		assert.Nil(t, res)
+	})
 }
 
 func TestAuthorService_Create(t *testing.T) {
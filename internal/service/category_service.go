@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type categoryService struct {
+	categoryRepository model.CategoryRepository
+	articleRepository  model.ArticleRepository
+	events             model.ArticleEventHandler
+}
+
+func NewCategoryService(categoryRepository model.CategoryRepository, articleRepository model.ArticleRepository, events model.ArticleEventHandler) model.CategoryMethodService {
+	return &categoryService{
+		categoryRepository: categoryRepository,
+		articleRepository:  articleRepository,
+		events:             events,
+	}
+}
+
+func (s *categoryService) FindAll(ctx context.Context) ([]*model.Category, error) {
+	categories, err := s.categoryRepository.FindAll(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	return buildCategoryTree(categories), nil
+}
+
+func (s *categoryService) FindByID(ctx context.Context, id string) (*model.Category, error) {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"category_id": id,
+	})
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		err := errors.New(errors.ErrInvalidData, "invalid category ID format")
+		log.Error(err)
+		return nil, err
+	}
+
+	category, err := s.categoryRepository.FindByID(ctx, uid)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if category == nil {
+		err := errors.New(errors.ErrRecordNotFound, "category not found")
+		log.Error(err)
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) FindArticles(ctx context.Context, slug string, filter model.ArticleQuery) (*model.CategoryArticles, int, error) {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"slug":   slug,
+		"filter": filter,
+	})
+
+	if err := validateFormat(filter.Format); err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	category, err := s.categoryRepository.FindBySlug(ctx, slug)
+	if err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	if category == nil {
+		err := errors.New(errors.ErrRecordNotFound, "category not found")
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	breadcrumbs, err := s.categoryRepository.FindAncestors(ctx, category.ID)
+	if err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	// Only the view applies here; field selection is limited to the article endpoints.
+	filter.Fields, err = articleFields(nil, filter.View)
+	if err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	if err := normalizeArticleFilter(&filter); err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	filter.Category = category.Slug
+	articles, total, err := s.articleRepository.FindAll(ctx, filter)
+	if err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	if len(articles) <= 0 {
+		articles = []*model.Article{}
+	}
+
+	if hasField(filter.Fields, "body") {
+		if err := formatArticles(filter.Format, articles...); err != nil {
+			log.Error(err)
+			return nil, 0, err
+		}
+	}
+
+	return &model.CategoryArticles{
+		Category:    category,
+		Breadcrumbs: breadcrumbs,
+		Articles:    articles,
+	}, total, nil
+}
+
+func (s *categoryService) Create(ctx context.Context, req *model.CreateCategoryRequest) (*model.Category, error) {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"req": helper.ToJSON(req),
+	})
+
+	category := &model.Category{
+		Name: req.Name,
+		Slug: categorySlug(req.Slug, req.Name),
+	}
+
+	if err := s.validate(ctx, category, req.ParentID); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	result, err := s.categoryRepository.Create(ctx, category)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *categoryService) Update(ctx context.Context, id string, req *model.UpdateCategoryRequest) (*model.Category, error) {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"category_id": id,
+		"req":         helper.ToJSON(req),
+	})
+
+	category, err := s.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	slug, parentID := category.Slug, category.ParentID
+
+	category.Name = req.Name
+	category.Slug = categorySlug(req.Slug, req.Name)
+	if err := s.validate(ctx, category, req.ParentID); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	result, err := s.categoryRepository.Update(ctx, category)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// Articles are filed under the slugs of their category path, which a new slug or
+	// parent changes for the whole subtree.
+	if result.Slug != slug || !sameCategory(result.ParentID, parentID) {
+		ids, err := s.articleIDs(ctx, result.Slug)
+		if err != nil {
+			log.WithError(err).Warn("failed to find the articles of a changed category")
+		}
+		s.saved(ctx, ids)
+	}
+
+	return result, nil
+}
+
+func (s *categoryService) Delete(ctx context.Context, id string) error {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"category_id": id,
+	})
+
+	category, err := s.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	hasChildren, err := s.categoryRepository.HasChildren(ctx, category.ID)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if hasChildren {
+		err := errors.New(errors.ErrInvalidData, "category still has subcategories")
+		log.Error(err)
+		return err
+	}
+
+	// Deleting the category leaves its articles without one.
+	ids, err := s.articleIDs(ctx, category.Slug)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := s.categoryRepository.Delete(ctx, category.ID); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	s.saved(ctx, ids)
+
+	return nil
+}
+
+// articleIDs lists the articles of the category with slug and its descendants.
+func (s *categoryService) articleIDs(ctx context.Context, slug string) ([]uuid.UUID, error) {
+	if s.events == nil {
+		return nil, nil
+	}
+
+	var ids []uuid.UUID
+	err := s.articleRepository.Stream(ctx, model.ArticleQuery{Category: slug}, func(article *model.Article) error {
+		ids = append(ids, article.ID)
+		return nil
+	})
+	return ids, err
+}
+
+// saved tells the event handler, if any, that the category of articles changed.
+func (s *categoryService) saved(ctx context.Context, ids []uuid.UUID) {
+	if s.events == nil {
+		return
+	}
+	for _, id := range ids {
+		s.events.HandleArticleEvent(ctx, model.ArticleEvent{Type: model.ArticleSaved, ArticleID: id})
+	}
+}
+
+func sameCategory(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// validate checks slug uniqueness and resolves parentID onto category, rejecting
+// parents that would turn the tree into a cycle.
+func (s *categoryService) validate(ctx context.Context, category *model.Category, parentID string) error {
+	if category.Slug == "" {
+		return errors.New(errors.ErrInvalidData, "category slug must contain letters or digits")
+	}
+
+	existing, err := s.categoryRepository.FindBySlug(ctx, category.Slug)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != category.ID {
+		return errors.New(errors.ErrDuplicate, "category slug already exists")
+	}
+
+	category.ParentID = nil
+	if parentID == "" {
+		return nil
+	}
+
+	pid, err := uuid.Parse(parentID)
+	if err != nil {
+		return errors.New(errors.ErrInvalidData, "invalid parent ID format")
+	}
+
+	if pid == category.ID {
+		return errors.New(errors.ErrInvalidData, "category cannot be its own parent")
+	}
+
+	ancestors, err := s.categoryRepository.FindAncestors(ctx, pid)
+	if err != nil {
+		return err
+	}
+
+	if len(ancestors) == 0 {
+		return errors.New(errors.ErrRecordNotFound, "parent category not found")
+	}
+
+	for _, ancestor := range ancestors {
+		if ancestor.ID == category.ID {
+			return errors.New(errors.ErrInvalidData, "parent category is a descendant of this category")
+		}
+	}
+
+	category.ParentID = &pid
+	return nil
+}
+
+func categorySlug(slug, name string) string {
+	if slug != "" {
+		return helper.Slugify(slug)
+	}
+	return helper.Slugify(name)
+}
+
+// buildCategoryTree nests a flat category list under their parents and returns the roots.
+func buildCategoryTree(categories []*model.Category) []*model.Category {
+	byID := make(map[uuid.UUID]*model.Category, len(categories))
+	for _, c := range categories {
+		c.Children = nil
+		byID[c.ID] = c
+	}
+
+	roots := []*model.Category{}
+	for _, c := range categories {
+		if c.ParentID != nil {
+			if parent, ok := byID[*c.ParentID]; ok {
+				parent.Children = append(parent.Children, c)
+				continue
+			}
+		}
+		roots = append(roots, c)
+	}
+
+	return roots
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewCategoryService(t *testing.T) {
+	s := NewCategoryService(nil, nil, nil)
+	require.NotNil(t, s)
+}
+
+func TestCategoryService_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := &categoryService{categoryRepository: mockRepo}
+
+	t.Run("builds tree", func(t *testing.T) {
+		rootID, childID := uuid.New(), uuid.New()
+		mockRepo.EXPECT().FindAll(gomock.Any()).Return([]*model.Category{
+			{ID: rootID, Name: "Tech", Slug: "tech"},
+			{ID: childID, ParentID: &rootID, Name: "Go", Slug: "go"},
+			{ID: uuid.New(), ParentID: &childID, Name: "Concurrency", Slug: "concurrency"},
+		}, nil)
+
+		res, err := service.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Len(t, res[0].Children, 1)
+		require.Equal(t, "concurrency", res[0].Children[0].Children[0].Slug)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockRepo.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("repo error"))
+
+		res, err := service.FindAll(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCategoryService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := &categoryService{categoryRepository: mockRepo}
+
+	t.Run("duplicate slug", func(t *testing.T) {
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go").Return(&model.Category{ID: uuid.New(), Slug: "go"}, nil)
+
+		res, err := service.Create(ctx, &model.CreateCategoryRequest{Name: "Go"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrDuplicate.Error())
+		assert.Nil(t, res)
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		parentID := uuid.New()
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go").Return(nil, nil)
+		mockRepo.EXPECT().FindAncestors(gomock.Any(), parentID).Return(nil, nil)
+
+		res, err := service.Create(ctx, &model.CreateCategoryRequest{Name: "Go", ParentID: parentID.String()})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
+		assert.Nil(t, res)
+	})
+
+	t.Run("success with parent", func(t *testing.T) {
+		parentID := uuid.New()
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go-lang").Return(nil, nil)
+		mockRepo.EXPECT().FindAncestors(gomock.Any(), parentID).Return([]*model.Category{{ID: parentID}}, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, c *model.Category) (*model.Category, error) {
+				return c, nil
+			})
+
+		res, err := service.Create(ctx, &model.CreateCategoryRequest{Name: "Go", Slug: "Go Lang", ParentID: parentID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, "go-lang", res.Slug)
+		assert.Equal(t, parentID, *res.ParentID)
+	})
+}
+
+func TestCategoryService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := &categoryService{categoryRepository: mockRepo}
+
+	rootID, childID := uuid.New(), uuid.New()
+
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := service.Update(ctx, "not-a-uuid", &model.UpdateCategoryRequest{Name: "Tech"})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("own parent", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), rootID).Return(&model.Category{ID: rootID, Slug: "tech"}, nil)
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "tech").Return(&model.Category{ID: rootID, Slug: "tech"}, nil)
+
+		_, err := service.Update(ctx, rootID.String(), &model.UpdateCategoryRequest{Name: "Tech", ParentID: rootID.String()})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("cycle through descendant", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), rootID).Return(&model.Category{ID: rootID, Slug: "tech"}, nil)
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "tech").Return(&model.Category{ID: rootID, Slug: "tech"}, nil)
+		mockRepo.EXPECT().FindAncestors(gomock.Any(), childID).Return([]*model.Category{
+			{ID: rootID},
+			{ID: childID, ParentID: &rootID},
+		}, nil)
+
+		_, err := service.Update(ctx, rootID.String(), &model.UpdateCategoryRequest{Name: "Tech", ParentID: childID.String()})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("success move to root", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), childID).Return(&model.Category{ID: childID, ParentID: &rootID, Slug: "go"}, nil)
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go").Return(&model.Category{ID: childID, Slug: "go"}, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, c *model.Category) (*model.Category, error) {
+				return c, nil
+			})
+
+		res, err := service.Update(ctx, childID.String(), &model.UpdateCategoryRequest{Name: "Go"})
+		require.NoError(t, err)
+		assert.Nil(t, res.ParentID)
+	})
+}
+
+func TestCategoryService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := &categoryService{categoryRepository: mockRepo}
+	id := uuid.New()
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), id).Return(nil, nil)
+
+		err := service.Delete(ctx, id.String())
+		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
+	})
+
+	t.Run("has children", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), id).Return(&model.Category{ID: id}, nil)
+		mockRepo.EXPECT().HasChildren(gomock.Any(), id).Return(true, nil)
+
+		err := service.Delete(ctx, id.String())
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), id).Return(&model.Category{ID: id}, nil)
+		mockRepo.EXPECT().HasChildren(gomock.Any(), id).Return(false, nil)
+		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
+
+		assert.NoError(t, service.Delete(ctx, id.String()))
+	})
+}
+
+func TestCategoryService_ArticleEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+	mockEvents := mocks.NewMockArticleEventHandler(ctrl)
+	service := &categoryService{categoryRepository: mockRepo, articleRepository: mockArticleRepo, events: mockEvents}
+
+	rootID, id := uuid.New(), uuid.New()
+	articles := []*model.Article{{ID: uuid.New()}, {ID: uuid.New()}}
+	stream := func(slug string) {
+		mockArticleRepo.EXPECT().
+			Stream(gomock.Any(), model.ArticleQuery{Category: slug}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.ArticleQuery, fn func(*model.Article) error) error {
+				for _, a := range articles {
+					if err := fn(a); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+	}
+	expectSaved := func() {
+		for _, a := range articles {
+			mockEvents.EXPECT().HandleArticleEvent(gomock.Any(), model.ArticleEvent{Type: model.ArticleSaved, ArticleID: a.ID})
+		}
+	}
+	update := func() {
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, c *model.Category) (*model.Category, error) {
+				return c, nil
+			})
+	}
+
+	t.Run("new slug saves the articles of the subtree", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), id).Return(&model.Category{ID: id, Name: "Go", Slug: "go"}, nil)
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "golang").Return(nil, nil)
+		update()
+		stream("golang")
+		expectSaved()
+
+		_, err := service.Update(ctx, id.String(), &model.UpdateCategoryRequest{Name: "Golang"})
+		require.NoError(t, err)
+	})
+
+	t.Run("new parent saves the articles of the subtree", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), id).Return(&model.Category{ID: id, Name: "Go", Slug: "go"}, nil)
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go").Return(&model.Category{ID: id, Slug: "go"}, nil)
+		mockRepo.EXPECT().FindAncestors(gomock.Any(), rootID).Return([]*model.Category{{ID: rootID}}, nil)
+		update()
+		stream("go")
+		expectSaved()
+
+		_, err := service.Update(ctx, id.String(), &model.UpdateCategoryRequest{Name: "Go", ParentID: rootID.String()})
+		require.NoError(t, err)
+	})
+
+	t.Run("new name alone saves nothing", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), id).Return(&model.Category{ID: id, ParentID: &rootID, Name: "Go", Slug: "go"}, nil)
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go").Return(&model.Category{ID: id, Slug: "go"}, nil)
+		mockRepo.EXPECT().FindAncestors(gomock.Any(), rootID).Return([]*model.Category{{ID: rootID}}, nil)
+		update()
+
+		_, err := service.Update(ctx, id.String(), &model.UpdateCategoryRequest{Name: "Go Language", Slug: "go", ParentID: rootID.String()})
+		require.NoError(t, err)
+	})
+
+	t.Run("delete saves the articles it leaves without a category", func(t *testing.T) {
+		mockRepo.EXPECT().FindByID(gomock.Any(), id).Return(&model.Category{ID: id, Slug: "go"}, nil)
+		mockRepo.EXPECT().HasChildren(gomock.Any(), id).Return(false, nil)
+		stream("go")
+		deleted := mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
+		for _, a := range articles {
+			mockEvents.EXPECT().
+				HandleArticleEvent(gomock.Any(), model.ArticleEvent{Type: model.ArticleSaved, ArticleID: a.ID}).
+				After(deleted)
+		}
+
+		require.NoError(t, service.Delete(ctx, id.String()))
+	})
+}
+
+func TestCategoryService_FindArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+	service := &categoryService{categoryRepository: mockRepo, articleRepository: mockArticleRepo}
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "missing").Return(nil, nil)
+
+		_, _, err := service.FindArticles(ctx, "missing", model.ArticleQuery{})
+		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
+	})
+
+	t.Run("success with breadcrumbs", func(t *testing.T) {
+		rootID := uuid.New()
+		category := &model.Category{ID: uuid.New(), ParentID: &rootID, Name: "Go", Slug: "go"}
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go").Return(category, nil)
+		mockRepo.EXPECT().FindAncestors(gomock.Any(), category.ID).Return([]*model.Category{
+			{ID: rootID, Name: "Tech", Slug: "tech"},
+			category,
+		}, nil)
+		mockArticleRepo.EXPECT().FindAll(gomock.Any(), model.ArticleQuery{Category: "go", Page: 1}).
+			Return(nil, 0, nil)
+
+		res, total, err := service.FindArticles(ctx, "go", model.ArticleQuery{Page: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Len(t, res.Breadcrumbs, 2)
+		assert.Equal(t, []*model.Article{}, res.Articles)
+	})
+
+	t.Run("formats bodies", func(t *testing.T) {
+		category := &model.Category{ID: uuid.New(), Name: "Go", Slug: "go"}
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go").Return(category, nil)
+		mockRepo.EXPECT().FindAncestors(gomock.Any(), category.ID).Return([]*model.Category{category}, nil)
+		mockArticleRepo.EXPECT().FindAll(gomock.Any(), model.ArticleQuery{Category: "go", ArticleReadOptions: model.ArticleReadOptions{Format: "text"}}).
+			Return([]*model.Article{{ID: uuid.New(), Body: "Some **bold** text", BodyHTML: "<p>Some <strong>bold</strong> text</p>\n"}}, 1, nil)
+
+		res, _, err := service.FindArticles(ctx, "go", model.ArticleQuery{ArticleReadOptions: model.ArticleReadOptions{Format: "text"}})
+		require.NoError(t, err)
+		assert.Equal(t, "Some bold text", res.Articles[0].Body)
+		assert.Empty(t, res.Articles[0].BodyHTML)
+		assert.Equal(t, model.ArticleFormatText, res.Articles[0].Format)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, _, err := service.FindArticles(ctx, "go", model.ArticleQuery{ArticleReadOptions: model.ArticleReadOptions{Format: "pdf"}})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("normalizes filters like article listings", func(t *testing.T) {
+		category := &model.Category{ID: uuid.New(), Name: "Go", Slug: "go"}
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go").Return(category, nil)
+		mockRepo.EXPECT().FindAncestors(gomock.Any(), category.ID).Return([]*model.Category{category}, nil)
+		mockArticleRepo.EXPECT().FindAll(gomock.Any(), model.ArticleQuery{
+			Category: "go",
+			Lang:     "id",
+			Tag:      "web-development",
+			TagsAny:  []string{"go", "rust"},
+		}).Return(nil, 0, nil)
+
+		_, _, err := service.FindArticles(ctx, "go", model.ArticleQuery{
+			Lang:    "ID",
+			Tag:     "Web Development",
+			TagsAny: []string{"Go, Rust"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown language", func(t *testing.T) {
+		category := &model.Category{ID: uuid.New(), Name: "Go", Slug: "go"}
+		mockRepo.EXPECT().FindBySlug(gomock.Any(), "go").Return(category, nil)
+		mockRepo.EXPECT().FindAncestors(gomock.Any(), category.ID).Return([]*model.Category{category}, nil)
+
+		_, _, err := service.FindArticles(ctx, "go", model.ArticleQuery{Lang: "fr"})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type commentService struct {
+	commentRepository model.CommentRepository
+	articleRepository model.ArticleRepository
+}
+
+func NewCommentService(commentRepository model.CommentRepository, articleRepository model.ArticleRepository) model.CommentMethodService {
+	return &commentService{
+		commentRepository: commentRepository,
+		articleRepository: articleRepository,
+	}
+}
+
+func (s *commentService) FindByArticle(ctx context.Context, articleID string, query model.CommentQuery) (*model.CommentPage, error) {
+	ctx, span := tracing.Start(ctx, "commentService.FindByArticle")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"article_id": articleID,
+		"query":      query,
+	})
+
+	if query.Limit == 0 {
+		query.Limit = config.DefaultCommentLimit
+	}
+	if query.Limit < 0 || query.Limit > config.MaxCommentLimit {
+		err := errors.New(errors.ErrInvalidData, fmt.Sprintf("limit must be between 1 and %d", config.MaxCommentLimit))
+		log.Error(err)
+		return nil, err
+	}
+
+	var after *model.CommentCursor
+	if query.Cursor != "" {
+		cursor, err := decodeCommentCursor(query.Cursor)
+		if err != nil {
+			err := errors.New(errors.ErrInvalidData, "invalid cursor")
+			log.Error(err)
+			return nil, err
+		}
+		after = cursor
+	}
+
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// One extra thread tells whether there is a next page.
+	threads, err := s.commentRepository.FindThreads(ctx, article.ID, after, query.Limit+1)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	page := &model.CommentPage{Comments: []*model.Comment{}}
+	if len(threads) > query.Limit {
+		threads = threads[:query.Limit]
+		page.NextCursor = encodeCommentCursor(threads[len(threads)-1])
+	}
+	if len(threads) == 0 {
+		return page, nil
+	}
+
+	rootIDs := make([]uuid.UUID, len(threads))
+	for i, thread := range threads {
+		rootIDs[i] = thread.ID
+	}
+	replies, err := s.commentRepository.FindReplies(ctx, rootIDs)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	page.Comments = nestComments(threads, replies)
+
+	return page, nil
+}
+
+func (s *commentService) Create(ctx context.Context, articleID, userID string, req *model.CreateCommentRequest) (*model.Comment, error) {
+	ctx, span := tracing.Start(ctx, "commentService.Create")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"article_id": articleID,
+		"user_id":    userID,
+		"req":        helper.ToJSON(req),
+	})
+
+	if err := requireUser(userID); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		err := errors.New(errors.ErrInvalidData, "comment body is required")
+		log.Error(err)
+		return nil, err
+	}
+
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	comment := &model.Comment{
+		ArticleID: article.ID,
+		UserID:    userID,
+		Body:      body,
+		Status:    initialCommentStatus(),
+	}
+
+	if req.ParentID != "" {
+		parent, err := s.findComment(ctx, req.ParentID)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		if parent.ArticleID != article.ID || parent.Status != model.CommentStatusApproved {
+			err := errors.New(errors.ErrInvalidData, "parent comment not found on this article")
+			log.Error(err)
+			return nil, err
+		}
+		if parent.Depth+1 > config.CommentMaxDepth() {
+			err := errors.New(errors.ErrInvalidData, fmt.Sprintf("replies can be nested at most %d levels deep", config.CommentMaxDepth()))
+			log.Error(err)
+			return nil, err
+		}
+
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+
+	result, err := s.commentRepository.Create(ctx, comment)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	result.Replies = []*model.Comment{}
+
+	return result, nil
+}
+
+func (s *commentService) Update(ctx context.Context, id, userID string, req *model.UpdateCommentRequest) (*model.Comment, error) {
+	ctx, span := tracing.Start(ctx, "commentService.Update")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"comment_id": id,
+		"user_id":    userID,
+		"req":        helper.ToJSON(req),
+	})
+
+	comment, err := s.findOwnComment(ctx, id, userID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		err := errors.New(errors.ErrInvalidData, "comment body is required")
+		log.Error(err)
+		return nil, err
+	}
+
+	comment.Body = body
+	// An edit goes through moderation again, except that spam stays spam.
+	if comment.Status != model.CommentStatusSpam {
+		comment.Status = initialCommentStatus()
+	}
+
+	result, err := s.commentRepository.Update(ctx, comment)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	result.Replies = []*model.Comment{}
+
+	return result, nil
+}
+
+func (s *commentService) Delete(ctx context.Context, id, userID string) error {
+	ctx, span := tracing.Start(ctx, "commentService.Delete")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"comment_id": id,
+		"user_id":    userID,
+	})
+
+	comment, err := s.findOwnComment(ctx, id, userID)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := s.commentRepository.Delete(ctx, comment); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *commentService) Moderate(ctx context.Context, id, userID string, req *model.ModerateCommentRequest) (*model.Comment, error) {
+	ctx, span := tracing.Start(ctx, "commentService.Moderate")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"comment_id": id,
+		"user_id":    userID,
+		"status":     req.Status,
+	})
+
+	if err := requireUser(userID); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if !slices.Contains(config.CommentModerators(), userID) {
+		err := errors.New(errors.ErrPermissionDenied, "only moderators can moderate comments")
+		log.Error(err)
+		return nil, err
+	}
+
+	comment, err := s.findComment(ctx, id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	comment.Status = req.Status
+
+	result, err := s.commentRepository.Update(ctx, comment)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	result.Replies = []*model.Comment{}
+
+	return result, nil
+}
+
+func (s *commentService) findArticle(ctx context.Context, id string) (*model.Article, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New(errors.ErrInvalidData, "invalid article ID format")
+	}
+
+	article, err := s.articleRepository.FindByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if article == nil {
+		return nil, errors.New(errors.ErrRecordNotFound, "article not found")
+	}
+
+	return article, nil
+}
+
+func (s *commentService) findComment(ctx context.Context, id string) (*model.Comment, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New(errors.ErrInvalidData, "invalid comment ID format")
+	}
+
+	comment, err := s.commentRepository.FindByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment == nil {
+		return nil, errors.New(errors.ErrRecordNotFound, "comment not found")
+	}
+
+	return comment, nil
+}
+
+// findOwnComment returns the comment with id if userID wrote it.
+func (s *commentService) findOwnComment(ctx context.Context, id, userID string) (*model.Comment, error) {
+	if err := requireUser(userID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.findComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userID {
+		return nil, errors.New(errors.ErrPermissionDenied, "only the author of a comment can change it")
+	}
+
+	return comment, nil
+}
+
+func requireUser(userID string) error {
+	if userID == "" {
+		return errors.New(errors.ErrUnauthorized, "a user ID is required")
+	}
+	return nil
+}
+
+func initialCommentStatus() string {
+	if config.CommentAutoApprove() {
+		return model.CommentStatusApproved
+	}
+	return model.CommentStatusPending
+}
+
+// nestComments attaches replies, ordered oldest first, below their parents.
+func nestComments(threads, replies []*model.Comment) []*model.Comment {
+	byID := make(map[uuid.UUID]*model.Comment, len(threads)+len(replies))
+	for _, comment := range slices.Concat(threads, replies) {
+		comment.Replies = []*model.Comment{}
+		byID[comment.ID] = comment
+	}
+
+	for _, reply := range replies {
+		if reply.ParentID == nil {
+			continue
+		}
+		if parent, ok := byID[*reply.ParentID]; ok {
+			parent.Replies = append(parent.Replies, reply)
+		}
+	}
+
+	return threads
+}
+
+func encodeCommentCursor(c *model.Comment) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.Format(time.RFC3339Nano) + "|" + c.ID.String()))
+}
+
+func decodeCommentCursor(cursor string) (*model.CommentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CommentCursor{CreatedAt: t, ID: uid}, nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewCommentService(t *testing.T) {
+	s := NewCommentService(nil, nil)
+	require.NotNil(t, s)
+}
+
+func TestCommentService(t *testing.T) {
+	ctx := context.TODO()
+
+	setup := func(t *testing.T) (*commentService, *mocks.MockCommentRepository, *mocks.MockArticleRepository) {
+		ctrl := gomock.NewController(t)
+		mockCommentRepo := mocks.NewMockCommentRepository(ctrl)
+		mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+		return &commentService{
+			commentRepository: mockCommentRepo,
+			articleRepository: mockArticleRepo,
+		}, mockCommentRepo, mockArticleRepo
+	}
+
+	article := &model.Article{ID: uuid.New()}
+
+	t.Run("find nests replies and pages threads", func(t *testing.T) {
+		service, mockCommentRepo, mockArticleRepo := setup(t)
+
+		created := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+		first := &model.Comment{ID: uuid.New(), CreatedAt: created}
+		second := &model.Comment{ID: uuid.New(), CreatedAt: created.Add(time.Minute)}
+		extra := &model.Comment{ID: uuid.New(), CreatedAt: created.Add(2 * time.Minute)}
+		reply := &model.Comment{ID: uuid.New(), ParentID: &first.ID, Depth: 1}
+		nested := &model.Comment{ID: uuid.New(), ParentID: &reply.ID, Depth: 2}
+
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(article, nil)
+		mockCommentRepo.EXPECT().FindThreads(gomock.Any(), article.ID, nil, 3).
+			Return([]*model.Comment{first, second, extra}, nil)
+		mockCommentRepo.EXPECT().FindReplies(gomock.Any(), []uuid.UUID{first.ID, second.ID}).
+			Return([]*model.Comment{reply, nested}, nil)
+
+		page, err := service.FindByArticle(ctx, article.ID.String(), model.CommentQuery{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page.Comments, 2)
+		require.Len(t, page.Comments[0].Replies, 1)
+		assert.Equal(t, nested, page.Comments[0].Replies[0].Replies[0])
+		assert.Empty(t, page.Comments[1].Replies)
+		require.NotEmpty(t, page.NextCursor)
+
+		cursor, err := decodeCommentCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, cursor.ID)
+		assert.True(t, second.CreatedAt.Equal(cursor.CreatedAt))
+	})
+
+	t.Run("find continues after the cursor", func(t *testing.T) {
+		service, mockCommentRepo, mockArticleRepo := setup(t)
+
+		last := &model.Comment{ID: uuid.New(), CreatedAt: time.Date(2024, 5, 1, 8, 0, 0, 123, time.UTC)}
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(article, nil)
+		mockCommentRepo.EXPECT().
+			FindThreads(gomock.Any(), article.ID, &model.CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID}, 21).
+			Return(nil, nil)
+
+		page, err := service.FindByArticle(ctx, article.ID.String(), model.CommentQuery{Cursor: encodeCommentCursor(last)})
+		require.NoError(t, err)
+		assert.NotNil(t, page.Comments)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("find rejects bad input", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		_, err := service.FindByArticle(ctx, article.ID.String(), model.CommentQuery{Cursor: "not a cursor"})
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+
+		_, err = service.FindByArticle(ctx, article.ID.String(), model.CommentQuery{Limit: 1000})
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+
+		_, err = service.FindByArticle(ctx, "bad", model.CommentQuery{})
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+	})
+
+	t.Run("create is pending unless auto approved", func(t *testing.T) {
+		service, mockCommentRepo, mockArticleRepo := setup(t)
+
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(article, nil).Times(2)
+		mockCommentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, c *model.Comment) (*model.Comment, error) { return c, nil }).Times(2)
+
+		res, err := service.Create(ctx, article.ID.String(), "user-1", &model.CreateCommentRequest{Body: " Nice "})
+		require.NoError(t, err)
+		assert.Equal(t, model.CommentStatusPending, res.Status)
+		assert.Equal(t, "user-1", res.UserID)
+		assert.Equal(t, "Nice", res.Body)
+		assert.Nil(t, res.ParentID)
+
+		setConfig(t, "comment.autoApprove", true)
+		res, err = service.Create(ctx, article.ID.String(), "user-1", &model.CreateCommentRequest{Body: "Nice"})
+		require.NoError(t, err)
+		assert.Equal(t, model.CommentStatusApproved, res.Status)
+	})
+
+	t.Run("create requires a user", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		_, err := service.Create(ctx, article.ID.String(), "", &model.CreateCommentRequest{Body: "Nice"})
+		assertErrorType(t, err, customErrors.ErrUnauthorized)
+	})
+
+	t.Run("reply below parent", func(t *testing.T) {
+		service, mockCommentRepo, mockArticleRepo := setup(t)
+
+		parent := &model.Comment{ID: uuid.New(), ArticleID: article.ID, Status: model.CommentStatusApproved, Depth: 1}
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(article, nil)
+		mockCommentRepo.EXPECT().FindByID(gomock.Any(), parent.ID).Return(parent, nil)
+		mockCommentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, c *model.Comment) (*model.Comment, error) { return c, nil })
+
+		res, err := service.Create(ctx, article.ID.String(), "user-1", &model.CreateCommentRequest{ParentID: parent.ID.String(), Body: "Agreed"})
+		require.NoError(t, err)
+		assert.Equal(t, parent.ID, *res.ParentID)
+		assert.Equal(t, 2, res.Depth)
+	})
+
+	t.Run("reply too deep", func(t *testing.T) {
+		service, mockCommentRepo, mockArticleRepo := setup(t)
+		setConfig(t, "comment.maxDepth", 1)
+
+		parent := &model.Comment{ID: uuid.New(), ArticleID: article.ID, Status: model.CommentStatusApproved, Depth: 1}
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(article, nil)
+		mockCommentRepo.EXPECT().FindByID(gomock.Any(), parent.ID).Return(parent, nil)
+
+		_, err := service.Create(ctx, article.ID.String(), "user-1", &model.CreateCommentRequest{ParentID: parent.ID.String(), Body: "Agreed"})
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+	})
+
+	t.Run("reply to another article", func(t *testing.T) {
+		service, mockCommentRepo, mockArticleRepo := setup(t)
+
+		parent := &model.Comment{ID: uuid.New(), ArticleID: uuid.New(), Status: model.CommentStatusApproved}
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(article, nil)
+		mockCommentRepo.EXPECT().FindByID(gomock.Any(), parent.ID).Return(parent, nil)
+
+		_, err := service.Create(ctx, article.ID.String(), "user-1", &model.CreateCommentRequest{ParentID: parent.ID.String(), Body: "Agreed"})
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+	})
+
+	t.Run("create on missing article", func(t *testing.T) {
+		service, _, mockArticleRepo := setup(t)
+
+		mockArticleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(nil, nil)
+
+		_, err := service.Create(ctx, article.ID.String(), "user-1", &model.CreateCommentRequest{Body: "Nice"})
+		assertErrorType(t, err, customErrors.ErrRecordNotFound)
+	})
+
+	t.Run("owner edit goes back to moderation", func(t *testing.T) {
+		service, mockCommentRepo, _ := setup(t)
+
+		comment := &model.Comment{ID: uuid.New(), UserID: "user-1", Status: model.CommentStatusApproved}
+		mockCommentRepo.EXPECT().FindByID(gomock.Any(), comment.ID).Return(comment, nil)
+		mockCommentRepo.EXPECT().Update(gomock.Any(), comment).Return(comment, nil)
+
+		res, err := service.Update(ctx, comment.ID.String(), "user-1", &model.UpdateCommentRequest{Body: "Edited"})
+		require.NoError(t, err)
+		assert.Equal(t, "Edited", res.Body)
+		assert.Equal(t, model.CommentStatusPending, res.Status)
+	})
+
+	t.Run("spam stays spam after an edit", func(t *testing.T) {
+		service, mockCommentRepo, _ := setup(t)
+		setConfig(t, "comment.autoApprove", true)
+
+		comment := &model.Comment{ID: uuid.New(), UserID: "user-1", Status: model.CommentStatusSpam}
+		mockCommentRepo.EXPECT().FindByID(gomock.Any(), comment.ID).Return(comment, nil)
+		mockCommentRepo.EXPECT().Update(gomock.Any(), comment).Return(comment, nil)
+
+		res, err := service.Update(ctx, comment.ID.String(), "user-1", &model.UpdateCommentRequest{Body: "Edited"})
+		require.NoError(t, err)
+		assert.Equal(t, model.CommentStatusSpam, res.Status)
+	})
+
+	t.Run("only the owner edits and deletes", func(t *testing.T) {
+		service, mockCommentRepo, _ := setup(t)
+
+		comment := &model.Comment{ID: uuid.New(), UserID: "user-1"}
+		mockCommentRepo.EXPECT().FindByID(gomock.Any(), comment.ID).Return(comment, nil).Times(2)
+
+		_, err := service.Update(ctx, comment.ID.String(), "user-2", &model.UpdateCommentRequest{Body: "Edited"})
+		assertErrorType(t, err, customErrors.ErrPermissionDenied)
+
+		err = service.Delete(ctx, comment.ID.String(), "user-2")
+		assertErrorType(t, err, customErrors.ErrPermissionDenied)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		service, mockCommentRepo, _ := setup(t)
+
+		comment := &model.Comment{ID: uuid.New(), UserID: "user-1"}
+		mockCommentRepo.EXPECT().FindByID(gomock.Any(), comment.ID).Return(comment, nil)
+		mockCommentRepo.EXPECT().Delete(gomock.Any(), comment).Return(nil)
+
+		require.NoError(t, service.Delete(ctx, comment.ID.String(), "user-1"))
+	})
+
+	t.Run("delete missing comment", func(t *testing.T) {
+		service, mockCommentRepo, _ := setup(t)
+
+		id := uuid.New()
+		mockCommentRepo.EXPECT().FindByID(gomock.Any(), id).Return(nil, nil)
+
+		err := service.Delete(ctx, id.String(), "user-1")
+		assertErrorType(t, err, customErrors.ErrRecordNotFound)
+	})
+
+	t.Run("moderate", func(t *testing.T) {
+		service, mockCommentRepo, _ := setup(t)
+		setConfig(t, "comment.moderators", []string{"mod"})
+
+		comment := &model.Comment{ID: uuid.New(), UserID: "user-1", Status: model.CommentStatusPending}
+		mockCommentRepo.EXPECT().FindByID(gomock.Any(), comment.ID).Return(comment, nil)
+		mockCommentRepo.EXPECT().Update(gomock.Any(), comment).Return(comment, nil)
+
+		res, err := service.Moderate(ctx, comment.ID.String(), "mod", &model.ModerateCommentRequest{Status: model.CommentStatusApproved})
+		require.NoError(t, err)
+		assert.Equal(t, model.CommentStatusApproved, res.Status)
+
+		_, err = service.Moderate(ctx, comment.ID.String(), "user-1", &model.ModerateCommentRequest{Status: model.CommentStatusApproved})
+		assertErrorType(t, err, customErrors.ErrPermissionDenied)
+	})
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertErrorType checks that err is a CustomError of the expected kind.
+func assertErrorType(t *testing.T, err error, expected error) {
+	t.Helper()
+
+	customErr, ok := err.(*customErrors.CustomError)
+	require.True(t, ok, "expected a CustomError, got %v", err)
+	assert.Equal(t, expected, customErr.Message)
+}
+
+// setConfig overrides a configuration key for the rest of the test.
+func setConfig(t *testing.T, key string, value any) {
+	t.Helper()
+
+	viper.Set(key, value)
+	t.Cleanup(func() { viper.Set(key, nil) })
+}
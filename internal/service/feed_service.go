@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/helper"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/gorilla/feeds"
+	"github.com/sirupsen/logrus"
+)
+
+var feedContentTypes = map[string]string{
+	model.FeedFormatRSS:  "application/rss+xml; charset=utf-8",
+	model.FeedFormatAtom: "application/atom+xml; charset=utf-8",
+	model.FeedFormatJSON: "application/feed+json; charset=utf-8",
+}
+
+type feedService struct {
+	articleService   model.ArticleMethodService
+	authorRepository model.AuthorRepository
+	cache            cache.Cache
+}
+
+// NewFeedService renders feeds from the article listing. Rendered feeds are cached
+// under model.FeedKey, which the article repository drops on every write.
+func NewFeedService(articleService model.ArticleMethodService, authorRepository model.AuthorRepository, cache cache.Cache) model.FeedMethodService {
+	return &feedService{
+		articleService:   articleService,
+		authorRepository: authorRepository,
+		cache:            cache,
+	}
+}
+
+func (s *feedService) Render(ctx context.Context, query model.FeedQuery) (*model.Feed, error) {
+	ctx, span := tracing.Start(ctx, "feedService.Render")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"query": query,
+	})
+
+	if !slices.Contains(model.FeedFormats, query.Format) {
+		err := errors.New(errors.ErrInvalidData, fmt.Sprintf("feed format must be one of %s", strings.Join(model.FeedFormats, ", ")))
+		log.Error(err)
+		return nil, err
+	}
+
+	if query.Content == "" {
+		query.Content = model.FeedContentExcerpt
+	}
+	if query.Content != model.FeedContentExcerpt && query.Content != model.FeedContentFull {
+		err := errors.New(errors.ErrInvalidData, fmt.Sprintf("content must be %s or %s", model.FeedContentExcerpt, model.FeedContentFull))
+		log.Error(err)
+		return nil, err
+	}
+
+	if query.AuthorID != "" {
+		id, err := uuid.Parse(query.AuthorID)
+		if err != nil {
+			err := errors.New(errors.ErrInvalidData, "invalid author id format")
+			log.Error(err)
+			return nil, err
+		}
+		query.AuthorID = id.String()
+	}
+	query.Tag = helper.Slugify(query.Tag)
+
+	key := feedCacheKey(query)
+
+	var cached model.Feed
+	if err := s.cache.Get(ctx, key, &cached); err == nil {
+		return &cached, nil
+	}
+
+	feed, err := s.build(ctx, query)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// HTTP dates have second precision, so If-Modified-Since compares against this.
+	lastModified := feed.Updated.UTC().Truncate(time.Second)
+	if feed.Updated.IsZero() {
+		// Atom requires a date even for a feed without entries.
+		feed.Updated = time.Now()
+	}
+	feed.Created = feed.Updated
+
+	rendered, err := renderFeed(feed, query.Format)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	sum := sha256.Sum256(rendered)
+	result := &model.Feed{
+		Body:         rendered,
+		ContentType:  feedContentTypes[query.Format],
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: lastModified,
+	}
+
+	if err := s.cache.Set(ctx, key, result, config.RedisExpired()); err != nil {
+		log.Warn("failed to cache feed")
+	}
+
+	return result, nil
+}
+
+// build collects the newest articles of the feed.
+func (s *feedService) build(ctx context.Context, query model.FeedQuery) (*feeds.Feed, error) {
+	feed := &feeds.Feed{
+		Title:       config.SiteTitle(),
+		Link:        &feeds.Link{Href: config.SiteURL()},
+		Description: config.SiteDescription(),
+	}
+
+	filter := model.ArticleQuery{Page: 1, Limit: config.FeedLimit(), Tag: query.Tag}
+	if query.AuthorID != "" {
+		id, _ := uuid.Parse(query.AuthorID)
+		author, err := s.authorRepository.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if author == nil {
+			return nil, errors.New(errors.ErrRecordNotFound, "author not found")
+		}
+
+		feed.Title = fmt.Sprintf("%s: %s", config.SiteTitle(), author.Name)
+		feed.Author = &feeds.Author{Name: author.Name}
+		filter.AuthorIDs = []string{query.AuthorID}
+	}
+	if query.Tag != "" {
+		feed.Title = fmt.Sprintf("%s: #%s", feed.Title, query.Tag)
+	}
+
+	if query.Content == model.FeedContentFull {
+		filter.Format = model.ArticleFormatHTML
+	} else {
+		filter.View = model.ArticleViewSummary
+	}
+
+	articles, _, err := s.articleService.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range articles {
+		item := &feeds.Item{
+			Title:       a.Title,
+			Link:        &feeds.Link{Href: config.ArticleURL(a.Slug)},
+			Author:      &feeds.Author{Name: a.Author},
+			Description: a.Excerpt,
+			Id:          "urn:uuid:" + a.ID.String(),
+			Created:     a.CreatedAt,
+			Updated:     a.UpdatedAt,
+		}
+		if query.Content == model.FeedContentFull {
+			item.Content = a.Body
+		}
+		feed.Items = append(feed.Items, item)
+
+		if a.UpdatedAt.After(feed.Updated) {
+			feed.Updated = a.UpdatedAt
+		}
+	}
+
+	return feed, nil
+}
+
+func renderFeed(feed *feeds.Feed, format string) ([]byte, error) {
+	var (
+		rendered string
+		err      error
+	)
+	switch format {
+	case model.FeedFormatAtom:
+		rendered, err = feed.ToAtom()
+	case model.FeedFormatJSON:
+		rendered, err = feed.ToJSON()
+	default:
+		rendered, err = feed.ToRss()
+	}
+	return []byte(rendered), err
+}
+
+func feedCacheKey(query model.FeedQuery) string {
+	return fmt.Sprintf("%s:%s:author=%s:tag=%s:content=%s", model.FeedKey, query.Format, query.AuthorID, query.Tag, query.Content)
+}
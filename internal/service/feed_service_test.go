@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewFeedService(t *testing.T) {
+	s := NewFeedService(nil, nil, nil)
+	require.NotNil(t, s)
+}
+
+func TestFeedService_Render(t *testing.T) {
+	ctx := context.TODO()
+
+	setup := func(t *testing.T) (*feedService, *mocks.MockArticleMethodService, *mocks.MockAuthorRepository, *cache.MockCache) {
+		ctrl := gomock.NewController(t)
+		mockArticleService := mocks.NewMockArticleMethodService(ctrl)
+		mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
+		mockCache := cache.NewMockCache()
+		return &feedService{
+			articleService:   mockArticleService,
+			authorRepository: mockAuthorRepo,
+			cache:            mockCache,
+		}, mockArticleService, mockAuthorRepo, mockCache
+	}
+
+	older := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 5, 2, 9, 30, 15, 500, time.UTC)
+	articles := func() []*model.Article {
+		return []*model.Article{
+			{ID: uuid.New(), Title: "Newest", Slug: "newest", Author: "Jane Doe", Excerpt: "Newest excerpt", Body: "<p>Newest body</p>", CreatedAt: newer, UpdatedAt: newer},
+			{ID: uuid.New(), Title: "Older", Slug: "older", Author: "John Roe", Excerpt: "Older excerpt", Body: "<p>Older body</p>", CreatedAt: older, UpdatedAt: older},
+		}
+	}
+
+	t.Run("rss with excerpts is cached", func(t *testing.T) {
+		service, mockArticleService, _, mockCache := setup(t)
+
+		mockArticleService.EXPECT().
+			FindAll(gomock.Any(), model.ArticleQuery{Page: 1, Limit: 20, View: model.ArticleViewSummary}).
+			Return(articles(), 2, nil).
+			Times(1)
+
+		feed, err := service.Render(ctx, model.FeedQuery{Format: model.FeedFormatRSS})
+		require.NoError(t, err)
+		assert.Equal(t, "application/rss+xml; charset=utf-8", feed.ContentType)
+		assert.Equal(t, newer.Truncate(time.Second), feed.LastModified)
+		assert.NotEmpty(t, feed.ETag)
+
+		body := string(feed.Body)
+		assert.Contains(t, body, "<title>Newest</title>")
+		assert.Contains(t, body, "/api/v1/article/slug/newest")
+		assert.Contains(t, body, "Older excerpt")
+		assert.NotContains(t, body, "Older body")
+
+		var cached model.Feed
+		require.NoError(t, mockCache.Get(ctx, "feed:rss:author=:tag=:content=excerpt", &cached))
+
+		again, err := service.Render(ctx, model.FeedQuery{Format: model.FeedFormatRSS})
+		require.NoError(t, err)
+		assert.Equal(t, feed.ETag, again.ETag)
+		assert.Equal(t, feed.Body, again.Body)
+	})
+
+	t.Run("json feed with full content for an author", func(t *testing.T) {
+		service, mockArticleService, mockAuthorRepo, _ := setup(t)
+
+		authorID := uuid.New()
+		mockAuthorRepo.EXPECT().FindByID(gomock.Any(), authorID).Return(&model.Author{ID: authorID, Name: "Jane Doe"}, nil)
+		mockArticleService.EXPECT().
+			FindAll(gomock.Any(), model.ArticleQuery{
+				Page: 1, Limit: 20, AuthorIDs: []string{authorID.String()},
+				ArticleReadOptions: model.ArticleReadOptions{Format: model.ArticleFormatHTML},
+			}).
+			Return(articles()[:1], 1, nil)
+
+		feed, err := service.Render(ctx, model.FeedQuery{Format: model.FeedFormatJSON, AuthorID: strings.ToUpper(authorID.String()), Content: model.FeedContentFull})
+		require.NoError(t, err)
+		assert.Equal(t, "application/feed+json; charset=utf-8", feed.ContentType)
+
+		var doc struct {
+			Title string `json:"title"`
+			Items []struct {
+				ContentHTML string `json:"content_html"`
+			} `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(feed.Body, &doc))
+		assert.Equal(t, "go-article: Jane Doe", doc.Title)
+		require.Len(t, doc.Items, 1)
+		assert.Equal(t, "<p>Newest body</p>", doc.Items[0].ContentHTML)
+	})
+
+	t.Run("atom feed for a tag", func(t *testing.T) {
+		service, mockArticleService, _, _ := setup(t)
+
+		mockArticleService.EXPECT().
+			FindAll(gomock.Any(), model.ArticleQuery{Page: 1, Limit: 20, Tag: "go-lang", View: model.ArticleViewSummary}).
+			Return([]*model.Article{}, 0, nil)
+
+		feed, err := service.Render(ctx, model.FeedQuery{Format: model.FeedFormatAtom, Tag: "Go Lang"})
+		require.NoError(t, err)
+		assert.Equal(t, "application/atom+xml; charset=utf-8", feed.ContentType)
+		assert.Contains(t, string(feed.Body), "go-article: #go-lang")
+		assert.NotContains(t, string(feed.Body), "<updated></updated>")
+		assert.True(t, feed.LastModified.IsZero())
+	})
+
+	t.Run("unknown author", func(t *testing.T) {
+		service, _, mockAuthorRepo, _ := setup(t)
+
+		mockAuthorRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		feed, err := service.Render(ctx, model.FeedQuery{Format: model.FeedFormatRSS, AuthorID: uuid.NewString()})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
+		assert.Nil(t, feed)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		service, _, _, _ := setup(t)
+
+		for _, query := range []model.FeedQuery{
+			{Format: "xml"},
+			{Format: model.FeedFormatRSS, Content: "partial"},
+			{Format: model.FeedFormatRSS, AuthorID: "not-a-uuid"},
+		} {
+			feed, err := service.Render(ctx, query)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+			assert.Nil(t, feed)
+		}
+	})
+
+	t.Run("listing error", func(t *testing.T) {
+		service, mockArticleService, _, _ := setup(t)
+
+		mockArticleService.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, 0, errors.New("db failure"))
+
+		feed, err := service.Render(ctx, model.FeedQuery{Format: model.FeedFormatRSS})
+		assert.EqualError(t, err, "db failure")
+		assert.Nil(t, feed)
+	})
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type reactionService struct {
+	reactionRepository model.ReactionRepository
+	articleRepository  model.ArticleRepository
+	articleService     model.ArticleMethodService
+	counter            cache.Counter
+}
+
+// NewReactionService records reactions in the database right away but counts them in
+// counter; Flush moves the counts to the database.
+func NewReactionService(reactionRepository model.ReactionRepository, articleRepository model.ArticleRepository, articleService model.ArticleMethodService, counter cache.Counter) model.ReactionMethodService {
+	return &reactionService{
+		reactionRepository: reactionRepository,
+		articleRepository:  articleRepository,
+		articleService:     articleService,
+		counter:            counter,
+	}
+}
+
+func (s *reactionService) React(ctx context.Context, articleID, userID, reaction string) error {
+	ctx, span := tracing.Start(ctx, "reactionService.React")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"article_id": articleID,
+		"user_id":    userID,
+		"reaction":   reaction,
+	})
+
+	article, err := s.findTarget(ctx, articleID, userID, reaction)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	added, err := s.reactionRepository.Add(ctx, article.ID, userID, reaction)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if added {
+		s.count(ctx, article.ID, reaction, 1)
+	}
+
+	return nil
+}
+
+func (s *reactionService) Unreact(ctx context.Context, articleID, userID, reaction string) error {
+	ctx, span := tracing.Start(ctx, "reactionService.Unreact")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"article_id": articleID,
+		"user_id":    userID,
+		"reaction":   reaction,
+	})
+
+	article, err := s.findTarget(ctx, articleID, userID, reaction)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	removed, err := s.reactionRepository.Remove(ctx, article.ID, userID, reaction)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if removed {
+		s.count(ctx, article.ID, reaction, -1)
+	}
+
+	return nil
+}
+
+func (s *reactionService) Bookmark(ctx context.Context, articleID, userID string) error {
+	ctx, span := tracing.Start(ctx, "reactionService.Bookmark")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"article_id": articleID,
+		"user_id":    userID,
+	})
+
+	article, err := s.findTarget(ctx, articleID, userID, "")
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := s.reactionRepository.AddBookmark(ctx, article.ID, userID); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *reactionService) Unbookmark(ctx context.Context, articleID, userID string) error {
+	ctx, span := tracing.Start(ctx, "reactionService.Unbookmark")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"article_id": articleID,
+		"user_id":    userID,
+	})
+
+	article, err := s.findTarget(ctx, articleID, userID, "")
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := s.reactionRepository.RemoveBookmark(ctx, article.ID, userID); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *reactionService) Bookmarks(ctx context.Context, userID string, filter model.ArticleQuery) ([]*model.Article, int, error) {
+	ctx, span := tracing.Start(ctx, "reactionService.Bookmarks")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"user_id": userID,
+	})
+
+	if err := requireUser(userID); err != nil {
+		log.Error(err)
+		return nil, 0, err
+	}
+
+	filter.BookmarkedBy = userID
+
+	return s.articleService.FindAll(ctx, filter)
+}
+
+func (s *reactionService) Flush(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "reactionService.Flush")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	drained, err := s.counter.Drain(ctx, model.ReactionCounterKey+":")
+	if err != nil {
+		log.Error(err)
+	}
+	if len(drained) == 0 {
+		return err
+	}
+
+	deltas := make(map[uuid.UUID]map[string]int64, len(drained))
+	for key, byType := range drained {
+		id, parseErr := uuid.Parse(key)
+		if parseErr != nil {
+			log.WithField("key", key).Warn("dropping reaction counts of an unknown key")
+			continue
+		}
+		deltas[id] = byType
+	}
+
+	if applyErr := s.reactionRepository.ApplyCounts(ctx, deltas); applyErr != nil {
+		log.Error(applyErr)
+		// Put the counts back so the next flush retries them.
+		for articleID, byType := range deltas {
+			for reaction, delta := range byType {
+				s.count(ctx, articleID, reaction, delta)
+			}
+		}
+		return applyErr
+	}
+
+	return err
+}
+
+// findTarget validates the input of a reaction or, when reaction is empty, a bookmark
+// and returns the article it applies to.
+func (s *reactionService) findTarget(ctx context.Context, articleID, userID, reaction string) (*model.Article, error) {
+	if err := requireUser(userID); err != nil {
+		return nil, err
+	}
+
+	if reaction != "" && !slices.Contains(model.ReactionTypes, reaction) {
+		return nil, errors.New(errors.ErrInvalidData, fmt.Sprintf("reaction must be one of %s", strings.Join(model.ReactionTypes, ", ")))
+	}
+
+	id, err := uuid.Parse(articleID)
+	if err != nil {
+		return nil, errors.New(errors.ErrInvalidData, "invalid article ID format")
+	}
+
+	article, err := s.articleRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if article == nil {
+		return nil, errors.New(errors.ErrRecordNotFound, "article not found")
+	}
+
+	return article, nil
+}
+
+// count adds delta to the pending count of a reaction. The reaction itself is already
+// stored, so a failure is logged rather than returned.
+func (s *reactionService) count(ctx context.Context, articleID uuid.UUID, reaction string, delta int64) {
+	key := fmt.Sprintf("%s:%s", model.ReactionCounterKey, articleID)
+	if err := s.counter.IncrBy(ctx, key, reaction, delta); err != nil {
+		logger.FromContext(ctx).WithError(err).Warn("failed to count reaction")
+	}
+}
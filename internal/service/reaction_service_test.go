@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewReactionService(t *testing.T) {
+	s := NewReactionService(nil, nil, nil, nil)
+	require.NotNil(t, s)
+}
+
+func TestReactionService(t *testing.T) {
+	ctx := context.TODO()
+
+	type kit struct {
+		service      *reactionService
+		reactionRepo *mocks.MockReactionRepository
+		articleRepo  *mocks.MockArticleRepository
+		articleSvc   *mocks.MockArticleMethodService
+		counter      *cache.MockCache
+	}
+	setup := func(t *testing.T) kit {
+		ctrl := gomock.NewController(t)
+		k := kit{
+			reactionRepo: mocks.NewMockReactionRepository(ctrl),
+			articleRepo:  mocks.NewMockArticleRepository(ctrl),
+			articleSvc:   mocks.NewMockArticleMethodService(ctrl),
+			counter:      cache.NewMockCache(),
+		}
+		k.service = &reactionService{
+			reactionRepository: k.reactionRepo,
+			articleRepository:  k.articleRepo,
+			articleService:     k.articleSvc,
+			counter:            k.counter,
+		}
+		return k
+	}
+
+	article := &model.Article{ID: uuid.New()}
+
+	t.Run("react counts only new reactions", func(t *testing.T) {
+		k := setup(t)
+
+		k.articleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(article, nil).Times(2)
+		gomock.InOrder(
+			k.reactionRepo.EXPECT().Add(gomock.Any(), article.ID, "user-1", model.ReactionLike).Return(true, nil),
+			k.reactionRepo.EXPECT().Add(gomock.Any(), article.ID, "user-1", model.ReactionLike).Return(false, nil),
+		)
+
+		require.NoError(t, k.service.React(ctx, article.ID.String(), "user-1", model.ReactionLike))
+		require.NoError(t, k.service.React(ctx, article.ID.String(), "user-1", model.ReactionLike))
+
+		drained, err := k.counter.Drain(ctx, model.ReactionCounterKey+":")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]map[string]int64{article.ID.String(): {model.ReactionLike: 1}}, drained)
+	})
+
+	t.Run("unreact decrements", func(t *testing.T) {
+		k := setup(t)
+
+		k.articleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(article, nil)
+		k.reactionRepo.EXPECT().Remove(gomock.Any(), article.ID, "user-1", model.ReactionLove).Return(true, nil)
+
+		require.NoError(t, k.service.Unreact(ctx, article.ID.String(), "user-1", model.ReactionLove))
+
+		drained, err := k.counter.Drain(ctx, model.ReactionCounterKey+":")
+		require.NoError(t, err)
+		assert.Equal(t, int64(-1), drained[article.ID.String()][model.ReactionLove])
+	})
+
+	t.Run("rejects bad input", func(t *testing.T) {
+		k := setup(t)
+
+		err := k.service.React(ctx, article.ID.String(), "", model.ReactionLike)
+		assertErrorType(t, err, customErrors.ErrUnauthorized)
+
+		err = k.service.React(ctx, article.ID.String(), "user-1", "angry")
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+
+		err = k.service.Bookmark(ctx, "bad", "user-1")
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+	})
+
+	t.Run("missing article", func(t *testing.T) {
+		k := setup(t)
+
+		k.articleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(nil, nil)
+
+		err := k.service.Bookmark(ctx, article.ID.String(), "user-1")
+		assertErrorType(t, err, customErrors.ErrRecordNotFound)
+	})
+
+	t.Run("bookmark and unbookmark", func(t *testing.T) {
+		k := setup(t)
+
+		k.articleRepo.EXPECT().FindByID(gomock.Any(), article.ID).Return(article, nil).Times(2)
+		k.reactionRepo.EXPECT().AddBookmark(gomock.Any(), article.ID, "user-1").Return(nil)
+		k.reactionRepo.EXPECT().RemoveBookmark(gomock.Any(), article.ID, "user-1").Return(nil)
+
+		require.NoError(t, k.service.Bookmark(ctx, article.ID.String(), "user-1"))
+		require.NoError(t, k.service.Unbookmark(ctx, article.ID.String(), "user-1"))
+	})
+
+	t.Run("bookmarks list the user's articles", func(t *testing.T) {
+		k := setup(t)
+
+		k.articleSvc.EXPECT().
+			FindAll(gomock.Any(), model.ArticleQuery{Page: 2, BookmarkedBy: "user-1"}).
+			Return([]*model.Article{article}, 1, nil)
+
+		res, total, err := k.service.Bookmarks(ctx, "user-1", model.ArticleQuery{Page: 2, BookmarkedBy: "someone-else"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, res, 1)
+
+		_, _, err = k.service.Bookmarks(ctx, "", model.ArticleQuery{})
+		assertErrorType(t, err, customErrors.ErrUnauthorized)
+	})
+
+	t.Run("flush applies drained counts", func(t *testing.T) {
+		k := setup(t)
+
+		key := model.ReactionCounterKey + ":" + article.ID.String()
+		require.NoError(t, k.counter.IncrBy(ctx, key, model.ReactionLike, 2))
+		require.NoError(t, k.counter.IncrBy(ctx, model.ReactionCounterKey+":not-an-id", model.ReactionLike, 1))
+
+		k.reactionRepo.EXPECT().
+			ApplyCounts(gomock.Any(), map[uuid.UUID]map[string]int64{article.ID: {model.ReactionLike: 2}}).
+			Return(nil)
+
+		require.NoError(t, k.service.Flush(ctx))
+
+		drained, err := k.counter.Drain(ctx, model.ReactionCounterKey+":")
+		require.NoError(t, err)
+		assert.Empty(t, drained)
+	})
+
+	t.Run("flush puts counts back on error", func(t *testing.T) {
+		k := setup(t)
+
+		key := model.ReactionCounterKey + ":" + article.ID.String()
+		require.NoError(t, k.counter.IncrBy(ctx, key, model.ReactionLike, 2))
+
+		k.reactionRepo.EXPECT().ApplyCounts(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+		require.Error(t, k.service.Flush(ctx))
+
+		drained, err := k.counter.Drain(ctx, model.ReactionCounterKey+":")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), drained[article.ID.String()][model.ReactionLike])
+	})
+
+	t.Run("flush without counts", func(t *testing.T) {
+		k := setup(t)
+
+		require.NoError(t, k.service.Flush(ctx))
+	})
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// reindexBatchSize is the number of articles Reindex indexes at once.
+const reindexBatchSize = 500
+
+type searchIndexService struct {
+	searchIndex        model.SearchIndex
+	articleRepository  model.ArticleRepository
+	categoryRepository model.CategoryRepository
+}
+
+func NewSearchIndexService(searchIndex model.SearchIndex, articleRepository model.ArticleRepository, categoryRepository model.CategoryRepository) model.SearchIndexMethodService {
+	return &searchIndexService{
+		searchIndex:        searchIndex,
+		articleRepository:  articleRepository,
+		categoryRepository: categoryRepository,
+	}
+}
+
+// HandleArticleEvent indexes the article of a saved event as it is now in the
+// database. The write already happened, so failures are only logged; a reindex
+// repairs the index.
+func (s *searchIndexService) HandleArticleEvent(ctx context.Context, event model.ArticleEvent) {
+	ctx, span := tracing.Start(ctx, "searchIndexService.HandleArticleEvent")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"event": event,
+	})
+
+	if event.Type != model.ArticleSaved {
+		return
+	}
+
+	article, err := s.articleRepository.FindByID(ctx, event.ArticleID)
+	if err != nil {
+		log.WithError(err).Warn("failed to index article")
+		return
+	}
+
+	if article == nil {
+		if err := s.searchIndex.Delete(ctx, event.ArticleID); err != nil {
+			log.WithError(err).Warn("failed to remove article from the search index")
+		}
+		return
+	}
+
+	doc, err := s.document(ctx, article, map[uuid.UUID][]string{})
+	if err != nil {
+		log.WithError(err).Warn("failed to index article")
+		return
+	}
+
+	if err := s.searchIndex.Index(ctx, doc); err != nil {
+		log.WithError(err).Warn("failed to index article")
+	}
+}
+
+func (s *searchIndexService) Reindex(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "searchIndexService.Reindex")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	if err := s.searchIndex.Reset(ctx); err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	var (
+		indexed    int
+		batch      = make([]*model.SearchDocument, 0, reindexBatchSize)
+		categories = make(map[uuid.UUID][]string)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.searchIndex.Index(ctx, batch...); err != nil {
+			return err
+		}
+		indexed += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	err := s.articleRepository.Stream(ctx, model.ArticleQuery{}, func(article *model.Article) error {
+		doc, err := s.document(ctx, article, categories)
+		if err != nil {
+			return err
+		}
+		batch = append(batch, doc)
+		if len(batch) < reindexBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		log.Error(err)
+		return indexed, err
+	}
+
+	return indexed, nil
+}
+
+// document builds the search document of article. categories caches the category
+// paths already resolved.
+func (s *searchIndexService) document(ctx context.Context, article *model.Article, categories map[uuid.UUID][]string) (*model.SearchDocument, error) {
+	doc := &model.SearchDocument{
+		ID:        article.ID,
+		AuthorID:  article.AuthorID,
+		Author:    article.Author,
+		Title:     article.Title,
+		Body:      article.Body,
+		Language:  article.Language,
+		Tags:      article.Tags,
+		CreatedAt: article.CreatedAt,
+		UpdatedAt: article.UpdatedAt,
+	}
+
+	if article.CategoryID == nil {
+		return doc, nil
+	}
+
+	slugs, ok := categories[*article.CategoryID]
+	if !ok {
+		path, err := s.categoryRepository.FindAncestors(ctx, *article.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range path {
+			slugs = append(slugs, category.Slug)
+		}
+		categories[*article.CategoryID] = slugs
+	}
+	doc.Categories = slugs
+
+	return doc, nil
+}
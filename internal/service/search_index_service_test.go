@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewSearchIndexService(t *testing.T) {
+	s := NewSearchIndexService(nil, nil, nil)
+	require.NotNil(t, s)
+}
+
+func TestSearchIndexService_HandleArticleEvent(t *testing.T) {
+	ctx := context.TODO()
+
+	setup := func(t *testing.T) (*searchIndexService, *mocks.MockSearchIndex, *mocks.MockArticleRepository, *mocks.MockCategoryRepository) {
+		ctrl := gomock.NewController(t)
+		searchIndex := mocks.NewMockSearchIndex(ctrl)
+		articleRepo := mocks.NewMockArticleRepository(ctrl)
+		categoryRepo := mocks.NewMockCategoryRepository(ctrl)
+		return &searchIndexService{
+			searchIndex:        searchIndex,
+			articleRepository:  articleRepo,
+			categoryRepository: categoryRepo,
+		}, searchIndex, articleRepo, categoryRepo
+	}
+
+	t.Run("indexes the saved article", func(t *testing.T) {
+		s, searchIndex, articleRepo, categoryRepo := setup(t)
+		id, categoryID := uuid.New(), uuid.New()
+		article := &model.Article{
+			ID:         id,
+			AuthorID:   uuid.New(),
+			Author:     "Jane",
+			Title:      "Go",
+			Body:       "Body",
+			CategoryID: &categoryID,
+			Tags:       []string{"go"},
+		}
+
+		articleRepo.EXPECT().FindByID(gomock.Any(), id).Return(article, nil)
+		categoryRepo.EXPECT().FindAncestors(gomock.Any(), categoryID).Return([]*model.Category{
+			{Slug: "tech"}, {Slug: "golang"},
+		}, nil)
+		searchIndex.EXPECT().Index(gomock.Any(), &model.SearchDocument{
+			ID:         id,
+			AuthorID:   article.AuthorID,
+			Author:     "Jane",
+			Title:      "Go",
+			Body:       "Body",
+			Categories: []string{"tech", "golang"},
+			Tags:       []string{"go"},
+		}).Return(nil)
+
+		s.HandleArticleEvent(ctx, model.ArticleEvent{Type: model.ArticleSaved, ArticleID: id})
+	})
+
+	t.Run("removes an article that no longer exists", func(t *testing.T) {
+		s, searchIndex, articleRepo, _ := setup(t)
+		id := uuid.New()
+
+		articleRepo.EXPECT().FindByID(gomock.Any(), id).Return(nil, nil)
+		searchIndex.EXPECT().Delete(gomock.Any(), id).Return(nil)
+
+		s.HandleArticleEvent(ctx, model.ArticleEvent{Type: model.ArticleSaved, ArticleID: id})
+	})
+
+	t.Run("ignores unknown events", func(t *testing.T) {
+		s, _, _, _ := setup(t)
+
+		s.HandleArticleEvent(ctx, model.ArticleEvent{Type: "other", ArticleID: uuid.New()})
+	})
+
+	t.Run("only logs failures", func(t *testing.T) {
+		s, searchIndex, articleRepo, _ := setup(t)
+		id := uuid.New()
+
+		articleRepo.EXPECT().FindByID(gomock.Any(), id).Return(&model.Article{ID: id}, nil)
+		searchIndex.EXPECT().Index(gomock.Any(), gomock.Any()).Return(errors.New("index error"))
+
+		s.HandleArticleEvent(ctx, model.ArticleEvent{Type: model.ArticleSaved, ArticleID: id})
+	})
+}
+
+func TestSearchIndexService_Reindex(t *testing.T) {
+	ctx := context.TODO()
+
+	setup := func(t *testing.T) (*searchIndexService, *mocks.MockSearchIndex, *mocks.MockArticleRepository, *mocks.MockCategoryRepository) {
+		ctrl := gomock.NewController(t)
+		searchIndex := mocks.NewMockSearchIndex(ctrl)
+		articleRepo := mocks.NewMockArticleRepository(ctrl)
+		categoryRepo := mocks.NewMockCategoryRepository(ctrl)
+		return &searchIndexService{
+			searchIndex:        searchIndex,
+			articleRepository:  articleRepo,
+			categoryRepository: categoryRepo,
+		}, searchIndex, articleRepo, categoryRepo
+	}
+
+	t.Run("indexes every article in batches", func(t *testing.T) {
+		s, searchIndex, articleRepo, categoryRepo := setup(t)
+		categoryID := uuid.New()
+		total := reindexBatchSize + 2
+
+		searchIndex.EXPECT().Reset(gomock.Any()).Return(nil)
+		articleRepo.EXPECT().Stream(gomock.Any(), model.ArticleQuery{}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.ArticleQuery, fn func(*model.Article) error) error {
+				for i := 0; i < total; i++ {
+					if err := fn(&model.Article{ID: uuid.New(), CategoryID: &categoryID}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		categoryRepo.EXPECT().FindAncestors(gomock.Any(), categoryID).
+			Return([]*model.Category{{Slug: "tech"}}, nil).Times(1)
+
+		var batches []int
+		searchIndex.EXPECT().Index(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, docs ...*model.SearchDocument) error {
+				batches = append(batches, len(docs))
+				for _, doc := range docs {
+					assert.Equal(t, []string{"tech"}, doc.Categories)
+				}
+				return nil
+			}).Times(2)
+
+		indexed, err := s.Reindex(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, total, indexed)
+		assert.Equal(t, []int{reindexBatchSize, 2}, batches)
+	})
+
+	t.Run("reset error", func(t *testing.T) {
+		s, searchIndex, _, _ := setup(t)
+
+		searchIndex.EXPECT().Reset(gomock.Any()).Return(errors.New("reset error"))
+
+		indexed, err := s.Reindex(ctx)
+		assert.EqualError(t, err, "reset error")
+		assert.Zero(t, indexed)
+	})
+
+	t.Run("stream error", func(t *testing.T) {
+		s, searchIndex, articleRepo, _ := setup(t)
+
+		searchIndex.EXPECT().Reset(gomock.Any()).Return(nil)
+		articleRepo.EXPECT().Stream(gomock.Any(), model.ArticleQuery{}, gomock.Any()).
+			Return(errors.New("db error"))
+
+		indexed, err := s.Reindex(ctx)
+		assert.EqualError(t, err, "db error")
+		assert.Zero(t, indexed)
+	})
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/sirupsen/logrus"
+)
+
+// Bounds of the suggestion prefix. Shorter prefixes match too much to be useful and
+// cannot use the trigram indexes.
+const (
+	minSuggestPrefix = 2
+	maxSuggestPrefix = 100
+)
+
+type searchService struct {
+	searchRepository model.SearchRepository
+	searchIndex      model.SearchIndex
+	cache            cache.Cache
+}
+
+// NewSearchService returns the search service. Facets are counted by searchIndex and
+// cached under FacetKey until an article listing changes.
+func NewSearchService(searchRepository model.SearchRepository, searchIndex model.SearchIndex, cache cache.Cache) model.SearchMethodService {
+	return &searchService{
+		searchRepository: searchRepository,
+		searchIndex:      searchIndex,
+		cache:            cache,
+	}
+}
+
+func (s *searchService) Suggest(ctx context.Context, query model.SuggestQuery) (*model.Suggestions, error) {
+	ctx, span := tracing.Start(ctx, "searchService.Suggest")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"query": query,
+	})
+
+	prefix := strings.ToLower(strings.Join(strings.Fields(query.Q), " "))
+	if utf8.RuneCountInString(prefix) > maxSuggestPrefix {
+		err := errors.New(errors.ErrInvalidData, fmt.Sprintf("q must be at most %d characters", maxSuggestPrefix))
+		log.Error(err)
+		return nil, err
+	}
+	if utf8.RuneCountInString(prefix) < minSuggestPrefix {
+		return &model.Suggestions{Articles: []*model.ArticleSuggestion{}, Authors: []*model.AuthorSuggestion{}}, nil
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = config.DefaultSuggestLimit
+	}
+	query.Limit = min(query.Limit, config.MaxSuggestLimit)
+
+	suggestions, err := s.searchRepository.Suggest(ctx, prefix, query.Limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+func (s *searchService) DidYouMean(ctx context.Context, query string) (string, error) {
+	ctx, span := tracing.Start(ctx, "searchService.DidYouMean")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"query": query,
+	})
+
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	similar, err := s.searchRepository.SimilarWords(ctx, words)
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+
+	changed := false
+	for i, word := range similar {
+		if i < len(words) && word != "" && word != words[i] {
+			words[i] = word
+			changed = true
+		}
+	}
+	if !changed {
+		return "", nil
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+func (s *searchService) RefreshWords(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "searchService.RefreshWords")
+	defer span.End()
+
+	if err := s.searchRepository.RefreshWords(ctx); err != nil {
+		logger.FromContext(ctx).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *searchService) Facets(ctx context.Context, filter model.ArticleQuery) (*model.Facets, error) {
+	ctx, span := tracing.Start(ctx, "searchService.Facets")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"filter": filter,
+	})
+
+	if err := normalizeArticleFilter(&filter); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	key := facetCacheKey(filter)
+
+	var cached model.Facets
+	if err := s.cache.Get(ctx, key, &cached); err == nil {
+		return &cached, nil
+	}
+
+	result, err := s.searchIndex.Search(ctx, model.SearchIndexQuery{
+		Filter:     filter,
+		FacetLimit: config.DefaultFacetLimit,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, key, result.Facets, config.RedisExpired()); err != nil {
+		log.Warn("failed to cache facets")
+	}
+
+	return result.Facets, nil
+}
+
+// facetCacheKey identifies the matches of a normalized filter: the search text and
+// author name are matched case-insensitively, and neither the order of the listed
+// values nor paging, sorting or representation change what matches.
+func facetCacheKey(filter model.ArticleQuery) string {
+	matching := model.ArticleQuery{
+		Query:        strings.ToLower(filter.Query),
+		Lang:         filter.Lang,
+		Author:       strings.ToLower(filter.Author),
+		AuthorIDs:    slices.Sorted(slices.Values(filter.AuthorIDs)),
+		Category:     filter.Category,
+		Tag:          filter.Tag,
+		TagsAny:      slices.Sorted(slices.Values(filter.TagsAny)),
+		TagsAll:      slices.Sorted(slices.Values(filter.TagsAll)),
+		CreatedFrom:  filter.CreatedFrom,
+		CreatedTo:    filter.CreatedTo,
+		BookmarkedBy: filter.BookmarkedBy,
+	}
+
+	// BookmarkedBy is left out of the JSON encoding of a query, so it is hashed apart.
+	encoded, _ := json.Marshal(matching)
+	sum := sha256.Sum256(append(encoded, matching.BookmarkedBy...))
+	return fmt.Sprintf("%s:%s", model.FacetKey, hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bagasss3/go-article/internal/config"
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewSearchService(t *testing.T) {
+	s := NewSearchService(nil, nil, nil)
+	require.NotNil(t, s)
+}
+
+func TestSearchService_Suggest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockSearchRepo := mocks.NewMockSearchRepository(ctrl)
+	searchService := &searchService{searchRepository: mockSearchRepo}
+
+	t.Run("normalizes the prefix", func(t *testing.T) {
+		expected := &model.Suggestions{Articles: []*model.ArticleSuggestion{{Title: "Go Generics"}}}
+		mockSearchRepo.EXPECT().Suggest(gomock.Any(), "go gen", config.DefaultSuggestLimit).Return(expected, nil)
+
+		res, err := searchService.Suggest(ctx, model.SuggestQuery{Q: "  Go   Gen "})
+		require.NoError(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		mockSearchRepo.EXPECT().Suggest(gomock.Any(), "go", config.MaxSuggestLimit).Return(&model.Suggestions{}, nil)
+
+		_, err := searchService.Suggest(ctx, model.SuggestQuery{Q: "go", Limit: 100})
+		require.NoError(t, err)
+	})
+
+	t.Run("short prefix matches nothing", func(t *testing.T) {
+		res, err := searchService.Suggest(ctx, model.SuggestQuery{Q: "g"})
+		require.NoError(t, err)
+		assert.Empty(t, res.Articles)
+		assert.Empty(t, res.Authors)
+	})
+
+	t.Run("long prefix", func(t *testing.T) {
+		_, err := searchService.Suggest(ctx, model.SuggestQuery{Q: strings.Repeat("a", 101)})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockSearchRepo.EXPECT().Suggest(gomock.Any(), "go", gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := searchService.Suggest(ctx, model.SuggestQuery{Q: "go"})
+		require.Error(t, err)
+	})
+}
+
+func TestSearchService_DidYouMean(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockSearchRepo := mocks.NewMockSearchRepository(ctrl)
+	searchService := &searchService{searchRepository: mockSearchRepo}
+
+	t.Run("respells unknown words", func(t *testing.T) {
+		mockSearchRepo.EXPECT().SimilarWords(gomock.Any(), []string{"postgress", "indexs", "xyzzy"}).
+			Return([]string{"postgres", "indexes", ""}, nil)
+
+		res, err := searchService.DidYouMean(ctx, "Postgress, indexs & xyzzy")
+		require.NoError(t, err)
+		assert.Equal(t, "postgres indexes xyzzy", res)
+	})
+
+	t.Run("nothing better", func(t *testing.T) {
+		mockSearchRepo.EXPECT().SimilarWords(gomock.Any(), []string{"postgres"}).Return([]string{"postgres"}, nil)
+
+		res, err := searchService.DidYouMean(ctx, "postgres")
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("no words", func(t *testing.T) {
+		res, err := searchService.DidYouMean(ctx, " ?! ")
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockSearchRepo.EXPECT().SimilarWords(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := searchService.DidYouMean(ctx, "postgress")
+		require.Error(t, err)
+	})
+}
+
+func TestSearchService_Facets(t *testing.T) {
+	ctx := context.TODO()
+
+	setup := func(t *testing.T) (*searchService, *mocks.MockSearchIndex) {
+		ctrl := gomock.NewController(t)
+		mockSearchIndex := mocks.NewMockSearchIndex(ctrl)
+		return &searchService{searchIndex: mockSearchIndex, cache: cache.NewMockCache()}, mockSearchIndex
+	}
+
+	t.Run("cached by the normalized filter", func(t *testing.T) {
+		searchService, mockSearchIndex := setup(t)
+
+		expected := &model.Facets{Tags: []*model.FacetCount{{Value: "go", Label: "Go", Count: 2}}}
+		mockSearchIndex.EXPECT().
+			Search(gomock.Any(), model.SearchIndexQuery{
+				Filter:     model.ArticleQuery{Query: "Go", TagsAny: []string{"web", "go"}, Page: 2},
+				FacetLimit: config.DefaultFacetLimit,
+			}).
+			Return(&model.SearchResult{Total: 2, Facets: expected}, nil)
+
+		res, err := searchService.Facets(ctx, model.ArticleQuery{Query: "Go", TagsAny: []string{"Web,go"}, Page: 2})
+		require.NoError(t, err)
+		assert.Equal(t, expected, res)
+
+		// The same matches on another page, in another order and case.
+		cached, err := searchService.Facets(ctx, model.ArticleQuery{Query: "go", TagsAny: []string{"go", "web"}, Sort: "title"})
+		require.NoError(t, err)
+		assert.Equal(t, expected, cached)
+	})
+
+	t.Run("different filters are cached apart", func(t *testing.T) {
+		searchService, mockSearchIndex := setup(t)
+
+		mockSearchIndex.EXPECT().Search(gomock.Any(), gomock.Any()).Return(&model.SearchResult{Facets: &model.Facets{}}, nil).Times(2)
+
+		_, err := searchService.Facets(ctx, model.ArticleQuery{Query: "go"})
+		require.NoError(t, err)
+		_, err = searchService.Facets(ctx, model.ArticleQuery{Query: "go", Category: "backend"})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		searchService, _ := setup(t)
+
+		_, err := searchService.Facets(ctx, model.ArticleQuery{Query: "go", CreatedFrom: "yesterday"})
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		searchService, mockSearchIndex := setup(t)
+
+		mockSearchIndex.EXPECT().Search(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := searchService.Facets(ctx, model.ArticleQuery{Query: "go"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewSeedService(t *testing.T) {
+	s := NewSeedService(nil, nil)
+	require.NotNil(t, s)
+}
+
+func TestSeedService_Seed(t *testing.T) {
+	ctx := context.TODO()
+
+	setup := func(t *testing.T) (*seedService, *mocks.MockArticleRepository, *mocks.MockAuthorRepository) {
+		ctrl := gomock.NewController(t)
+		mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+		mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
+		return &seedService{articleRepository: mockArticleRepo, authorRepository: mockAuthorRepo}, mockArticleRepo, mockAuthorRepo
+	}
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		res, err := service.Seed(ctx, model.SeedOptions{Authors: 1, Articles: 1})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+		assert.Nil(t, res)
+	})
+
+	t.Run("articles without authors", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		res, err := service.Seed(ctx, model.SeedOptions{Articles: 5, BatchSize: 10})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+		assert.Nil(t, res)
+	})
+
+	t.Run("success in batches", func(t *testing.T) {
+		service, mockArticleRepo, mockAuthorRepo := setup(t)
+
+		var authorBatches, articleBatches []int
+		authorIDs := map[string]bool{}
+		mockAuthorRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockAuthorRepo.EXPECT().
+			BulkCreate(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, authors []*model.Author) error {
+				authorBatches = append(authorBatches, len(authors))
+				for _, a := range authors {
+					authorIDs[a.ID.String()] = true
+				}
+				return nil
+			}).
+			Times(2)
+		mockArticleRepo.EXPECT().
+			BulkCreate(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, articles []*model.Article) error {
+				articleBatches = append(articleBatches, len(articles))
+				for _, a := range articles {
+					assert.True(t, authorIDs[a.AuthorID.String()])
+					assert.NotEmpty(t, a.Slug)
+					assert.NotEmpty(t, a.BodyHTML)
+					assert.NotEmpty(t, a.Excerpt)
+					assert.Positive(t, a.WordCount)
+					assert.False(t, a.CreatedAt.IsZero())
+				}
+				return nil
+			}).
+			Times(3)
+
+		res, err := service.Seed(ctx, model.SeedOptions{Authors: 3, Articles: 5, BatchSize: 2, Seed: 42})
+		require.NoError(t, err)
+		assert.Equal(t, &model.SeedResult{Authors: 3, Articles: 5}, res)
+		assert.Equal(t, []int{2, 1}, authorBatches)
+		assert.Equal(t, []int{2, 2, 1}, articleBatches)
+	})
+
+	t.Run("same seed yields same data", func(t *testing.T) {
+		run := func(seed int64) []*model.Article {
+			service, mockArticleRepo, mockAuthorRepo := setup(t)
+
+			var seeded []*model.Article
+			mockAuthorRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, nil)
+			mockAuthorRepo.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).Return(nil)
+			mockArticleRepo.EXPECT().
+				BulkCreate(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, articles []*model.Article) error {
+					seeded = articles
+					return nil
+				})
+
+			_, err := service.Seed(ctx, model.SeedOptions{Authors: 2, Articles: 3, BatchSize: 10, Seed: seed})
+			require.NoError(t, err)
+			return seeded
+		}
+
+		first, second, other := run(7), run(7), run(8)
+		assert.Equal(t, first, second)
+		assert.NotEqual(t, first[0].ID, other[0].ID)
+	})
+
+	t.Run("wipe deletes before seeding", func(t *testing.T) {
+		service, mockArticleRepo, mockAuthorRepo := setup(t)
+
+		gomock.InOrder(
+			mockArticleRepo.EXPECT().DeleteAll(gomock.Any()).Return(nil),
+			mockAuthorRepo.EXPECT().DeleteAll(gomock.Any()).Return(nil),
+			mockAuthorRepo.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).Return(nil),
+		)
+
+		res, err := service.Seed(ctx, model.SeedOptions{Authors: 1, BatchSize: 10, Wipe: true})
+		require.NoError(t, err)
+		assert.Equal(t, &model.SeedResult{Authors: 1}, res)
+	})
+
+	t.Run("wipe error", func(t *testing.T) {
+		service, mockArticleRepo, _ := setup(t)
+
+		mockArticleRepo.EXPECT().DeleteAll(gomock.Any()).Return(errors.New("db failure"))
+
+		res, err := service.Seed(ctx, model.SeedOptions{Authors: 1, BatchSize: 10, Wipe: true})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("bulk create error", func(t *testing.T) {
+		service, mockArticleRepo, mockAuthorRepo := setup(t)
+
+		mockAuthorRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockAuthorRepo.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).Return(nil)
+		mockArticleRepo.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).Return(errors.New("copy failed"))
+
+		res, err := service.Seed(ctx, model.SeedOptions{Authors: 1, Articles: 1, BatchSize: 10})
+		assert.EqualError(t, err, "copy failed")
+		assert.Nil(t, res)
+	})
+
+	t.Run("rerun of a seed is refused", func(t *testing.T) {
+		service, _, mockAuthorRepo := setup(t)
+
+		var seeded *model.Author
+		mockAuthorRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockAuthorRepo.EXPECT().
+			BulkCreate(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, authors []*model.Author) error {
+				seeded = authors[0]
+				return nil
+			})
+
+		_, err := service.Seed(ctx, model.SeedOptions{Authors: 1, BatchSize: 10, Seed: 3})
+		require.NoError(t, err)
+
+		mockAuthorRepo.EXPECT().FindByID(gomock.Any(), seeded.ID).Return(seeded, nil)
+
+		res, err := service.Seed(ctx, model.SeedOptions{Authors: 5, Articles: 5, BatchSize: 10, Seed: 3})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+		assert.Nil(t, res)
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		service, _, mockAuthorRepo := setup(t)
+
+		mockAuthorRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("db failure"))
+
+		res, err := service.Seed(ctx, model.SeedOptions{Authors: 1, BatchSize: 10})
+		assert.EqualError(t, err, "db failure")
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/sirupsen/logrus"
+)
+
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type sitemapService struct {
+	articleRepository model.ArticleRepository
+	authorRepository  model.AuthorRepository
+	cache             cache.Cache
+}
+
+// NewSitemapService lists the article pages followed by the author pages. Rendered
+// sitemaps are cached under model.SitemapKey, which article and author writes drop.
+func NewSitemapService(articleRepository model.ArticleRepository, authorRepository model.AuthorRepository, cache cache.Cache) model.SitemapMethodService {
+	return &sitemapService{
+		articleRepository: articleRepository,
+		authorRepository:  authorRepository,
+		cache:             cache,
+	}
+}
+
+func (s *sitemapService) Index(ctx context.Context) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "sitemapService.Index")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	return s.cached(ctx, model.SitemapKey+":index", func() ([]byte, error) {
+		articles, authors, err := s.count(ctx)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		pageSize := config.SitemapPageSize()
+		if articles+authors <= pageSize {
+			return s.urlset(ctx, 0, pageSize, articles)
+		}
+
+		pages := (articles + authors + pageSize - 1) / pageSize
+		var b bytes.Buffer
+		b.WriteString(xml.Header)
+		fmt.Fprintf(&b, `<sitemapindex xmlns="%s">`+"\n", sitemapNamespace)
+		for page := 1; page <= pages; page++ {
+			b.WriteString("  <sitemap><loc>")
+			xml.EscapeText(&b, []byte(fmt.Sprintf("%s/sitemap-%d.xml", config.SiteURL(), page)))
+			b.WriteString("</loc></sitemap>\n")
+		}
+		b.WriteString("</sitemapindex>\n")
+
+		return b.Bytes(), nil
+	})
+}
+
+func (s *sitemapService) Page(ctx context.Context, page int) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "sitemapService.Page")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"page": page,
+	})
+
+	if page < 1 {
+		err := errors.New(errors.ErrInvalidData, "sitemap pages start at 1")
+		log.Error(err)
+		return nil, err
+	}
+
+	return s.cached(ctx, fmt.Sprintf("%s:page=%d", model.SitemapKey, page), func() ([]byte, error) {
+		articles, authors, err := s.count(ctx)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		pageSize := config.SitemapPageSize()
+		if start := (page - 1) * pageSize; start >= articles+authors && page > 1 {
+			err := errors.New(errors.ErrRecordNotFound, "sitemap page not found")
+			log.Error(err)
+			return nil, err
+		}
+
+		return s.urlset(ctx, (page-1)*pageSize, pageSize, articles)
+	})
+}
+
+// cached returns the sitemap stored under key, rendering and storing it on a miss.
+func (s *sitemapService) cached(ctx context.Context, key string, render func() ([]byte, error)) ([]byte, error) {
+	var body []byte
+	if err := s.cache.Get(ctx, key, &body); err == nil {
+		return body, nil
+	}
+
+	body, err := render()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, key, body, config.RedisExpired()); err != nil {
+		logger.FromContext(ctx).Warn("failed to cache sitemap")
+	}
+
+	return body, nil
+}
+
+func (s *sitemapService) count(ctx context.Context) (articles, authors int, err error) {
+	if articles, err = s.articleRepository.Count(ctx); err != nil {
+		return 0, 0, err
+	}
+	if authors, err = s.authorRepository.Count(ctx); err != nil {
+		return 0, 0, err
+	}
+	return articles, authors, nil
+}
+
+// urlset renders up to size URLs starting at start, where the first articles URLs are
+// article pages and the rest author pages.
+func (s *sitemapService) urlset(ctx context.Context, start, size, articles int) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<urlset xmlns="%s">`+"\n", sitemapNamespace)
+
+	written := 0
+	writeURL := func(loc string, lastmod *time.Time) {
+		b.WriteString("  <url><loc>")
+		xml.EscapeText(&b, []byte(loc))
+		b.WriteString("</loc>")
+		if lastmod != nil {
+			fmt.Fprintf(&b, "<lastmod>%s</lastmod>", lastmod.UTC().Format(time.RFC3339))
+		}
+		b.WriteString("</url>\n")
+		written++
+	}
+
+	if start < articles {
+		err := s.articleRepository.StreamSitemap(ctx, start, min(size, articles-start), func(a *model.Article) error {
+			writeURL(config.ArticleURL(a.Slug), &a.UpdatedAt)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if remaining := size - written; remaining > 0 {
+		err := s.authorRepository.StreamSitemap(ctx, max(0, start-articles), remaining, func(a *model.SitemapAuthor) error {
+			writeURL(config.AuthorURL(a.ID), a.UpdatedAt)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b.WriteString("</urlset>\n")
+	return b.Bytes(), nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewSitemapService(t *testing.T) {
+	s := NewSitemapService(nil, nil, nil)
+	require.NotNil(t, s)
+}
+
+func TestSitemapService(t *testing.T) {
+	ctx := context.TODO()
+
+	setup := func(t *testing.T) (*sitemapService, *mocks.MockArticleRepository, *mocks.MockAuthorRepository) {
+		ctrl := gomock.NewController(t)
+		mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+		mockAuthorRepo := mocks.NewMockAuthorRepository(ctrl)
+		return &sitemapService{
+			articleRepository: mockArticleRepo,
+			authorRepository:  mockAuthorRepo,
+			cache:             cache.NewMockCache(),
+		}, mockArticleRepo, mockAuthorRepo
+	}
+
+	updated := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	authorID := uuid.New()
+
+	streamArticles := func(slugs ...string) func(context.Context, int, int, func(*model.Article) error) error {
+		return func(_ context.Context, _, _ int, fn func(*model.Article) error) error {
+			for _, slug := range slugs {
+				if err := fn(&model.Article{Slug: slug, UpdatedAt: updated}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	t.Run("single urlset with articles and authors, cached", func(t *testing.T) {
+		service, mockArticleRepo, mockAuthorRepo := setup(t)
+
+		mockArticleRepo.EXPECT().Count(gomock.Any()).Return(2, nil).Times(1)
+		mockAuthorRepo.EXPECT().Count(gomock.Any()).Return(2, nil).Times(1)
+		mockArticleRepo.EXPECT().StreamSitemap(gomock.Any(), 0, 2, gomock.Any()).DoAndReturn(streamArticles("first", "a&b"))
+		mockAuthorRepo.EXPECT().
+			StreamSitemap(gomock.Any(), 0, 50000-2, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ int, fn func(*model.SitemapAuthor) error) error {
+				require.NoError(t, fn(&model.SitemapAuthor{ID: authorID.String(), UpdatedAt: &updated}))
+				return fn(&model.SitemapAuthor{ID: "no-articles"})
+			})
+
+		body, err := service.Index(ctx)
+		require.NoError(t, err)
+
+		xml := string(body)
+		assert.Contains(t, xml, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+		assert.Contains(t, xml, "<url><loc>http://localhost:8000/api/v1/article/slug/first</loc><lastmod>2024-05-01T08:00:00Z</lastmod></url>")
+		assert.Contains(t, xml, "/api/v1/article/slug/a&amp;b</loc>")
+		assert.Contains(t, xml, "<loc>http://localhost:8000/api/v1/author/"+authorID.String()+"</loc><lastmod>2024-05-01T08:00:00Z</lastmod>")
+		assert.Contains(t, xml, "<url><loc>http://localhost:8000/api/v1/author/no-articles</loc></url>")
+
+		again, err := service.Index(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, body, again)
+	})
+
+	t.Run("index and pages over the page size", func(t *testing.T) {
+		viper.Set("sitemap.pageSize", 2)
+		t.Cleanup(func() { viper.Set("sitemap.pageSize", nil) })
+
+		service, mockArticleRepo, mockAuthorRepo := setup(t)
+
+		mockArticleRepo.EXPECT().Count(gomock.Any()).Return(3, nil).AnyTimes()
+		mockAuthorRepo.EXPECT().Count(gomock.Any()).Return(2, nil).AnyTimes()
+
+		index, err := service.Index(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, string(index), "<sitemapindex")
+		assert.Contains(t, string(index), "<sitemap><loc>http://localhost:8000/sitemap-3.xml</loc></sitemap>")
+		assert.NotContains(t, string(index), "sitemap-4.xml")
+
+		// Page 2 holds the last article and the first author.
+		mockArticleRepo.EXPECT().StreamSitemap(gomock.Any(), 2, 1, gomock.Any()).DoAndReturn(streamArticles("third"))
+		mockAuthorRepo.EXPECT().
+			StreamSitemap(gomock.Any(), 0, 1, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ int, fn func(*model.SitemapAuthor) error) error {
+				return fn(&model.SitemapAuthor{ID: "first-author"})
+			})
+
+		page, err := service.Page(ctx, 2)
+		require.NoError(t, err)
+		assert.Contains(t, string(page), "/article/slug/third")
+		assert.Contains(t, string(page), "/author/first-author")
+
+		// Page 3 holds authors only.
+		mockAuthorRepo.EXPECT().StreamSitemap(gomock.Any(), 1, 2, gomock.Any()).Return(nil)
+
+		_, err = service.Page(ctx, 3)
+		require.NoError(t, err)
+
+		_, err = service.Page(ctx, 4)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrRecordNotFound.Error())
+	})
+
+	t.Run("invalid page", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		_, err := service.Page(ctx, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), customErrors.ErrInvalidData.Error())
+	})
+
+	t.Run("count error", func(t *testing.T) {
+		service, mockArticleRepo, _ := setup(t)
+
+		mockArticleRepo.EXPECT().Count(gomock.Any()).Return(0, errors.New("db failure"))
+
+		body, err := service.Index(ctx)
+		assert.EqualError(t, err, "db failure")
+		assert.Nil(t, body)
+	})
+
+	t.Run("stream error", func(t *testing.T) {
+		service, mockArticleRepo, mockAuthorRepo := setup(t)
+
+		mockArticleRepo.EXPECT().Count(gomock.Any()).Return(1, nil)
+		mockAuthorRepo.EXPECT().Count(gomock.Any()).Return(0, nil)
+		mockArticleRepo.EXPECT().StreamSitemap(gomock.Any(), 0, 1, gomock.Any()).Return(errors.New("db failure"))
+
+		body, err := service.Page(ctx, 1)
+		assert.EqualError(t, err, "db failure")
+		assert.Nil(t, body)
+	})
+}
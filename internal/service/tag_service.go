@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"github.com/bagasss3/go-article/internal/logger"
+
+	"github.com/bagasss3/go-article/pkg/model"
+)
+
+type tagService struct {
+	tagRepository model.TagRepository
+}
+
+func NewTagService(tagRepository model.TagRepository) model.TagMethodService {
+	return &tagService{
+		tagRepository: tagRepository,
+	}
+}
+
+func (s *tagService) FindAll(ctx context.Context) ([]*model.Tag, error) {
+	tags, err := s.tagRepository.FindAll(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, err
+	}
+
+	if len(tags) <= 0 {
+		return []*model.Tag{}, nil
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewTagService(t *testing.T) {
+	s := NewTagService(nil)
+	require.NotNil(t, s)
+}
+
+func TestTagService_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	mockRepo := mocks.NewMockTagRepository(ctrl)
+
+	service := &tagService{tagRepository: mockRepo}
+
+	t.Run("success", func(t *testing.T) {
+		expected := []*model.Tag{{ID: uuid.New(), Name: "go", Slug: "go", ArticleCount: 2}}
+		mockRepo.EXPECT().FindAll(gomock.Any()).Return(expected, nil)
+
+		res, err := service.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("empty tags", func(t *testing.T) {
+		mockRepo.EXPECT().FindAll(gomock.Any()).Return(nil, nil)
+
+		res, err := service.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.Tag{}, res)
+	})
+
+	t.Run("error from repo", func(t *testing.T) {
+		mockRepo.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("repo error"))
+
+		res, err := service.FindAll(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/config"
+	"github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/infrastructure/tracing"
+	"github.com/bagasss3/go-article/internal/logger"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// viewKeyTTL keeps the visitors of a day counted through the next day, so the last
+// views of a day are still rolled up after midnight.
+const viewKeyTTL = 48 * time.Hour
+
+// trendingWindow is how far back a trending list looks and how fast views fade in it.
+type trendingWindow struct {
+	span     time.Duration
+	halfLife time.Duration
+}
+
+var trendingWindows = map[string]trendingWindow{
+	model.TrendingWindowDay:  {span: 24 * time.Hour, halfLife: 6 * time.Hour},
+	model.TrendingWindowWeek: {span: 7 * 24 * time.Hour, halfLife: 24 * time.Hour},
+}
+
+// trendingFields are read for trending articles: every field but the body.
+var trendingFields = slices.DeleteFunc(slices.Clone(model.ArticleFields), func(field string) bool {
+	return field == "body" || field == "format"
+})
+
+type viewService struct {
+	viewRepository    model.ViewRepository
+	articleRepository model.ArticleRepository
+	visits            cache.VisitCounter
+	cache             cache.Cache
+}
+
+// NewViewService counts unique visitors in visits; Flush rolls the counts up into the
+// database, where trending lists are scored from. Trending lists are cached for
+// config.TrendingCacheTTL.
+func NewViewService(viewRepository model.ViewRepository, articleRepository model.ArticleRepository, visits cache.VisitCounter, cache cache.Cache) model.ViewMethodService {
+	return &viewService{
+		viewRepository:    viewRepository,
+		articleRepository: articleRepository,
+		visits:            visits,
+		cache:             cache,
+	}
+}
+
+func (s *viewService) Record(ctx context.Context, articleID uuid.UUID, visitor string) error {
+	ctx, span := tracing.Start(ctx, "viewService.Record")
+	defer span.End()
+
+	key := fmt.Sprintf("%s:%s:%s", model.ViewCounterKey, time.Now().UTC().Format(time.DateOnly), articleID)
+	if err := s.visits.Visit(ctx, key, visitor, viewKeyTTL); err != nil {
+		logger.FromContext(ctx).WithField("article_id", articleID).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *viewService) Flush(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "viewService.Flush")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	counted, err := s.visits.Visits(ctx, model.ViewCounterKey+":")
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	views := make([]model.DailyViews, 0, len(counted))
+	for key, n := range counted {
+		day, id, ok := strings.Cut(key, ":")
+		if !ok {
+			log.WithField("key", key).Warn("skipping views of an unknown key")
+			continue
+		}
+		date, dateErr := time.Parse(time.DateOnly, day)
+		articleID, idErr := uuid.Parse(id)
+		if dateErr != nil || idErr != nil {
+			log.WithField("key", key).Warn("skipping views of an unknown key")
+			continue
+		}
+		views = append(views, model.DailyViews{ArticleID: articleID, Day: date, Views: n})
+	}
+
+	// The counters stay in Redis until they expire, so a failed rollup is retried by
+	// the next one.
+	if err := s.viewRepository.SaveDaily(ctx, views); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *viewService) Trending(ctx context.Context, query model.TrendingQuery) ([]*model.Article, error) {
+	ctx, span := tracing.Start(ctx, "viewService.Trending")
+	defer span.End()
+
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"query": query,
+	})
+
+	if query.Window == "" {
+		query.Window = model.TrendingWindowDay
+	}
+	window, ok := trendingWindows[query.Window]
+	if !ok {
+		err := errors.New(errors.ErrInvalidData, fmt.Sprintf("window must be one of %s", strings.Join(model.TrendingWindows, ", ")))
+		log.Error(err)
+		return nil, err
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = config.DefaultTrendingLimit
+	}
+	query.Limit = min(query.Limit, config.MaxTrendingLimit)
+
+	fields, err := validateFields(query.Fields, model.ArticleFields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if slices.Contains(fields, "body") || slices.Contains(fields, "format") {
+		err := errors.New(errors.ErrInvalidData, "trending articles are listed without their body")
+		log.Error(err)
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s:%s:%d", model.TrendingKey, query.Window, query.Limit)
+
+	var cached []*model.Article
+	if err := s.cache.Get(ctx, key, &cached); err == nil {
+		return cached, nil
+	}
+
+	ids, err := s.viewRepository.Trending(ctx, time.Now().UTC().Add(-window.span), window.halfLife, query.Limit)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// The articles come back in the order of ids, highest score first, without those
+	// deleted since they were viewed.
+	articles, err := s.articleRepository.FindByIDs(ctx, ids, trendingFields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, key, articles, config.TrendingCacheTTL()); err != nil {
+		log.Warn("failed to cache trending articles")
+	}
+
+	return articles, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bagasss3/go-article/internal/config"
+	customErrors "github.com/bagasss3/go-article/internal/errors"
+	"github.com/bagasss3/go-article/internal/infrastructure/cache"
+	"github.com/bagasss3/go-article/internal/mocks"
+	"github.com/bagasss3/go-article/pkg/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewViewService(t *testing.T) {
+	s := NewViewService(nil, nil, nil, nil)
+	require.NotNil(t, s)
+}
+
+func TestViewService(t *testing.T) {
+	ctx := context.TODO()
+
+	type kit struct {
+		service     *viewService
+		viewRepo    *mocks.MockViewRepository
+		articleRepo *mocks.MockArticleRepository
+		cache       *cache.MockCache
+	}
+	setup := func(t *testing.T) kit {
+		ctrl := gomock.NewController(t)
+		k := kit{
+			viewRepo:    mocks.NewMockViewRepository(ctrl),
+			articleRepo: mocks.NewMockArticleRepository(ctrl),
+			cache:       cache.NewMockCache(),
+		}
+		k.service = &viewService{
+			viewRepository:    k.viewRepo,
+			articleRepository: k.articleRepo,
+			visits:            k.cache,
+			cache:             k.cache,
+		}
+		return k
+	}
+
+	articleID := uuid.New()
+	today, _ := time.Parse(time.DateOnly, time.Now().UTC().Format(time.DateOnly))
+
+	t.Run("record and flush count unique visitors", func(t *testing.T) {
+		k := setup(t)
+
+		require.NoError(t, k.service.Record(ctx, articleID, "user:1"))
+		require.NoError(t, k.service.Record(ctx, articleID, "user:1"))
+		require.NoError(t, k.service.Record(ctx, articleID, "ip:203.0.113.7"))
+		require.NoError(t, k.cache.Visit(ctx, model.ViewCounterKey+":not-a-day:x", "user:1", time.Hour))
+
+		k.viewRepo.EXPECT().
+			SaveDaily(gomock.Any(), []model.DailyViews{{ArticleID: articleID, Day: today, Views: 2}}).
+			Return(nil)
+
+		require.NoError(t, k.service.Flush(ctx))
+	})
+
+	t.Run("record error", func(t *testing.T) {
+		k := setup(t)
+		k.cache.SetShouldError = true
+
+		require.Error(t, k.service.Record(ctx, articleID, "user:1"))
+	})
+
+	t.Run("flush error", func(t *testing.T) {
+		k := setup(t)
+
+		require.NoError(t, k.service.Record(ctx, articleID, "user:1"))
+		k.viewRepo.EXPECT().SaveDaily(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+		require.Error(t, k.service.Flush(ctx))
+	})
+
+	t.Run("trending is read in one query and cached without bodies", func(t *testing.T) {
+		k := setup(t)
+
+		hottest, gone := uuid.New(), uuid.New()
+		k.viewRepo.EXPECT().
+			Trending(gomock.Any(), gomock.Any(), 24*time.Hour, config.DefaultTrendingLimit).
+			DoAndReturn(func(_ context.Context, since time.Time, _ time.Duration, _ int) ([]uuid.UUID, error) {
+				assert.WithinDuration(t, time.Now().Add(-7*24*time.Hour), since, time.Minute)
+				return []uuid.UUID{hottest, articleID, gone}, nil
+			})
+		k.articleRepo.EXPECT().
+			FindByIDs(gomock.Any(), []uuid.UUID{hottest, articleID, gone}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ []uuid.UUID, fields []string) ([]*model.Article, error) {
+				assert.NotContains(t, fields, "body")
+				assert.Contains(t, fields, "tags")
+				return []*model.Article{{ID: hottest, Title: "Hottest"}, {ID: articleID, Title: "Hot"}}, nil
+			})
+
+		query := model.TrendingQuery{Window: model.TrendingWindowWeek}
+		res, err := k.service.Trending(ctx, query)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, "Hottest", res[0].Title)
+		assert.Equal(t, "Hot", res[1].Title)
+
+		cached, err := k.service.Trending(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, res[0].ID, cached[0].ID)
+	})
+
+	t.Run("trending error", func(t *testing.T) {
+		k := setup(t)
+
+		k.viewRepo.EXPECT().Trending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]uuid.UUID{articleID}, nil)
+		k.articleRepo.EXPECT().FindByIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := k.service.Trending(ctx, model.TrendingQuery{})
+		require.Error(t, err)
+	})
+
+	t.Run("trending defaults to the last day", func(t *testing.T) {
+		k := setup(t)
+
+		k.viewRepo.EXPECT().
+			Trending(gomock.Any(), gomock.Any(), 6*time.Hour, config.MaxTrendingLimit).
+			Return(nil, nil)
+		k.articleRepo.EXPECT().FindByIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*model.Article{}, nil)
+
+		res, err := k.service.Trending(ctx, model.TrendingQuery{Limit: 1000})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("trending rejects bad input", func(t *testing.T) {
+		k := setup(t)
+
+		_, err := k.service.Trending(ctx, model.TrendingQuery{Window: "1y"})
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+
+		_, err = k.service.Trending(ctx, model.TrendingQuery{Fields: []string{"title,body"}})
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+
+		_, err = k.service.Trending(ctx, model.TrendingQuery{Fields: []string{"nope"}})
+		assertErrorType(t, err, customErrors.ErrInvalidData)
+	})
+}
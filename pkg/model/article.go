@@ -10,23 +10,123 @@ import (
 var (
 	ArticleKey     string = "article"
 	CacheableLimit int    = 10
+	// RelatedKey prefixes the cached related articles, one set per article.
+	RelatedKey string = "related"
 )
 
+// Representations of Article.Body selectable with the format query parameter.
+const (
+	ArticleFormatMarkdown string = "markdown"
+	ArticleFormatHTML     string = "html"
+	ArticleFormatText     string = "text"
+)
+
+// Views of the article list selectable with the view query parameter. The summary
+// view leaves out the body.
+const (
+	ArticleViewFull    string = "full"
+	ArticleViewSummary string = "summary"
+)
+
+// ArticleSorts are the accepted values of the sort query parameter; a leading dash sorts
+// descending. Without a sort the newest articles come first.
+var ArticleSorts = []string{
+	"created_at", "-created_at", "title", "-title", "updated_at", "-updated_at", "relevance",
+}
+
+// ArticleLanguages are the accepted article languages, as ISO 639-1 codes. Each is
+// searched with the text search configuration the article_search_config database
+// function maps it to.
+var ArticleLanguages = []string{"en", "id"}
+
+// ArticleFields are the response fields that can be requested with the fields query parameter.
+var ArticleFields = []string{
+	"id", "author_id", "author", "title", "slug", "body", "format", "excerpt",
+	"word_count", "reading_time", "comment_count", "view_count", "reactions", "category_id", "tags", "created_at", "updated_at",
+	"highlights", "language", "translation_id", "translations",
+}
+
+// ArticleReadOptions controls how articles are represented in responses.
+type ArticleReadOptions struct {
+	Format string `query:"format"`
+	// Fields limits the response to a subset of ArticleFields; empty means every field.
+	// Values may be repeated or comma separated.
+	Fields []string `query:"fields"`
+	// Languages are the languages the reader prefers, most preferred first. A single
+	// article is read in the first of them it is translated to. They are set from the
+	// lang query parameter and the Accept-Language header, and do not apply to lists.
+	Languages []string `json:"-"`
+}
+
 type ArticleQuery struct {
-	Query  string `query:"query"`
-	Author string `query:"author"`
-	Page   int    `query:"page"`
-	Limit  int    `query:"limit"`
+	Query     string   `query:"query"`
+	Author    string   `query:"author"`
+	AuthorIDs []string `query:"author_id"`
+	Category  string   `query:"category"`
+	Tag       string   `query:"tag"`
+	TagsAny   []string `query:"tags_any"`
+	TagsAll   []string `query:"tags_all"`
+	Lang      string   `query:"lang"`
+	// CreatedFrom and CreatedTo bound created_at (inclusive), formatted as 2006-01-02T15:04.
+	CreatedFrom string `query:"created_from"`
+	CreatedTo   string `query:"created_to"`
+	// BookmarkedBy limits the listing to the bookmarks of a user. It is set from the
+	// caller's identity, never from the query string.
+	BookmarkedBy string `json:"-"`
+	Sort         string `query:"sort"`
+	Page         int    `query:"page"`
+	Limit        int    `query:"limit"`
+	View         string `query:"view"`
+
+	ArticleReadOptions
+}
+
+type RelatedQuery struct {
+	Limit  int      `query:"limit"`
+	Fields []string `query:"fields"`
 }
 
 type Article struct {
-	ID        uuid.UUID `json:"id"`
-	AuthorID  uuid.UUID `json:"author_id"`
-	Title     string    `json:"title"`
-	Body      string    `json:"body"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          uuid.UUID `json:"id"`
+	AuthorID    uuid.UUID `json:"author_id"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	Body        string    `json:"body,omitempty"`
+	BodyHTML    string    `json:"body_html,omitempty"`
+	Format      string    `json:"format,omitempty"`
+	Excerpt     string    `json:"excerpt"`
+	WordCount   int       `json:"word_count"`
+	ReadingTime int       `json:"reading_time"`
+	// CommentCount counts the approved comments of the article.
+	CommentCount int `json:"comment_count"`
+	// ViewCount counts the unique daily visitors of the article, as of the last view
+	// rollup.
+	ViewCount int64 `json:"view_count"`
+	// Reactions counts the reactions of the article by type.
+	Reactions  map[string]int `json:"reactions"`
+	CategoryID *uuid.UUID     `json:"category_id"`
+	Language   string         `json:"language"`
+	// TranslationID is shared by the translations of an article: it is the ID of the
+	// one written first.
+	TranslationID uuid.UUID `json:"translation_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	Author string   `json:"author"`
+	Tags   []string `json:"tags"`
+	// Highlights are the matching fragments of the title and body of a search result.
+	Highlights map[string][]string `json:"highlights,omitempty"`
+	// Translations are the other languages the article is available in. They are only
+	// set on single-article reads.
+	Translations []*ArticleTranslation `json:"translations,omitempty"`
+}
 
-	Author string `json:"author"`
+// ArticleTranslation is an article in one of its languages.
+type ArticleTranslation struct {
+	ID       uuid.UUID `json:"id"`
+	Language string    `json:"language"`
+	Title    string    `json:"title"`
+	Slug     string    `json:"slug"`
 }
 
 type CachedArticles struct {
@@ -35,17 +135,92 @@ type CachedArticles struct {
 }
 
 type CreateArticleRequest struct {
-	AuthorID string `json:"author_id" validate:"required,uuid"`
-	Title    string `json:"title" validate:"required,min=3,max=255"`
-	Body     string `json:"body" validate:"required"`
+	AuthorID   string   `json:"author_id" validate:"required,uuid"`
+	Title      string   `json:"title" validate:"required,min=3,max=255"`
+	Body       string   `json:"body" validate:"required"`
+	Summary    string   `json:"summary" validate:"omitempty,max=500"`
+	CategoryID string   `json:"category_id" validate:"omitempty,uuid"`
+	Tags       []string `json:"tags" validate:"omitempty,dive,required,max=50"`
+	// Language is one of ArticleLanguages, English by default.
+	Language string `json:"language"`
+	// TranslationOf makes the article a translation of another article, in a language
+	// that article is not yet translated to.
+	TranslationOf string `json:"translation_of" validate:"omitempty,uuid"`
+}
+
+type UpdateArticleRequest struct {
+	Title      string   `json:"title" validate:"required,min=3,max=255"`
+	Body       string   `json:"body" validate:"required"`
+	Summary    string   `json:"summary" validate:"omitempty,max=500"`
+	CategoryID string   `json:"category_id" validate:"omitempty,uuid"`
+	Tags       []string `json:"tags" validate:"omitempty,dive,required,max=50"`
+}
+
+// ArticleSaved is the type of the event of an article being created or updated.
+const ArticleSaved = "saved"
+
+// ArticleEvent tells that an article was written.
+type ArticleEvent struct {
+	Type      string
+	ArticleID uuid.UUID
+}
+
+// ArticleEventHandler is told of article writes once they are committed. It cannot
+// fail the write, so it reports its own errors.
+type ArticleEventHandler interface {
+	HandleArticleEvent(ctx context.Context, event ArticleEvent)
 }
 
 type ArticleMethodService interface {
 	FindAll(ctx context.Context, filter ArticleQuery) ([]*Article, int, error)
+	FindByID(ctx context.Context, id string, opts ArticleReadOptions) (*Article, error)
+	// FindBySlug also resolves slugs an article used before a title change; the
+	// returned canonical slug differs from slug in that case.
+	FindBySlug(ctx context.Context, slug string, opts ArticleReadOptions) (article *Article, canonicalSlug string, err error)
 	Create(ctx context.Context, req *CreateArticleRequest) (*Article, error)
+	Update(ctx context.Context, id string, req *UpdateArticleRequest) (*Article, error)
+	// Import creates an article for every row, reporting rows that fail instead of
+	// stopping at them.
+	Import(ctx context.Context, rows ArticleImportReader) (*ArticleImportResult, error)
+	// Export writes every article matching the filters, oldest first, without holding
+	// them all in memory. Sorting, paging, fields and views do not apply.
+	Export(ctx context.Context, filter ArticleQuery, w ArticleExportWriter) error
+	// FindRelated lists the articles most related to an article, without their bodies.
+	FindRelated(ctx context.Context, id string, query RelatedQuery) ([]*Article, error)
 }
 
 type ArticleRepository interface {
 	FindAll(ctx context.Context, filter ArticleQuery) ([]*Article, int, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Article, error)
+	// FindByIDs returns the articles of ids that exist, in the order of ids, reading
+	// only the columns of fields.
+	FindByIDs(ctx context.Context, ids []uuid.UUID, fields []string) ([]*Article, error)
+	FindBySlug(ctx context.Context, slug string) (*Article, error)
+	// FindTranslations returns every language of the article with translationID,
+	// the article of that ID included.
+	FindTranslations(ctx context.Context, translationID uuid.UUID) ([]*ArticleTranslation, error)
+	// FindSlugRedirect returns the current slug of the article that previously used slug.
+	FindSlugRedirect(ctx context.Context, slug string) (string, error)
+	// FindSlugs returns the slugs equal to base or base with a numeric suffix that are
+	// used, now or historically, by articles other than excludeID.
+	FindSlugs(ctx context.Context, base string, excludeID uuid.UUID) ([]string, error)
+	// Stream calls fn for every article matching the filters, oldest first, reading them
+	// in pages. It stops at the first error fn returns.
+	Stream(ctx context.Context, filter ArticleQuery, fn func(*Article) error) error
+	// StreamSitemap calls fn with the id, slug and update time of limit articles, oldest
+	// first, starting at offset.
+	StreamSitemap(ctx context.Context, offset, limit int, fn func(*Article) error) error
+	Count(ctx context.Context) (int, error)
+	// FindRelated returns up to limit other articles, without their bodies, ranked by
+	// the tags they share with the article, a common author and the similarity of their
+	// text to its title. Results are cached until an article is written.
+	FindRelated(ctx context.Context, id uuid.UUID, limit int) ([]*Article, error)
+	// Create uses article.CreatedAt when it is set, the current time otherwise.
 	Create(ctx context.Context, article *Article) (*Article, error)
+	Update(ctx context.Context, article *Article) (*Article, error)
+	// BulkCreate inserts fully prepared articles, keeping their IDs, slugs and
+	// timestamps, in one COPY.
+	BulkCreate(ctx context.Context, articles []*Article) error
+	// DeleteAll removes every article together with its tags links and slug history.
+	DeleteAll(ctx context.Context) error
 }
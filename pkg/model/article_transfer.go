@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Formats of article imports and exports, selectable with the format query parameter.
+const (
+	TransferFormatNDJSON string = "ndjson"
+	TransferFormatCSV    string = "csv"
+	// TransferFormatMarkdown is a zip archive, or a directory for the CLI, of Markdown
+	// files with YAML front matter.
+	TransferFormatMarkdown string = "markdown"
+)
+
+var TransferFormats = []string{TransferFormatNDJSON, TransferFormatCSV, TransferFormatMarkdown}
+
+// ArticleImportRow is one article of an import. The author is matched by name and
+// created when no author has that name.
+type ArticleImportRow struct {
+	// Row is the line of the row in NDJSON and CSV sources and the position of the
+	// file in Markdown sources, where Source names the file.
+	Row    int    `json:"-" yaml:"-"`
+	Source string `json:"-" yaml:"-"`
+
+	Author     string     `json:"author" yaml:"author"`
+	Title      string     `json:"title" yaml:"title"`
+	Body       string     `json:"body" yaml:"-"`
+	Summary    string     `json:"summary" yaml:"summary"`
+	CategoryID string     `json:"category_id" yaml:"category_id"`
+	Tags       []string   `json:"tags" yaml:"tags"`
+	Language   string     `json:"language" yaml:"language"`
+	CreatedAt  *time.Time `json:"created_at" yaml:"created_at"`
+}
+
+// ArticleImportError reports a row that was not imported.
+type ArticleImportError struct {
+	Row     int    `json:"row"`
+	Source  string `json:"source,omitempty"`
+	Message string `json:"error"`
+}
+
+func (e *ArticleImportError) Error() string {
+	if e.Source != "" {
+		return fmt.Sprintf("%s: %s", e.Source, e.Message)
+	}
+	return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+}
+
+type ArticleImportResult struct {
+	Imported int                  `json:"imported"`
+	Failed   int                  `json:"failed"`
+	Errors   []ArticleImportError `json:"errors"`
+}
+
+// ArticleImportReader yields the rows of an import source. Next returns io.EOF after the
+// last row. A malformed row is returned as an *ArticleImportError and the following
+// call continues with the next row; any other error, such as a source that cannot be
+// parsed at all, ends the import.
+type ArticleImportReader interface {
+	Next() (*ArticleImportRow, error)
+}
+
+// ArticleExportWriter encodes exported articles; Close completes the output.
+type ArticleExportWriter interface {
+	Write(article *Article) error
+	Close() error
+}
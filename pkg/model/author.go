@@ -8,6 +8,9 @@ import (
 
 var (
 	AuthorKey string = "author"
+
+	// AuthorFields are the response fields that can be requested with the fields query parameter.
+	AuthorFields = []string{"id", "name"}
 )
 
 type Author struct {
@@ -15,16 +18,30 @@ type Author struct {
 	Name string    `json:"name"`
 }
 
+// AuthorReadOptions controls how authors are represented in responses.
+type AuthorReadOptions struct {
+	Fields []string `query:"fields"`
+}
+
 type CreateAuthorRequest struct {
 	Name string `json:"name" validate:"required,min=3,max=100"`
 }
 
 type AuthorRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*Author, error)
+	// FindByName returns an author with exactly this name, or nil when there is none.
+	FindByName(ctx context.Context, name string) (*Author, error)
+	// StreamSitemap calls fn for limit authors ordered by id, starting at offset.
+	StreamSitemap(ctx context.Context, offset, limit int, fn func(*SitemapAuthor) error) error
+	Count(ctx context.Context) (int, error)
 	Create(ctx context.Context, author *Author) (*Author, error)
+	// BulkCreate inserts authors, keeping their IDs, in one COPY.
+	BulkCreate(ctx context.Context, authors []*Author) error
+	// DeleteAll removes every author and, by cascade, every article.
+	DeleteAll(ctx context.Context) error
 }
 
 type AuthorMethodService interface {
-	FindByID(ctx context.Context, id string) (*Author, error)
+	FindByID(ctx context.Context, id string, opts AuthorReadOptions) (*Author, error)
 	Create(ctx context.Context, req *CreateAuthorRequest) (*Author, error)
 }
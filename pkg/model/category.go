@@ -0,0 +1,61 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	CategoryKey string = "category"
+)
+
+type Category struct {
+	ID        uuid.UUID  `json:"id"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	Children []*Category `json:"children,omitempty"`
+}
+
+type CategoryArticles struct {
+	Category    *Category   `json:"category"`
+	Breadcrumbs []*Category `json:"breadcrumbs"`
+	Articles    []*Article  `json:"articles"`
+}
+
+type CreateCategoryRequest struct {
+	ParentID string `json:"parent_id" validate:"omitempty,uuid"`
+	Name     string `json:"name" validate:"required,min=2,max=100"`
+	Slug     string `json:"slug" validate:"omitempty,max=100"`
+}
+
+type UpdateCategoryRequest struct {
+	ParentID string `json:"parent_id" validate:"omitempty,uuid"`
+	Name     string `json:"name" validate:"required,min=2,max=100"`
+	Slug     string `json:"slug" validate:"omitempty,max=100"`
+}
+
+type CategoryRepository interface {
+	FindAll(ctx context.Context) ([]*Category, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Category, error)
+	FindBySlug(ctx context.Context, slug string) (*Category, error)
+	// FindAncestors returns the path from the root category down to id, inclusive.
+	FindAncestors(ctx context.Context, id uuid.UUID) ([]*Category, error)
+	HasChildren(ctx context.Context, id uuid.UUID) (bool, error)
+	Create(ctx context.Context, category *Category) (*Category, error)
+	Update(ctx context.Context, category *Category) (*Category, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type CategoryMethodService interface {
+	FindAll(ctx context.Context) ([]*Category, error)
+	FindByID(ctx context.Context, id string) (*Category, error)
+	FindArticles(ctx context.Context, slug string, filter ArticleQuery) (*CategoryArticles, int, error)
+	Create(ctx context.Context, req *CreateCategoryRequest) (*Category, error)
+	Update(ctx context.Context, id string, req *UpdateCategoryRequest) (*Category, error)
+	Delete(ctx context.Context, id string) error
+}
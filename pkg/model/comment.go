@@ -0,0 +1,87 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Moderation states of a comment. Only approved comments are listed and counted.
+const (
+	CommentStatusPending  string = "pending"
+	CommentStatusApproved string = "approved"
+	CommentStatusSpam     string = "spam"
+)
+
+type Comment struct {
+	ID        uuid.UUID  `json:"id"`
+	ArticleID uuid.UUID  `json:"article_id"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	UserID    string     `json:"user_id"`
+	Body      string     `json:"body"`
+	Status    string     `json:"status"`
+	// Depth is 0 for a top-level comment and one more than its parent for a reply.
+	Depth     int       `json:"depth"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Replies []*Comment `json:"replies"`
+}
+
+// CommentQuery pages the threads of an article. Cursor is the next_cursor of the
+// previous page.
+type CommentQuery struct {
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+// CommentCursor is the position after which the next page of threads starts.
+type CommentCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CommentPage holds top-level comments with their replies nested below them.
+type CommentPage struct {
+	Comments   []*Comment `json:"comments"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+type CreateCommentRequest struct {
+	ParentID string `json:"parent_id" validate:"omitempty,uuid"`
+	Body     string `json:"body" validate:"required,max=5000"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+type ModerateCommentRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending approved spam"`
+}
+
+type CommentRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*Comment, error)
+	// FindThreads returns up to limit approved top-level comments of an article, oldest
+	// first, starting after the cursor when one is given.
+	FindThreads(ctx context.Context, articleID uuid.UUID, after *CommentCursor, limit int) ([]*Comment, error)
+	// FindReplies returns the approved replies below the comments with rootIDs, at any
+	// depth, oldest first. Replies to comments that are not approved are left out.
+	FindReplies(ctx context.Context, rootIDs []uuid.UUID) ([]*Comment, error)
+	// Create, Update and Delete keep the comment count of the article in step.
+	Create(ctx context.Context, comment *Comment) (*Comment, error)
+	Update(ctx context.Context, comment *Comment) (*Comment, error)
+	// Delete removes a comment together with its replies.
+	Delete(ctx context.Context, comment *Comment) error
+}
+
+type CommentMethodService interface {
+	FindByArticle(ctx context.Context, articleID string, query CommentQuery) (*CommentPage, error)
+	Create(ctx context.Context, articleID, userID string, req *CreateCommentRequest) (*Comment, error)
+	// Update and Delete are limited to the user who wrote the comment.
+	Update(ctx context.Context, id, userID string, req *UpdateCommentRequest) (*Comment, error)
+	Delete(ctx context.Context, id, userID string) error
+	// Moderate sets the status of a comment; only configured moderators may.
+	Moderate(ctx context.Context, id, userID string, req *ModerateCommentRequest) (*Comment, error)
+}
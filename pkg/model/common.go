@@ -17,6 +17,12 @@ type JsonResponseTotal struct {
 	Data       any    `json:"data"`
 }
 
+// JsonResponseSearch is a JsonResponseTotal of search results with their SearchMeta.
+type JsonResponseSearch struct {
+	JsonResponseTotal
+	SearchMeta
+}
+
 type JsonResponsError struct {
 	RequestId        string `json:"request_id"`
 	StatusCode       int    `json:"status_code"`
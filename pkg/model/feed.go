@@ -0,0 +1,46 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	FeedKey string = "feed"
+)
+
+// Feed formats, served as /feed.rss, /feed.atom and /feed.json.
+const (
+	FeedFormatRSS  string = "rss"
+	FeedFormatAtom string = "atom"
+	FeedFormatJSON string = "json"
+)
+
+var FeedFormats = []string{FeedFormatRSS, FeedFormatAtom, FeedFormatJSON}
+
+// Content of feed entries selectable with the content query parameter. Excerpts are
+// the default.
+const (
+	FeedContentExcerpt string = "excerpt"
+	FeedContentFull    string = "full"
+)
+
+// FeedQuery selects a feed of the newest articles, optionally of one author or tag.
+type FeedQuery struct {
+	Format   string
+	AuthorID string
+	Tag      string
+	Content  string
+}
+
+// Feed is a rendered feed together with the validators of its HTTP response.
+type Feed struct {
+	Body         []byte    `json:"body"`
+	ContentType  string    `json:"content_type"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+type FeedMethodService interface {
+	Render(ctx context.Context, query FeedQuery) (*Feed, error)
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ReactionCounterKey prefixes the Redis hashes of reaction counts not yet written
+	// to the database, one per article.
+	ReactionCounterKey string = "reaction_counts"
+)
+
+// Reactions a reader can leave on an article, each at most once.
+const (
+	ReactionLike       string = "like"
+	ReactionLove       string = "love"
+	ReactionInsightful string = "insightful"
+	ReactionFunny      string = "funny"
+)
+
+var ReactionTypes = []string{ReactionLike, ReactionLove, ReactionInsightful, ReactionFunny}
+
+type ReactionRepository interface {
+	// Add records a reaction and reports whether the user had not left it already.
+	Add(ctx context.Context, articleID uuid.UUID, userID, reaction string) (bool, error)
+	// Remove deletes a reaction and reports whether the user had left it.
+	Remove(ctx context.Context, articleID uuid.UUID, userID, reaction string) (bool, error)
+	// ApplyCounts adds deltas, per article and reaction, to the stored totals. Articles
+	// deleted in the meantime are skipped.
+	ApplyCounts(ctx context.Context, deltas map[uuid.UUID]map[string]int64) error
+	// AddBookmark and RemoveBookmark are idempotent.
+	AddBookmark(ctx context.Context, articleID uuid.UUID, userID string) error
+	RemoveBookmark(ctx context.Context, articleID uuid.UUID, userID string) error
+}
+
+type ReactionMethodService interface {
+	React(ctx context.Context, articleID, userID, reaction string) error
+	Unreact(ctx context.Context, articleID, userID, reaction string) error
+	Bookmark(ctx context.Context, articleID, userID string) error
+	Unbookmark(ctx context.Context, articleID, userID string) error
+	// Bookmarks lists the articles the user bookmarked, filtered like any listing.
+	Bookmarks(ctx context.Context, userID string, filter ArticleQuery) ([]*Article, int, error)
+	// Flush writes the counted reactions to the database. Counts shown on articles lag
+	// behind reactions until it runs.
+	Flush(ctx context.Context) error
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// FacetKey prefixes the cached facets of searches.
+	FacetKey string = "facets"
+)
+
+// Search backends, chosen with search.backend.
+const (
+	SearchBackendPostgres = "postgres"
+	SearchBackendBleve    = "bleve"
+)
+
+type SuggestQuery struct {
+	Q     string `query:"q"`
+	Limit int    `query:"limit"`
+}
+
+// Suggestions are the type-ahead matches of a search prefix.
+type Suggestions struct {
+	Articles []*ArticleSuggestion `json:"articles"`
+	Authors  []*AuthorSuggestion  `json:"authors"`
+}
+
+type ArticleSuggestion struct {
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+	Slug  string    `json:"slug"`
+}
+
+type AuthorSuggestion struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// FacetCount counts the matching articles that share a value: an author id, a tag
+// slug or a month (2006-01).
+type FacetCount struct {
+	Value string `json:"value"`
+	// Label is the display name of an author or tag.
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+// Facets break the matches of a search down, each list by count descending except
+// Months, which is newest first.
+type Facets struct {
+	Authors []*FacetCount `json:"authors"`
+	Tags    []*FacetCount `json:"tags"`
+	Months  []*FacetCount `json:"months"`
+}
+
+// SearchMeta accompanies the results of an article search.
+type SearchMeta struct {
+	// DidYouMean is a respelling of a query that found nothing, when one is known.
+	DidYouMean string  `json:"did_you_mean,omitempty"`
+	Facets     *Facets `json:"facets,omitempty"`
+}
+
+// SearchDocument is what a SearchIndex holds of an article.
+type SearchDocument struct {
+	ID       uuid.UUID
+	AuthorID uuid.UUID
+	Author   string
+	Title    string
+	Body     string
+	// Language selects how the title and body are analyzed.
+	Language string
+	// Categories are the slugs of the category of the article and of its ancestors.
+	Categories []string
+	Tags       []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type SearchIndexQuery struct {
+	// Filter selects and sorts the hits. Its paging, fields and representation do
+	// not apply, and BookmarkedBy is not supported.
+	Filter ArticleQuery
+	Offset int
+	// Limit is the number of hits to return; zero counts the matches only.
+	Limit int
+	// FacetLimit, when positive, asks for facets of that many values each.
+	FacetLimit int
+	// Highlight asks for the matching fragments of the title and body of each hit.
+	Highlight bool
+}
+
+type SearchHit struct {
+	ID uuid.UUID
+	// Highlights are fragments of the title and body with the matches wrapped in
+	// <mark> tags, by field.
+	Highlights map[string][]string
+}
+
+type SearchResult struct {
+	Hits   []*SearchHit
+	Total  int
+	Facets *Facets
+}
+
+// SearchIndex finds articles by text. It is kept up to date with article writes,
+// and can be rebuilt from the database by emptying it and indexing every article.
+type SearchIndex interface {
+	// Index adds documents, replacing those with the same ids.
+	Index(ctx context.Context, docs ...*SearchDocument) error
+	Delete(ctx context.Context, ids ...uuid.UUID) error
+	Search(ctx context.Context, query SearchIndexQuery) (*SearchResult, error)
+	// Reset removes every document.
+	Reset(ctx context.Context) error
+	Close() error
+}
+
+// SearchIndexMethodService keeps the search index in step with the articles.
+type SearchIndexMethodService interface {
+	ArticleEventHandler
+	// Reindex rebuilds the search index from the database and returns the number of
+	// articles indexed.
+	Reindex(ctx context.Context) (int, error)
+}
+
+type SearchRepository interface {
+	// Suggest returns up to limit articles and authors whose title or name has a word
+	// starting with prefix, those starting with it first. prefix is lower case.
+	Suggest(ctx context.Context, prefix string, limit int) (*Suggestions, error)
+	// SimilarWords returns, for each word, the most similar word of any article, or ""
+	// when none is similar enough.
+	SimilarWords(ctx context.Context, words []string) ([]string, error)
+	// RefreshWords rebuilds the words SimilarWords picks from.
+	RefreshWords(ctx context.Context) error
+}
+
+type SearchMethodService interface {
+	Suggest(ctx context.Context, query SuggestQuery) (*Suggestions, error)
+	// DidYouMean respells query with words found in articles, or returns "" when it
+	// has nothing better to offer.
+	DidYouMean(ctx context.Context, query string) (string, error)
+	// RefreshWords picks up the words of articles written since the last refresh.
+	RefreshWords(ctx context.Context) error
+	// Facets counts the articles matching filter, validated like a listing, per
+	// author, tag and month. Paging, sorting and representation do not apply.
+	Facets(ctx context.Context, filter ArticleQuery) (*Facets, error)
+}
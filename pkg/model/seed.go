@@ -0,0 +1,23 @@
+package model
+
+import "context"
+
+// SeedOptions describes the fake data created by the seed command.
+type SeedOptions struct {
+	Authors   int
+	Articles  int
+	BatchSize int
+	// Seed makes runs reproducible: the same seed yields the same IDs and content.
+	Seed int64
+	// Wipe deletes every author and article before seeding.
+	Wipe bool
+}
+
+type SeedResult struct {
+	Authors  int `json:"authors"`
+	Articles int `json:"articles"`
+}
+
+type SeedMethodService interface {
+	Seed(ctx context.Context, opts SeedOptions) (*SeedResult, error)
+}
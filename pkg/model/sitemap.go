@@ -0,0 +1,25 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	SitemapKey string = "sitemap"
+)
+
+// SitemapAuthor is an author page of the sitemap, last modified with their newest
+// article update.
+type SitemapAuthor struct {
+	ID        string
+	UpdatedAt *time.Time
+}
+
+type SitemapMethodService interface {
+	// Index returns /sitemap.xml: every URL while they fit in one file, an index of the
+	// numbered pages otherwise.
+	Index(ctx context.Context) ([]byte, error)
+	// Page returns the URLs of the numbered sitemap page, starting at 1.
+	Page(ctx context.Context, page int) ([]byte, error)
+}
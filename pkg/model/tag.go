@@ -0,0 +1,26 @@
+package model
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+var (
+	TagKey string = "tag"
+)
+
+type Tag struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	ArticleCount int       `json:"article_count"`
+}
+
+type TagRepository interface {
+	FindAll(ctx context.Context) ([]*Tag, error)
+}
+
+type TagMethodService interface {
+	FindAll(ctx context.Context) ([]*Tag, error)
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ViewCounterKey prefixes the Redis HyperLogLogs of unique visitors, one per day
+	// and article: view_counts:<2006-01-02>:<article id>.
+	ViewCounterKey string = "view_counts"
+	// TrendingKey prefixes the cached trending lists.
+	TrendingKey string = "trending"
+)
+
+// Windows of the trending list.
+const (
+	TrendingWindowDay  string = "24h"
+	TrendingWindowWeek string = "7d"
+)
+
+var TrendingWindows = []string{TrendingWindowDay, TrendingWindowWeek}
+
+// DailyViews counts the unique visitors of an article on a day (UTC).
+type DailyViews struct {
+	ArticleID uuid.UUID
+	Day       time.Time
+	Views     int64
+}
+
+type TrendingQuery struct {
+	// Window is one of TrendingWindows; empty means TrendingWindowDay.
+	Window string   `query:"window"`
+	Limit  int      `query:"limit"`
+	Fields []string `query:"fields"`
+}
+
+type ViewRepository interface {
+	// SaveDaily stores daily view counts and refreshes the view totals of their
+	// articles. A count never lowers the one already stored for the same day. Articles
+	// deleted in the meantime are skipped.
+	SaveDaily(ctx context.Context, views []DailyViews) error
+	// Trending returns the ids of the articles viewed since the given day, the highest
+	// scored first. Views score less the older their day, halving every halfLife.
+	Trending(ctx context.Context, since time.Time, halfLife time.Duration, limit int) ([]uuid.UUID, error)
+}
+
+type ViewMethodService interface {
+	// Record counts visitor as a viewer of the article today; repeated views by the
+	// same visitor count once.
+	Record(ctx context.Context, articleID uuid.UUID, visitor string) error
+	// Flush rolls the counted views up into the database. View counts shown on
+	// articles lag behind until it runs.
+	Flush(ctx context.Context) error
+	// Trending lists the articles viewed most within query.Window, favouring recent
+	// views, without their bodies.
+	Trending(ctx context.Context, query TrendingQuery) ([]*Article, error)
+}
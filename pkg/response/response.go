@@ -26,6 +26,20 @@ func ResponseInterfaceTotal(c echo.Context, statusServer int, res any, msg strin
 	return nil
 }
 
+func ResponseInterfaceSearch(c echo.Context, statusServer int, res any, msg string, total int, meta model.SearchMeta) error {
+	c.JSON(statusServer, model.JsonResponseSearch{
+		JsonResponseTotal: model.JsonResponseTotal{
+			RequestId:  c.Response().Header().Get(echo.HeaderXRequestID),
+			StatusCode: statusServer,
+			Message:    msg,
+			Data:       res,
+			Total:      total,
+		},
+		SearchMeta: meta,
+	})
+	return nil
+}
+
 func ResponseInterfaceError(c echo.Context, statusServer int, res any, msg string) error {
 	c.JSON(statusServer, model.JsonResponsError{
 		RequestId:        c.Response().Header().Get(echo.HeaderXRequestID),